@@ -1,34 +1,400 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/term"
 
 	"github.com/divijg19/Grimoire/internal/adapters"
+	"github.com/divijg19/Grimoire/internal/buildinfo"
+	"github.com/divijg19/Grimoire/internal/engine"
+	"github.com/divijg19/Grimoire/internal/ports"
 	"github.com/divijg19/Grimoire/internal/ui/cli"
 	"github.com/divijg19/Grimoire/internal/ui/tui"
 )
 
+// version and revision are set at link time, e.g.
+//
+//	go build -ldflags "-X main.version=v1.2.0 -X main.revision=$(git rev-parse HEAD)" ./cmd/grimoire
+var (
+	version  = "dev"
+	revision = ""
+)
+
 func main() {
+	os.Exit(run())
+}
+
+// run is the program proper. It returns the exit code rather than calling
+// os.Exit so its deferred cleanup, like flushing the action log, still runs.
+func run() int {
+	buildinfo.Version, buildinfo.Revision = version, revision
+
 	useCLI := flag.Bool("cli", false, "run legacy line-based CLI instead of fullscreen TUI")
+	seed := flag.Int64("seed", 0, "RNG seed for a new game (default: time-based)")
+	theme := flag.String("theme", "", "TUI color theme: default, monochrome, solarized")
+	logPath := flag.String("log", "", "append a JSON line per command to this file")
+	script := flag.String("script", "", "run commands from this file, save, then exit")
+	interactive := flag.Bool("interactive", false, "with --script: continue interactively afterwards")
+	strict := flag.Bool("strict", false, "with --script: stop at the first failing line")
+	daily := flag.Bool("daily", false, "play today's shared challenge; the save file is not touched")
+	noAutosave := flag.Bool("no-autosave", false, "only save on `save` and `exit`")
+	rngKind := flag.String("rng", "math", "random source: math (seeded, replayable) or crypto")
+	initiative := flag.Bool("initiative", false, "let faster enemies strike first in combat")
+	compress := flag.Bool("compress", false, "gzip the save as grimoire.json.gz")
+	saveFlag := flag.String("save", "", "save file (default: $GRIMOIRE_SAVE, else grimoire.json in the user config directory)")
+	variants := flag.Bool("variants", false, "roll enemy variants like a scarred goblin, with small stat shifts")
+	splitRNG := flag.Bool("split-rng", false, "draw enemy selection and combat from separate seeded streams")
+	encounterRate := flag.Int("encounter-rate", engine.EncounterRate, "percent of explores that meet an enemy (lower is safer, higher grindier)")
+	haggle := flag.Bool("haggle", false, "allow `sell haggle`: a chance at a better price, or the merchant walks away")
+	noHints := flag.Bool("no-hints", false, "turn off the tips shown to new players")
+	antiFarm := flag.Bool("anti-farm", false, "shrink XP and gold from hunting and exploring enemies far below your level")
+	pity := flag.Bool("pity", false, "each missed drop raises that item's next drop chance until it drops")
+	affixes := flag.Bool("affixes", false, "equipment picked up may roll blessed (+1) or cursed (-1, stuck until a remove curse scroll)")
+	configPath := flag.String("config", adapters.DefaultTunablesPath, "JSON file overriding engine tunables (xp_percent, encounter_rate, ...)")
+	showVersion := flag.Bool("version", false, "print the version and save schema, then exit")
 	flag.Parse()
 
-	store := adapters.NewJSONStore("grimoire.json")
-	rng := adapters.NewMathRNG()
+	if args := flag.Args(); *showVersion || (len(args) > 0 && args[0] == "version") {
+		fmt.Println(buildinfo.String())
+		return 0
+	}
 
-	state, err := store.Load()
+	tunables, err := adapters.LoadTunables(*configPath)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return 2
+	}
+	engine.ApplyTunables(tunables)
+
+	engine.Initiative = *initiative
+	engine.EnemyVariants = *variants
+	engine.ItemAffixes = *affixes
+	engine.Haggling = *haggle
+	engine.LootPity = *pity
+	engine.AntiFarm = *antiFarm
+	engine.Tutorial = !*noHints
+	flag.Visit(func(f *flag.Flag) {
+		// An explicit flag beats the config file.
+		if f.Name == "encounter-rate" {
+			engine.EncounterRate = max(0, min(*encounterRate, 100))
+		}
+	})
+
+	switch *rngKind {
+	case "math":
+	case "crypto":
+		// crypto/rand has no seed, so seeded and shared runs can't use it.
+		if *daily || *seed != 0 {
+			fmt.Println("Error: --rng crypto can't be seeded; drop --daily and --seed, or use --rng math")
+			return 2
+		}
+	default:
+		fmt.Printf("Error: unknown --rng %q (want math or crypto)\n", *rngKind)
+		return 2
+	}
+
+	if *daily && *script != "" {
+		fmt.Println("Error: --daily is played by hand; drop --script")
+		return 2
+	}
+
+	if args := flag.Args(); len(args) > 0 && (args[0] == "diff" || args[0] == "compare") {
+		return runDiff(args[1:])
+	}
+	if args := flag.Args(); len(args) > 0 && args[0] == "migrate" {
+		return runMigrate(args[1:])
+	}
+	if args := flag.Args(); len(args) > 0 && args[0] == "arena" {
+		resolved, err := adapters.ResolveSavePath(*saveFlag, os.Getenv, os.UserConfigDir)
+		if err != nil {
+			fmt.Println("Warning: save location:", err)
+		}
+		return runArena(args[1:], adapters.ArenaRecordsPath(resolved))
+	}
+
+	if *theme != "" {
+		if err := tui.SetTheme(*theme); err != nil {
+			fmt.Println("Warning:", err)
+		}
+	}
+
+	// Piped or CI runs would leave the TUI waiting on a terminal forever.
+	interactiveRun := *script == "" || *interactive
+	if interactiveRun && !*useCLI && !tui.Usable(term.IsTerminal) {
+		fmt.Println("No terminal detected; using the line-based CLI.")
+		*useCLI = true
+	}
+
+	if *daily {
+		runDaily(time.Now(), *useCLI)
+		return 0
+	}
+
+	resolved, err := adapters.ResolveSavePath(*saveFlag, os.Getenv, os.UserConfigDir)
+	if err != nil {
+		fmt.Println("Warning: save location:", err)
+	}
+	savePath, loadPath := savePaths(resolved, *compress)
+	_, statErr := os.Stat(loadPath)
+	jsonStore := adapters.NewJSONStore(savePath)
+
+	state, err := adapters.NewJSONStore(loadPath).Load()
+	if errors.Is(err, adapters.ErrNewerSchema) {
+		fmt.Printf("Error: %s: %v. Update grimoire to play it; the save was left as is.\n", loadPath, err)
+		return 1
+	}
 	if err != nil {
 		fmt.Println("Warning: load issue, continuing with defaults")
 	}
 
+	// A profile only shapes brand-new games, never an existing save.
+	if errors.Is(statErr, os.ErrNotExist) {
+		applyProfile(state)
+	}
+
+	var (
+		rng   ports.RNG
+		store ports.Store
+	)
+	if *rngKind == "crypto" {
+		// Crypto draws can't be replayed, so forget any saved stream.
+		state.Meta.RNGSeed, state.Meta.RNGDraws, state.Meta.RNGStreams = 0, 0, nil
+		rng, store = adapters.NewCryptoRNG(), jsonStore
+	} else {
+		// Resume the saved RNG stream so a seeded game replays identically.
+		s, draws := state.Meta.RNGSeed, state.Meta.RNGDraws
+		if s != 0 && *seed != 0 && *seed != s {
+			fmt.Printf("Warning: --seed %d ignored; this save resumes its seed %d stream. Pass --save with a new file to play seed %d.\n", *seed, s, *seed)
+		}
+		if s == 0 {
+			s, draws = *seed, 0
+			if s == 0 {
+				s = time.Now().UnixNano()
+			}
+		}
+		// A save made with split streams stays split.
+		var stream ports.ReplayableRNG
+		if *splitRNG || len(state.Meta.RNGStreams) > 0 {
+			stream = adapters.ResumeStreamSet(s, draws, state.Meta.RNGStreams)
+		} else {
+			stream = adapters.ResumeStreamRNG(s, draws)
+		}
+		rng, store = stream, adapters.NewRNGTrackingStore(jsonStore, stream)
+	}
+
+	defer engine.TrackEventCounts()()
+
+	if *logPath != "" {
+		actionLog, err := adapters.OpenActionLog(*logPath, adapters.DefaultActionLogMaxBytes)
+		if err != nil {
+			fmt.Println("Warning: action log disabled:", err)
+		} else {
+			defer actionLog.Close()
+		}
+	}
+
+	if *script != "" {
+		code := runScript(cli.NewApp(state, store, rng), *script, *strict)
+		if !*interactive {
+			return code
+		}
+	}
+
 	if *useCLI {
 		app := cli.NewApp(state, store, rng)
+		app.SetAutosave(!*noAutosave)
 		app.Run()
-		return
+		return 0
 	}
 
 	app := tui.NewApp(state, store, rng)
+	app.SetAutosave(!*noAutosave)
+	app.SetSetup(errors.Is(statErr, os.ErrNotExist))
 	if err := app.Run(); err != nil {
 		fmt.Println("Error:", err)
+		return 1
+	}
+	return 0
+}
+
+// savePaths picks the save file: path.gz with --compress or when it is the
+// only save present, otherwise path. When both a plain and a gzipped save
+// exist, as after switching --compress on or off, the newer one is loaded,
+// so a stale copy is never resumed.
+func savePaths(path string, compress bool) (save, load string) {
+	plain := strings.TrimSuffix(path, ".gz")
+	gzipped := plain + ".gz"
+	compress = compress || plain != path
+	plainInfo, plainErr := os.Stat(plain)
+	gzInfo, gzErr := os.Stat(gzipped)
+
+	save = plain
+	if compress || (plainErr != nil && gzErr == nil) {
+		save = gzipped
+	}
+	switch {
+	case plainErr == nil && gzErr == nil:
+		if plainInfo.ModTime().After(gzInfo.ModTime()) {
+			return save, plain
+		}
+		if gzInfo.ModTime().After(plainInfo.ModTime()) {
+			return save, gzipped
+		}
+		return save, save
+	case plainErr == nil:
+		return save, plain
+	case gzErr == nil:
+		return save, gzipped
+	}
+	return save, save
+}
+
+// applyProfile applies grimoire.profile.json, if present, to a new game.
+func applyProfile(state *engine.State) {
+	profile, err := adapters.LoadProfile(adapters.DefaultProfilePath)
+	if err != nil {
+		fmt.Println("Warning: profile ignored:", err)
+		return
+	}
+	for _, w := range profile.Warnings {
+		fmt.Println("Warning: profile:", w)
+	}
+	profile.Apply(state)
+}
+
+// runDaily plays the daily challenge for now's day on a fresh in-memory
+// state under the standard rules, then prints the score.
+func runDaily(now time.Time, useCLI bool) {
+	if engine.UseStandardRules() {
+		fmt.Println("The daily challenge uses the standard rules; --config and rule flags are ignored.")
+	}
+	state := engine.DefaultState()
+	store := adapters.NewMemoryStore(state)
+	rng := adapters.NewSeededMathRNG(engine.DailySeed(now))
+	fmt.Printf("Daily challenge %s. Your save file is not touched.\n", engine.DailyDate(now))
+
+	if useCLI {
+		cli.NewApp(&state, store, rng).Run()
+	} else if err := tui.NewApp(&state, store, rng).Run(); err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Printf("Daily challenge %s score: %d\n", engine.DailyDate(now), engine.ChallengeScore(&state))
+}
+
+// runScript implements --script, returning the process exit code.
+func runScript(app *cli.App, path string, strict bool) int {
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return 1
+	}
+	defer f.Close()
+
+	if err := app.RunScript(f, strict); err != nil {
+		fmt.Println("Error:", err)
+		return 1
+	}
+	return 0
+}
+
+// runDiff implements `grimoire diff <fileA> <fileB>`.
+func runDiff(args []string) int {
+	if len(args) != 2 {
+		fmt.Println("Usage: grimoire diff <fileA> <fileB>")
+		return 2
+	}
+	a, err := adapters.ReadStateFile(args[0])
+	if err != nil {
+		fmt.Println("Error:", err)
+		return 1
+	}
+	b, err := adapters.ReadStateFile(args[1])
+	if err != nil {
+		fmt.Println("Error:", err)
+		return 1
+	}
+	cli.RenderDiff(args[0], args[1], engine.DiffStates(a, b))
+	return 0
+}
+
+// runMigrate implements `grimoire migrate <dir>`.
+func runMigrate(args []string) int {
+	if len(args) != 1 {
+		fmt.Println("Usage: grimoire migrate <dir>")
+		return 2
+	}
+	results, err := adapters.MigrateDir(args[0])
+	if err != nil {
+		fmt.Println("Error:", err)
+		return 1
+	}
+	if len(results) == 0 {
+		fmt.Println("No grimoire*.json saves in", args[0])
+		return 0
+	}
+
+	failed := 0
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			failed++
+			fmt.Printf("failed    %s: %v\n", r.Path, r.Err)
+		case r.Upgraded():
+			fmt.Printf("upgraded  %s (schema %d -> %d, original in %s)\n", r.Path, r.From, engine.SchemaVersion, r.Backup)
+		default:
+			fmt.Printf("current   %s\n", r.Path)
+		}
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// runArena implements `grimoire arena [seed]`: one arena run on a fixed
+// seed, recording the best score per seed next to the save.
+func runArena(args []string, recordsPath string) int {
+	if len(args) > 1 {
+		fmt.Println("Usage: grimoire arena [seed]")
+		return 2
+	}
+	seed := engine.ArenaDefaultSeed
+	if len(args) == 1 {
+		n, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			fmt.Println("Usage: grimoire arena [seed]")
+			return 2
+		}
+		seed = n
+	}
+
+	res := engine.RunArena(seed, adapters.NewSeededMathRNG(seed))
+	fmt.Printf("Arena seed %d: %d waves cleared, %d gold, score %d\n", seed, res.Waves, res.Gold, res.Score())
+
+	if !engine.ArenaStandard() {
+		fmt.Println("Custom rules are in effect (--config or --initiative); this run isn't recorded.")
+		return 0
+	}
+	records, err := adapters.LoadArenaRecords(recordsPath)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return 1
+	}
+	if !records.Record(res) {
+		fmt.Printf("Best on this seed: %d\n", records[seed])
+		return 0
+	}
+	if err := adapters.SaveArenaRecords(recordsPath, records); err != nil {
+		fmt.Println("Error:", err)
+		return 1
 	}
+	fmt.Println("New best on this seed!")
+	return 0
 }
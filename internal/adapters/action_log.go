@@ -0,0 +1,119 @@
+package adapters
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+// DefaultActionLogMaxBytes is the size at which an action log rotates.
+const DefaultActionLogMaxBytes = 1 << 20
+
+// ActionLog appends one JSON record per command to a file: the command,
+// the events it emitted, each with its engine.CueFor cue, and a snapshot of
+// the player afterwards. It hooks
+// into the engine's observers, so actions need no logging code.
+type ActionLog struct {
+	Path     string
+	MaxBytes int64
+
+	file    *os.File
+	pending []loggedEvent
+	detach  []func()
+}
+
+type loggedEvent struct {
+	Type string       `json:"type"`
+	Cue  string       `json:"cue,omitempty"`
+	Data engine.Event `json:"data"`
+}
+
+type actionRecord struct {
+	Time     string        `json:"time"`
+	Command  string        `json:"command"`
+	Error    string        `json:"error,omitempty"`
+	Events   []loggedEvent `json:"events"`
+	Player   engine.Player `json:"player"`
+	Commands int           `json:"command_count"`
+}
+
+// OpenActionLog opens (or creates) the log at path for appending and
+// subscribes it to engine events and commands.
+func OpenActionLog(path string, maxBytes int64) (*ActionLog, error) {
+	l := &ActionLog{Path: path, MaxBytes: maxBytes}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	l.detach = append(l.detach,
+		engine.Subscribe(l.observe),
+		engine.SubscribeCommands(l.record),
+	)
+	return l, nil
+}
+
+// Close unsubscribes the log and closes the file.
+func (l *ActionLog) Close() error {
+	for _, d := range l.detach {
+		d()
+	}
+	l.detach = nil
+	return l.file.Close()
+}
+
+func (l *ActionLog) open() error {
+	f, err := os.OpenFile(l.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	l.file = f
+	return nil
+}
+
+func (l *ActionLog) observe(e engine.Event) {
+	l.pending = append(l.pending, loggedEvent{Type: e.EventType(), Cue: engine.CueFor(e), Data: e})
+}
+
+// record writes the events gathered since the last command. Each record is
+// a single write, so the file is current after every command.
+func (l *ActionLog) record(line string, state *engine.State, cmdErr error) {
+	rec := actionRecord{
+		Time:     time.Now().UTC().Format(time.RFC3339),
+		Command:  line,
+		Events:   l.pending,
+		Player:   state.Player,
+		Commands: state.Meta.CommandCount,
+	}
+	if rec.Events == nil {
+		rec.Events = []loggedEvent{}
+	}
+	if cmdErr != nil {
+		rec.Error = cmdErr.Error()
+	}
+	l.pending = nil
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+	l.rotateIfFull()
+	_, _ = l.file.Write(append(data, '\n'))
+}
+
+// rotateIfFull moves a log past MaxBytes to Path+".1" and starts afresh.
+func (l *ActionLog) rotateIfFull() {
+	if l.MaxBytes <= 0 {
+		return
+	}
+	info, err := l.file.Stat()
+	if err != nil || info.Size() < l.MaxBytes {
+		return
+	}
+	_ = l.file.Close()
+	_ = os.Rename(l.Path, l.Path+".1")
+	if err := l.open(); err != nil {
+		// Fall back to appending to the rotated file rather than losing records.
+		l.file, _ = os.OpenFile(l.Path+".1", os.O_WRONLY|os.O_APPEND, 0644)
+	}
+}
@@ -0,0 +1,121 @@
+package adapters
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+func TestActionLog_WritesOneRecordPerCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "actions.log")
+	log, err := OpenActionLog(path, DefaultActionLogMaxBytes)
+	if err != nil {
+		t.Fatalf("OpenActionLog returned error: %v", err)
+	}
+
+	state := engine.DefaultState()
+	rng := NewSeededMathRNG(7)
+	commands := []string{"explore", "rest 1", "hunt 1", "rest 999"}
+	for _, cmd := range commands {
+		_, _ = engine.RunCommand(&state, cmd, rng)
+	}
+	_, _ = engine.RunCommand(&state, "dance", rng) // unknown: not logged
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	defer f.Close()
+
+	var records []map[string]any
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, rec)
+	}
+
+	if len(records) != len(commands) {
+		t.Fatalf("expected %d records, got %d", len(commands), len(records))
+	}
+	for i, rec := range records {
+		if rec["command"] != commands[i] {
+			t.Fatalf("record %d: expected command %q, got %v", i, commands[i], rec["command"])
+		}
+		if _, ok := rec["player"].(map[string]any); !ok {
+			t.Fatalf("record %d: missing player snapshot", i)
+		}
+	}
+	events := records[1]["events"].([]any)
+	if len(events) == 0 {
+		t.Fatalf("expected rest events in record")
+	}
+	for _, e := range events {
+		if cue, _ := e.(map[string]any)["cue"].(string); cue == "" {
+			t.Fatalf("expected a cue on every logged event, got %v", e)
+		}
+	}
+	if records[3]["error"] == nil {
+		t.Fatalf("expected failed command to carry its error")
+	}
+}
+
+func TestActionLog_RotatesPastSizeCap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "actions.log")
+	log, err := OpenActionLog(path, 64)
+	if err != nil {
+		t.Fatalf("OpenActionLog returned error: %v", err)
+	}
+	defer log.Close()
+
+	state := engine.DefaultState()
+	state.Player.SP = 100
+	for i := 0; i < 3; i++ {
+		_, _ = engine.RunCommand(&state, "rest 1", NewSeededMathRNG(1))
+	}
+
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Fatalf("expected rotated log: %v", err)
+	}
+}
+
+func TestActionLog_IgnoresSimulatedFights(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "actions.log")
+	log, err := OpenActionLog(path, DefaultActionLogMaxBytes)
+	if err != nil {
+		t.Fatalf("OpenActionLog returned error: %v", err)
+	}
+
+	state := engine.DefaultState()
+	state.Player.Gold = 0
+	if _, err := engine.Simulate(&state, "goblin", 20, NewSeededMathRNG(7)); err != nil {
+		t.Fatalf("Simulate: %v", err)
+	}
+	_, _ = engine.RunCommand(&state, "train hp", NewSeededMathRNG(7))
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	var rec struct {
+		Events []json.RawMessage `json:"events"`
+	}
+	if err := json.Unmarshal(data, &rec); err != nil {
+		t.Fatalf("invalid record %q: %v", data, err)
+	}
+	if len(rec.Events) != 0 {
+		t.Fatalf("expected the train record to carry no simulated events, got %d", len(rec.Events))
+	}
+}
@@ -0,0 +1,47 @@
+package adapters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+// DefaultArenaRecordsPath holds the best arena score per seed, apart from
+// any save.
+const DefaultArenaRecordsPath = "grimoire.arena.json"
+
+// ArenaRecordsPath is where the arena records live for the save at
+// savePath: beside it, so they follow --save and GRIMOIRE_SAVE.
+func ArenaRecordsPath(savePath string) string {
+	return filepath.Join(filepath.Dir(savePath), DefaultArenaRecordsPath)
+}
+
+// LoadArenaRecords reads the arena records file. A missing file yields no
+// records.
+func LoadArenaRecords(path string) (engine.ArenaRecords, error) {
+	records := engine.ArenaRecords{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	}
+	if err != nil {
+		return records, err
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return engine.ArenaRecords{}, fmt.Errorf("arena records %s: %w", path, err)
+	}
+	return records, nil
+}
+
+// SaveArenaRecords writes records to path.
+func SaveArenaRecords(path string, records engine.ArenaRecords) error {
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
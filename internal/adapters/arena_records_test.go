@@ -0,0 +1,36 @@
+package adapters
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+func TestArenaRecords_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grimoire.arena.json")
+
+	records, err := LoadArenaRecords(path)
+	if err != nil || len(records) != 0 {
+		t.Fatalf("expected no records before the first run, got %v, %v", records, err)
+	}
+	records.Record(engine.ArenaResult{Seed: 42, Waves: 3, Gold: 60})
+	if err := SaveArenaRecords(path, records); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	loaded, err := LoadArenaRecords(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if loaded[42] != 63 {
+		t.Fatalf("expected seed 42's best of 63, got %v", loaded)
+	}
+}
+
+func TestArenaRecordsPath_SitsBesideTheSave(t *testing.T) {
+	save := filepath.Join("saves", "hero.json")
+	if got := ArenaRecordsPath(save); got != filepath.Join("saves", DefaultArenaRecordsPath) {
+		t.Fatalf("expected records beside %s, got %s", save, got)
+	}
+}
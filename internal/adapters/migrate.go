@@ -0,0 +1,137 @@
+package adapters
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+// ================================
+// Schema Migrations
+// ================================
+
+// migrations upgrade a decoded save one schema version at a time:
+// migrations[n] turns a version-n save into a version-n+1 one.
+var migrations = map[int]func(*engine.State){
+	// Saves from before MaxSP existed keep whatever SP they had banked.
+	1: func(state *engine.State) {
+		if state.Player.MaxSP == 0 {
+			state.Player.MaxSP = max(state.Player.SP, engine.DefaultMaxSP)
+		}
+	},
+}
+
+// saveSchema is the schema a save was written with; unversioned saves
+// predate versioning and count as 1.
+func saveSchema(state *engine.State) int {
+	return max(state.Meta.SchemaVersion, 1)
+}
+
+// ErrNewerSchema reports a save written by a newer build. Such saves are
+// refused, and left where they are, rather than guessed at.
+var ErrNewerSchema = errors.New("save is from a newer build")
+
+// migrateState runs the migration chain from the save's schema up to the
+// current one. A save from a newer build is refused with ErrNewerSchema.
+func migrateState(state *engine.State) error {
+	from := saveSchema(state)
+	if from > engine.SchemaVersion {
+		return fmt.Errorf("%w: schema %d, this build reads up to %d", ErrNewerSchema, from, engine.SchemaVersion)
+	}
+	for v := from; v < engine.SchemaVersion; v++ {
+		if step, ok := migrations[v]; ok {
+			step(state)
+		}
+	}
+	state.Meta.SchemaVersion = engine.SchemaVersion
+	return nil
+}
+
+// ================================
+// Bulk Migration
+// ================================
+
+// MigrationResult reports what MigrateDir did with one save.
+type MigrationResult struct {
+	Path   string
+	From   int
+	Backup string // where the original was copied; "" if left untouched
+	Err    error
+}
+
+// Upgraded reports whether the save was rewritten at the current schema.
+func (r MigrationResult) Upgraded() bool {
+	return r.Err == nil && r.Backup != ""
+}
+
+// MigrateDir upgrades every grimoire*.json save in dir to the current
+// schema, skipping the profile, config and arena files kept beside saves.
+// Each original is copied to a ".v<N>.bak" file before being rewritten;
+// saves already current are skipped and unreadable ones are reported and
+// left alone.
+func MigrateDir(dir string) ([]MigrationResult, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "grimoire*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	results := make([]MigrationResult, 0, len(paths))
+	for _, path := range paths {
+		if isSidecar(path) {
+			continue
+		}
+		results = append(results, migrateFile(path))
+	}
+	return results, nil
+}
+
+func migrateFile(path string) MigrationResult {
+	res := MigrationResult{Path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	raw, err := gunzipSave(data)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+
+	// Peek at the version before decodeState migrates it away.
+	var peek engine.State
+	if err := json.Unmarshal(raw, &peek); err != nil {
+		res.Err = err
+		return res
+	}
+	res.From = saveSchema(&peek)
+
+	state, _, err := decodeState(raw)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	if res.From == engine.SchemaVersion {
+		return res
+	}
+
+	backup := fmt.Sprintf("%s.v%d.bak", path, res.From)
+	if err := os.WriteFile(backup, data, 0644); err != nil {
+		res.Err = err
+		return res
+	}
+	store := &JSONStore{Path: path, Compress: bytes.HasPrefix(data, gzipMagic)}
+	if err := store.Save(state); err != nil {
+		res.Err = err
+		return res
+	}
+	res.Backup = backup
+	return res
+}
@@ -0,0 +1,85 @@
+package adapters
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+func TestMigrateDir_UpgradesMixedVersions(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+		return path
+	}
+
+	legacy := write("grimoire.json", `{
+  "player": {"name": "Old", "class": "Adventurer", "gold": 5, "hp": 100, "max_hp": 100, "sp": 14, "level": 1, "xp": 0},
+  "meta": {"location": "Starting Village"}
+}`)
+	current := filepath.Join(dir, "grimoire_current.json")
+	state := engine.DefaultState()
+	if err := (&JSONStore{Path: current}).Save(&state); err != nil {
+		t.Fatalf("save current: %v", err)
+	}
+	corrupt := write("grimoire_broken.json", `{"player": {`)
+	write("notes.json", `not a save`)
+	write(DefaultProfilePath, `{"wins": 1}`)
+	write(DefaultTunablesPath, `{"xp_percent": 100}`)
+	write(DefaultArenaRecordsPath, `{"1": 4}`)
+
+	results, err := MigrateDir(dir)
+	if err != nil {
+		t.Fatalf("MigrateDir: %v", err)
+	}
+	byPath := map[string]MigrationResult{}
+	for _, r := range results {
+		byPath[r.Path] = r
+	}
+	if len(byPath) != 3 {
+		t.Fatalf("expected 3 saves scanned, got %+v", results)
+	}
+
+	r := byPath[legacy]
+	if !r.Upgraded() || r.From != 1 {
+		t.Fatalf("legacy save not upgraded: %+v", r)
+	}
+	if _, err := os.Stat(r.Backup); err != nil {
+		t.Fatalf("legacy backup missing: %v", err)
+	}
+	got, err := ReadStateFile(legacy)
+	if err != nil {
+		t.Fatalf("read upgraded save: %v", err)
+	}
+	if got.Meta.SchemaVersion != engine.SchemaVersion || got.Player.MaxSP != 14 {
+		t.Fatalf("upgraded save: schema %d, max SP %d", got.Meta.SchemaVersion, got.Player.MaxSP)
+	}
+
+	if r := byPath[current]; r.Err != nil || r.Upgraded() {
+		t.Fatalf("current save should be left as is: %+v", r)
+	}
+
+	r = byPath[corrupt]
+	if r.Err == nil || r.Upgraded() {
+		t.Fatalf("corrupt save should be reported: %+v", r)
+	}
+	if data, _ := os.ReadFile(corrupt); string(data) != `{"player": {` {
+		t.Fatalf("corrupt save was modified: %q", data)
+	}
+	if backups, _ := filepath.Glob(filepath.Join(dir, "grimoire_broken.json.*")); len(backups) != 0 {
+		t.Fatalf("corrupt save should not be backed up: %v", backups)
+	}
+}
+
+func TestDecodeState_RefusesNewerSchema(t *testing.T) {
+	payload := `{"player": {"name": "Future", "hp": 10, "max_hp": 10, "level": 1}, "meta": {"schema_version": 99}}`
+	if _, _, err := decodeState([]byte(payload)); err == nil {
+		t.Fatal("expected a save from a newer schema to be refused")
+	}
+}
@@ -0,0 +1,108 @@
+package adapters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+// DefaultProfilePath is where main looks for starting conditions.
+const DefaultProfilePath = "grimoire.profile.json"
+
+// ProfileOverrides are optional starting conditions for a new game. Unset
+// fields keep DefaultState's values; a non-nil Inventory replaces the
+// default one.
+type ProfileOverrides struct {
+	Gold      *int           `json:"gold,omitempty"`
+	HP        *int           `json:"hp,omitempty"`
+	MaxHP     *int           `json:"max_hp,omitempty"`
+	Class     string         `json:"class,omitempty"`
+	Inventory map[string]int `json:"inventory,omitempty"`
+
+	// Warnings lists entries LoadProfile dropped, such as unknown items.
+	Warnings []string `json:"-"`
+}
+
+// LoadProfile reads a profile file. A missing file yields empty overrides.
+// Unknown items and classes are dropped with a warning; invalid numbers are
+// an error.
+func LoadProfile(path string) (ProfileOverrides, error) {
+	var p ProfileOverrides
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return ProfileOverrides{}, fmt.Errorf("profile %s: %w", path, err)
+	}
+
+	if p.Inventory != nil {
+		inv := map[string]int{}
+		for id, n := range p.Inventory {
+			norm := engine.NormalizeItemID(id)
+			if _, ok := engine.Items[norm]; !ok {
+				p.Warnings = append(p.Warnings, fmt.Sprintf("unknown item %q ignored", id))
+				continue
+			}
+			if n > 0 {
+				inv[norm] += n
+			}
+		}
+		p.Inventory = inv
+	}
+
+	if p.Class != "" {
+		if _, ok := engine.LookupClass(p.Class); !ok {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("unknown class %q ignored", p.Class))
+			p.Class = ""
+		}
+	}
+
+	if p.Gold != nil && *p.Gold < 0 {
+		return ProfileOverrides{}, errors.New("profile gold must not be negative")
+	}
+	maxHP := engine.DefaultMaxHP
+	if p.MaxHP != nil {
+		if *p.MaxHP < 1 {
+			return ProfileOverrides{}, errors.New("profile max_hp must be positive")
+		}
+		maxHP = *p.MaxHP
+	}
+	if p.HP != nil && (*p.HP < 1 || *p.HP > maxHP) {
+		return ProfileOverrides{}, fmt.Errorf("profile hp must be between 1 and max_hp (%d)", maxHP)
+	}
+
+	return p, nil
+}
+
+// Apply writes the overrides onto state. Setting MaxHP without HP starts
+// the player at full health.
+func (p ProfileOverrides) Apply(state *engine.State) {
+	if p.Gold != nil {
+		state.Player.Gold = *p.Gold
+	}
+	if p.MaxHP != nil {
+		state.Player.MaxHP = *p.MaxHP
+		state.Player.HP = *p.MaxHP
+	}
+	if p.HP != nil {
+		state.Player.HP = *p.HP
+	}
+	if c, ok := engine.LookupClass(p.Class); ok {
+		state.Player.Class = c.Name
+	}
+	if p.Inventory != nil {
+		state.Player.Inventory = map[string]int{}
+		for id, n := range p.Inventory {
+			state.Player.Inventory[id] = n
+		}
+	}
+	state.Player.ClampHP()
+}
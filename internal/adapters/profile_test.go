@@ -0,0 +1,75 @@
+package adapters
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+func writeProfile(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "grimoire.profile.json")
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatalf("write profile: %v", err)
+	}
+	return path
+}
+
+func TestLoadProfile_AppliesGoldAndInventory(t *testing.T) {
+	path := writeProfile(t, `{
+  "gold": 250,
+  "class": "warrior",
+  "inventory": {"healing_potion": 3, "Torch": 2, "dragon_egg": 1}
+}`)
+
+	profile, err := LoadProfile(path)
+	if err != nil {
+		t.Fatalf("LoadProfile returned error: %v", err)
+	}
+	if len(profile.Warnings) != 1 {
+		t.Fatalf("expected one warning for the unknown item, got %v", profile.Warnings)
+	}
+
+	state := engine.DefaultState()
+	profile.Apply(&state)
+
+	if state.Player.Gold != 250 {
+		t.Fatalf("expected 250 gold, got %d", state.Player.Gold)
+	}
+	if state.Player.Class != "Warrior" {
+		t.Fatalf("expected Warrior, got %q", state.Player.Class)
+	}
+	want := map[string]int{"healing_potion": 3, "torch": 2}
+	if len(state.Player.Inventory) != len(want) {
+		t.Fatalf("expected inventory %v, got %v", want, state.Player.Inventory)
+	}
+	for id, n := range want {
+		if state.Player.Inventory[id] != n {
+			t.Fatalf("expected inventory %v, got %v", want, state.Player.Inventory)
+		}
+	}
+	if state.Player.HP != engine.DefaultMaxHP {
+		t.Fatalf("expected default HP, got %d", state.Player.HP)
+	}
+}
+
+func TestLoadProfile_RejectsHPAboveMax(t *testing.T) {
+	path := writeProfile(t, `{"hp": 150, "max_hp": 120}`)
+	if _, err := LoadProfile(path); err == nil {
+		t.Fatal("expected error for hp above max_hp")
+	}
+}
+
+func TestLoadProfile_MissingFileIsEmpty(t *testing.T) {
+	profile, err := LoadProfile(filepath.Join(t.TempDir(), "none.json"))
+	if err != nil {
+		t.Fatalf("expected no error for a missing profile, got %v", err)
+	}
+	state := engine.DefaultState()
+	profile.Apply(&state)
+	if state.Player.Gold != engine.DefaultState().Player.Gold {
+		t.Fatalf("expected defaults untouched, got gold %d", state.Player.Gold)
+	}
+}
@@ -0,0 +1,49 @@
+package adapters
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+
+	"github.com/divijg19/Grimoire/internal/ports"
+)
+
+// CryptoRNG is a crypto/rand-backed RNG adapter for players who want draws
+// nobody can predict. It has no seed, so games using it can't be replayed.
+type CryptoRNG struct{}
+
+// NewCryptoRNG creates an RNG reading from crypto/rand.
+func NewCryptoRNG() ports.RNG {
+	return CryptoRNG{}
+}
+
+// Intn returns an unbiased integer in [0, n), rejecting draws from the
+// incomplete top range of uint64. Like math/rand, it panics if n <= 0.
+func (CryptoRNG) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	bound := uint64(n)
+	// limit is the largest multiple of bound that fits in a uint64; draws
+	// at or above it would favor small results.
+	limit := ^uint64(0) - ^uint64(0)%bound
+	for {
+		if v := cryptoUint64(); v < limit {
+			return int(v % bound)
+		}
+	}
+}
+
+// Float64 returns a float in [0.0, 1.0) built from 53 random bits.
+func (CryptoRNG) Float64() float64 {
+	return float64(cryptoUint64()>>11) / (1 << 53)
+}
+
+// cryptoUint64 reads 8 bytes from crypto/rand. A failing system entropy
+// source leaves no safe fallback, so it panics.
+func cryptoUint64() uint64 {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("crypto/rand: " + err.Error())
+	}
+	return binary.LittleEndian.Uint64(b[:])
+}
@@ -0,0 +1,48 @@
+package adapters
+
+import "testing"
+
+func TestCryptoRNG_IntnStaysInRange(t *testing.T) {
+	rng := NewCryptoRNG()
+	seen := map[int]bool{}
+	for i := 0; i < 2000; i++ {
+		v := rng.Intn(7)
+		if v < 0 || v >= 7 {
+			t.Fatalf("Intn(7) = %d, out of range", v)
+		}
+		seen[v] = true
+	}
+	if len(seen) != 7 {
+		t.Fatalf("expected every value of [0,7) drawn, got %v", seen)
+	}
+	if v := rng.Intn(1); v != 0 {
+		t.Fatalf("Intn(1) = %d, want 0", v)
+	}
+}
+
+func TestCryptoRNG_IntnPanicsLikeMathRNG(t *testing.T) {
+	for _, n := range []int{0, -3} {
+		for name, rng := range map[string]interface{ Intn(int) int }{
+			"crypto": NewCryptoRNG(),
+			"math":   NewSeededMathRNG(1),
+		} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatalf("%s Intn(%d): expected a panic", name, n)
+					}
+				}()
+				rng.Intn(n)
+			}()
+		}
+	}
+}
+
+func TestCryptoRNG_Float64InUnitInterval(t *testing.T) {
+	rng := NewCryptoRNG()
+	for i := 0; i < 2000; i++ {
+		if f := rng.Float64(); f < 0 || f >= 1 {
+			t.Fatalf("Float64() = %v, out of [0,1)", f)
+		}
+	}
+}
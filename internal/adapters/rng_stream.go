@@ -0,0 +1,173 @@
+package adapters
+
+import (
+	"errors"
+	"hash/fnv"
+	"math/rand"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+	"github.com/divijg19/Grimoire/internal/ports"
+)
+
+// countingSource wraps a rand.Source and counts draws so the stream
+// position can be saved and fast-forwarded on resume.
+type countingSource struct {
+	src   rand.Source
+	draws int64
+}
+
+func (c *countingSource) Int63() int64 {
+	c.draws++
+	return c.src.Int63()
+}
+
+func (c *countingSource) Seed(seed int64) {
+	c.src.Seed(seed)
+	c.draws = 0
+}
+
+// StreamRNG is a seeded math/rand RNG that tracks its stream position.
+type StreamRNG struct {
+	seed int64
+	src  *countingSource
+	r    *rand.Rand
+}
+
+// NewStreamRNG creates a position-tracking RNG at the start of seed's stream.
+func NewStreamRNG(seed int64) *StreamRNG {
+	src := &countingSource{src: rand.NewSource(seed)}
+	return &StreamRNG{
+		seed: seed,
+		src:  src,
+		r:    rand.New(src),
+	}
+}
+
+// ResumeStreamRNG recreates the stream for seed and skips the first draws
+// values, continuing exactly where a saved game left off.
+func ResumeStreamRNG(seed, draws int64) *StreamRNG {
+	s := NewStreamRNG(seed)
+	for s.src.draws < draws {
+		s.src.Int63()
+	}
+	return s
+}
+
+func (s *StreamRNG) Intn(n int) int {
+	return s.r.Intn(n)
+}
+
+func (s *StreamRNG) Float64() float64 {
+	return s.r.Float64()
+}
+
+// Position returns the seed and number of source draws so far.
+func (s *StreamRNG) Position() (int64, int64) {
+	return s.seed, s.src.draws
+}
+
+// StreamSet is an engine.RNGSet built from StreamRNGs: a master stream on
+// the seed plus one sub-stream per name, seeded from the seed and the name.
+// Every position is tracked so a split game replays like a single stream.
+type StreamSet struct {
+	*engine.RNGSet
+	master *StreamRNG
+	subs   map[string]*StreamRNG
+}
+
+// DefaultStreams are the sub-streams the engine draws from.
+var DefaultStreams = []string{engine.StreamSelection, engine.StreamCombat}
+
+// NewStreamSet splits seed into a master stream and the named sub-streams.
+func NewStreamSet(seed int64, names ...string) *StreamSet {
+	positions := make(map[string]int64, len(names))
+	for _, name := range names {
+		positions[name] = 0
+	}
+	return ResumeStreamSet(seed, 0, positions)
+}
+
+// ResumeStreamSet recreates a split stream, skipping draws on the master
+// and each sub-stream's recorded draws. With no positions it splits into
+// DefaultStreams.
+func ResumeStreamSet(seed, draws int64, positions map[string]int64) *StreamSet {
+	if len(positions) == 0 {
+		positions = make(map[string]int64, len(DefaultStreams))
+		for _, name := range DefaultStreams {
+			positions[name] = 0
+		}
+	}
+	s := &StreamSet{
+		RNGSet: &engine.RNGSet{Streams: make(map[string]engine.RNG, len(positions))},
+		master: ResumeStreamRNG(seed, draws),
+		subs:   make(map[string]*StreamRNG, len(positions)),
+	}
+	s.RNGSet.Master = s.master
+	for name, n := range positions {
+		sub := ResumeStreamRNG(subSeed(seed, name), n)
+		s.subs[name] = sub
+		s.RNGSet.Streams[name] = sub
+	}
+	return s
+}
+
+// subSeed derives a sub-stream's seed from the master seed and its name.
+func subSeed(seed int64, name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return seed ^ int64(h.Sum64())
+}
+
+// Position returns the master stream's seed and draws.
+func (s *StreamSet) Position() (int64, int64) {
+	return s.master.Position()
+}
+
+// StreamPositions returns the draws made on each sub-stream.
+func (s *StreamSet) StreamPositions() map[string]int64 {
+	out := make(map[string]int64, len(s.subs))
+	for name, sub := range s.subs {
+		_, out[name] = sub.Position()
+	}
+	return out
+}
+
+// rngTrackingStore stamps the RNG position into Meta before every save.
+type rngTrackingStore struct {
+	ports.Store
+	rng ports.ReplayableRNG
+}
+
+// NewRNGTrackingStore wraps store so each save records rng's stream position.
+func NewRNGTrackingStore(store ports.Store, rng ports.ReplayableRNG) ports.Store {
+	return &rngTrackingStore{Store: store, rng: rng}
+}
+
+func (s *rngTrackingStore) Save(state *engine.State) error {
+	state.Meta.RNGSeed, state.Meta.RNGDraws = s.rng.Position()
+	state.Meta.RNGStreams = nil
+	if set, ok := s.rng.(*StreamSet); ok {
+		state.Meta.RNGStreams = set.StreamPositions()
+	}
+	return s.Store.Save(state)
+}
+
+// Slots and LoadSlot forward to the wrapped store when it has slots, so
+// wrapping a store never hides them.
+func (s *rngTrackingStore) Slots() ([]string, error) {
+	slots, ok := s.Store.(ports.SlotStore)
+	if !ok {
+		return nil, errNoSlots
+	}
+	return slots.Slots()
+}
+
+func (s *rngTrackingStore) LoadSlot(name string) (*engine.State, error) {
+	slots, ok := s.Store.(ports.SlotStore)
+	if !ok {
+		return nil, errNoSlots
+	}
+	return slots.LoadSlot(name)
+}
+
+var errNoSlots = errors.New("store has no save slots")
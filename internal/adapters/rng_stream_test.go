@@ -0,0 +1,152 @@
+package adapters
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+func playExplores(t *testing.T, state *engine.State, rng engine.RNG, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		_, _ = engine.RunCommand(state, "explore", rng)
+	}
+}
+
+func TestStreamRNG_ResumesAcrossSaveAndLoad(t *testing.T) {
+	const seed = 42
+
+	// Uninterrupted run.
+	want := engine.DefaultState()
+	want.Player.HP, want.Player.MaxHP = 10000, 10000
+	wantRNG := NewStreamRNG(seed)
+	playExplores(t, &want, wantRNG, 10)
+
+	// Save at command 5, reload, continue.
+	path := filepath.Join(t.TempDir(), "save.json")
+	got := engine.DefaultState()
+	got.Player.HP, got.Player.MaxHP = 10000, 10000
+	rng := NewStreamRNG(seed)
+	store := NewRNGTrackingStore(&JSONStore{Path: path}, rng)
+	playExplores(t, &got, rng, 5)
+	if err := store.Save(&got); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	reloaded, err := (&JSONStore{Path: path}).Load()
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	if reloaded.Meta.RNGSeed != seed || reloaded.Meta.RNGDraws == 0 {
+		t.Fatalf("expected persisted RNG position, got seed=%d draws=%d", reloaded.Meta.RNGSeed, reloaded.Meta.RNGDraws)
+	}
+	resumed := ResumeStreamRNG(reloaded.Meta.RNGSeed, reloaded.Meta.RNGDraws)
+	playExplores(t, reloaded, resumed, 5)
+
+	if reloaded.Player.Gold != want.Player.Gold || reloaded.Player.XP != want.Player.XP || reloaded.Player.HP != want.Player.HP {
+		t.Fatalf("resumed run diverged: gold %d/%d xp %d/%d hp %d/%d",
+			reloaded.Player.Gold, want.Player.Gold, reloaded.Player.XP, want.Player.XP, reloaded.Player.HP, want.Player.HP)
+	}
+	for i := 0; i < 20; i++ {
+		if a, b := resumed.Intn(1000), wantRNG.Intn(1000); a != b {
+			t.Fatalf("draw %d diverged after resume: %d != %d", i, a, b)
+		}
+	}
+}
+
+func TestStreamRNG_PositionCountsDraws(t *testing.T) {
+	rng := NewStreamRNG(7)
+	if _, draws := rng.Position(); draws != 0 {
+		t.Fatalf("expected fresh stream at 0 draws, got %d", draws)
+	}
+	rng.Intn(10)
+	rng.Float64()
+	if seed, draws := rng.Position(); seed != 7 || draws < 2 {
+		t.Fatalf("unexpected position seed=%d draws=%d", seed, draws)
+	}
+}
+
+// huntOrc stakes the max extra SP with a weight shift big enough that the
+// selection always lands on an orc, after burning selectionBurn draws from
+// rng's selection stream to stand in for a change to selection logic. It
+// returns the combat events.
+func huntOrc(t *testing.T, rng engine.RNG, selectionBurn int) []engine.Event {
+	t.Helper()
+	old := engine.HuntTunables
+	engine.HuntTunables.WeightPerSP = 1 << 20
+	defer func() { engine.HuntTunables = old }()
+
+	selection := rng
+	if set, ok := rng.(*StreamSet); ok {
+		selection = set.Stream(engine.StreamSelection)
+	}
+	for range selectionBurn {
+		selection.Intn(100)
+	}
+
+	state := engine.DefaultState()
+	state.Player.SP, state.Player.MaxSP = 50, 50
+	state.Player.HP, state.Player.MaxHP = 10000, 10000
+	events, err := engine.Hunt(&state, engine.HuntExtraSPMax, rng)
+	if err != nil {
+		t.Fatalf("hunt failed: %v", err)
+	}
+	var fight []engine.Event
+	for _, ev := range events {
+		switch e := ev.(type) {
+		case engine.EncounterStarted:
+			if e.EnemyID != "orc" {
+				t.Fatalf("expected the weighted pick to be an orc, got %s", e.EnemyID)
+			}
+		case engine.DamageDealt:
+			fight = append(fight, e)
+		}
+	}
+	if len(fight) == 0 {
+		t.Fatal("expected a fight")
+	}
+	return fight
+}
+
+func TestStreamSet_SelectionChangesLeaveCombatAlone(t *testing.T) {
+	base := huntOrc(t, NewStreamSet(42, DefaultStreams...), 0)
+	shifted := huntOrc(t, NewStreamSet(42, DefaultStreams...), 3)
+	if !reflect.DeepEqual(base, shifted) {
+		t.Fatalf("split streams: extra selection draws changed combat\n%v\n%v", base, shifted)
+	}
+
+	// On a single stream the same change shifts every combat roll.
+	single := huntOrc(t, NewStreamRNG(42), 0)
+	singleShifted := huntOrc(t, NewStreamRNG(42), 3)
+	if reflect.DeepEqual(single, singleShifted) {
+		t.Fatal("single stream: expected extra selection draws to change combat")
+	}
+}
+
+func TestStreamSet_ResumesEveryStream(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	state := engine.DefaultState()
+	state.Player.HP, state.Player.MaxHP = 10000, 10000
+	rng := NewStreamSet(9, DefaultStreams...)
+	store := NewRNGTrackingStore(&JSONStore{Path: path}, rng)
+	playExplores(t, &state, rng, 20)
+	if err := store.Save(&state); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	reloaded, err := (&JSONStore{Path: path}).Load()
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	if len(reloaded.Meta.RNGStreams) != len(DefaultStreams) {
+		t.Fatalf("expected every sub-stream saved, got %v", reloaded.Meta.RNGStreams)
+	}
+	resumed := ResumeStreamSet(reloaded.Meta.RNGSeed, reloaded.Meta.RNGDraws, reloaded.Meta.RNGStreams)
+	for _, name := range DefaultStreams {
+		if a, b := resumed.Stream(name).Intn(1000), rng.Stream(name).Intn(1000); a != b {
+			t.Fatalf("%s stream diverged after resume: %d != %d", name, a, b)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package adapters
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultSavePath is the save in the working directory, where Grimoire
+// kept it before it had a data directory.
+const DefaultSavePath = "grimoire.json"
+
+// sidecarFiles are the JSON files Grimoire keeps beside its saves that
+// aren't saves themselves.
+var sidecarFiles = map[string]bool{
+	DefaultProfilePath:      true,
+	DefaultTunablesPath:     true,
+	DefaultArenaRecordsPath: true,
+}
+
+// isSidecar reports whether path is one of the sidecarFiles.
+func isSidecar(path string) bool {
+	return sidecarFiles[filepath.Base(path)]
+}
+
+// SavePathEnv overrides the save location when --save is not given.
+const SavePathEnv = "GRIMOIRE_SAVE"
+
+// ResolveSavePath picks the save file, in order: flagPath, $GRIMOIRE_SAVE,
+// a save already in the working directory, then grimoire.json under the
+// OS config directory (e.g. ~/.config/grimoire on Linux). The chosen
+// file's directory is created if missing. When the config directory is
+// unusable it falls back to DefaultSavePath and reports why.
+//
+// getenv and configDir are os.Getenv and os.UserConfigDir outside tests.
+func ResolveSavePath(flagPath string, getenv func(string) string, configDir func() (string, error)) (string, error) {
+	if flagPath != "" {
+		return flagPath, ensureDir(flagPath)
+	}
+	if env := getenv(SavePathEnv); env != "" {
+		return env, ensureDir(env)
+	}
+	// Keep playing an existing save where it is rather than silently
+	// starting a new game elsewhere.
+	if fileExists(DefaultSavePath) || fileExists(DefaultSavePath+".gz") {
+		return DefaultSavePath, nil
+	}
+
+	dir, err := configDir()
+	if err != nil {
+		return DefaultSavePath, fmt.Errorf("no config directory: %w", err)
+	}
+	path := filepath.Join(dir, "grimoire", DefaultSavePath)
+	if err := ensureDir(path); err != nil {
+		return DefaultSavePath, err
+	}
+	return path, nil
+}
+
+// ensureDir creates the directory that will hold path.
+func ensureDir(path string) error {
+	return os.MkdirAll(filepath.Dir(path), 0755)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
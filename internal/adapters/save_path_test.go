@@ -0,0 +1,63 @@
+package adapters
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveSavePath_Precedence(t *testing.T) {
+	t.Chdir(t.TempDir())
+	config := t.TempDir()
+	configDir := func() (string, error) { return config, nil }
+	noConfig := func() (string, error) { return "", errors.New("no home") }
+	env := func(v string) func(string) string {
+		return func(key string) string {
+			if key == SavePathEnv {
+				return v
+			}
+			return ""
+		}
+	}
+	flagPath := filepath.Join(t.TempDir(), "slots", "mine.json")
+	envPath := filepath.Join(t.TempDir(), "env", "save.json")
+	xdgPath := filepath.Join(config, "grimoire", DefaultSavePath)
+
+	tests := []struct {
+		name      string
+		flag      string
+		env       string
+		configDir func() (string, error)
+		want      string
+		wantErr   bool
+	}{
+		{"flag beats env", flagPath, envPath, configDir, flagPath, false},
+		{"env beats config dir", "", envPath, configDir, envPath, false},
+		{"config dir by default", "", "", configDir, xdgPath, false},
+		{"no config dir falls back", "", "", noConfig, DefaultSavePath, true},
+	}
+	for _, tc := range tests {
+		got, err := ResolveSavePath(tc.flag, env(tc.env), tc.configDir)
+		if got != tc.want || (err != nil) != tc.wantErr {
+			t.Errorf("%s: got %q, %v; want %q (error %v)", tc.name, got, err, tc.want, tc.wantErr)
+			continue
+		}
+		if !tc.wantErr {
+			if info, err := os.Stat(filepath.Dir(got)); err != nil || !info.IsDir() {
+				t.Errorf("%s: directory for %q not created", tc.name, got)
+			}
+		}
+	}
+
+	// A save already in the working directory keeps being used.
+	if err := os.WriteFile(DefaultSavePath, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ResolveSavePath("", env(""), configDir); got != DefaultSavePath || err != nil {
+		t.Fatalf("legacy save: got %q, %v", got, err)
+	}
+	if got, _ := ResolveSavePath("", env(envPath), configDir); got != envPath {
+		t.Fatalf("env should still beat a legacy save, got %q", got)
+	}
+}
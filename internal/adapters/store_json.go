@@ -1,10 +1,18 @@
 package adapters
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/divijg19/Grimoire/internal/engine"
@@ -14,6 +22,10 @@ import (
 // JSONStore implements ports.Store using a JSON file.
 type JSONStore struct {
 	Path string
+
+	// Compress gzips the save on write. A Path ending in ".gz" implies it.
+	// Load reads either format regardless.
+	Compress bool
 }
 
 // NewJSONStore creates a JSON-backed store at the given path.
@@ -21,7 +33,9 @@ func NewJSONStore(path string) ports.Store {
 	return &JSONStore{Path: path}
 }
 
-// Load loads the game state or returns DefaultState if missing/corrupt.
+// Load loads the game state or returns DefaultState if missing/corrupt. A
+// corrupt save is moved aside; one from a newer build (ErrNewerSchema) is
+// left in place so the caller can refuse to play over it.
 func (s *JSONStore) Load() (*engine.State, error) {
 	if _, err := os.Stat(s.Path); errors.Is(err, os.ErrNotExist) {
 		state := engine.DefaultState()
@@ -34,8 +48,15 @@ func (s *JSONStore) Load() (*engine.State, error) {
 		return &state, err
 	}
 
-	var state engine.State
-	if err := json.Unmarshal(data, &state); err != nil {
+	state, repaired, err := decodeSave(data)
+	for _, r := range repaired {
+		log.Printf("grimoire: %s: repaired: %v", s.Path, r)
+	}
+	if errors.Is(err, ErrNewerSchema) {
+		def := engine.DefaultState()
+		return &def, err
+	}
+	if err != nil {
 		// Corrupt save: move aside
 		ts := time.Now().Unix()
 		corrupt := s.Path + ".corrupt." + intToString(ts)
@@ -45,22 +66,34 @@ func (s *JSONStore) Load() (*engine.State, error) {
 		return &def, err
 	}
 
-	// Ensure inventory map exists
-	state.Player.EnsureInventory()
-	state.Player.Inventory = engine.NormalizeInventory(state.Player.Inventory)
-	state.Player.ClampHP()
+	return state, nil
+}
 
-	return &state, nil
+// ReadStateFile parses a save file without the side effects of Load: a
+// missing or corrupt file is reported as an error and never renamed.
+func ReadStateFile(path string) (*engine.State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	state, _, err := decodeSave(data)
+	return state, err
 }
 
-// Save writes the state atomically.
+// Save writes the state atomically, gzipped if the store compresses.
 func (s *JSONStore) Save(state *engine.State) error {
 	tmp := s.Path + ".tmp"
+	state.Meta.SchemaVersion = engine.SchemaVersion
 
 	data, err := json.MarshalIndent(state, "", "  ")
 	if err != nil {
 		return err
 	}
+	if s.compressed() {
+		if data, err = gzipBytes(data); err != nil {
+			return err
+		}
+	}
 
 	if err := os.WriteFile(tmp, data, 0644); err != nil {
 		return err
@@ -70,10 +103,143 @@ func (s *JSONStore) Save(state *engine.State) error {
 	return os.Rename(tmp, s.Path)
 }
 
+// Archive renames the save to a timestamped file next to it. A counter
+// suffix keeps rapid resets within the same second from colliding.
+func (s *JSONStore) Archive() (string, error) {
+	if _, err := os.Stat(s.Path); errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+
+	base := s.Path + ".archive." + intToString(time.Now().Unix())
+	dest := base
+	for n := 1; ; n++ {
+		if _, err := os.Stat(dest); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		dest = base + "." + strconv.Itoa(n)
+	}
+
+	if err := os.Rename(s.Path, dest); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// ================================
+// Slots
+// ================================
+
+// Slots lists the saves in the same directory as the active one; each
+// *.json or *.json.gz file other than the profile, config and arena files
+// is a slot named after the file, listed once even when both forms exist.
+func (s *JSONStore) Slots() ([]string, error) {
+	dir := filepath.Dir(s.Path)
+	plain, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	gzipped, err := filepath.Glob(filepath.Join(dir, "*.json.gz"))
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	names := make([]string, 0, len(plain)+len(gzipped))
+	for _, m := range append(plain, gzipped...) {
+		if isSidecar(m) {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(m), ".gz"), ".json")
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// LoadSlot reads the named slot without Load's side effects. When the
+// slot exists both plain and gzipped, the newer file is read.
+func (s *JSONStore) LoadSlot(name string) (*engine.State, error) {
+	path := filepath.Join(filepath.Dir(s.Path), name+".json")
+	plain, plainErr := os.Stat(path)
+	gzipped, gzErr := os.Stat(path + ".gz")
+	if gzErr == nil && (plainErr != nil || gzipped.ModTime().After(plain.ModTime())) {
+		path += ".gz"
+	}
+	return ReadStateFile(path)
+}
+
 // ================================
 // Helpers
 // ================================
 
+// compressed reports whether Save writes gzip.
+func (s *JSONStore) compressed() bool {
+	return s.Compress || strings.HasSuffix(s.Path, ".gz")
+}
+
+// gzipMagic opens every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// decodeSave is decodeState for raw file contents, which may be gzipped.
+// A gzip stream that won't decompress is as corrupt as bad JSON.
+func decodeSave(data []byte) (*engine.State, []error, error) {
+	data, err := gunzipSave(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return decodeState(data)
+}
+
+// gunzipSave returns the JSON inside a save, decompressing it if needed.
+func gunzipSave(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, gzipMagic) {
+		return data, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(zr)
+}
+
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeState unmarshals a save, normalizes fields older saves may lack and
+// repairs broken invariants, returning what it repaired. A save whose
+// problems can't be repaired is an error.
+func decodeState(data []byte) (*engine.State, []error, error) {
+	var state engine.State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, nil, err
+	}
+	if err := migrateState(&state); err != nil {
+		return nil, nil, err
+	}
+
+	repaired := engine.RepairState(&state)
+	if errs := engine.ValidateState(&state); len(errs) > 0 {
+		return nil, repaired, fmt.Errorf("invalid save: %w", errors.Join(errs...))
+	}
+
+	// Ensure inventory map exists
+	state.Player.EnsureInventory()
+	state.Player.Inventory = engine.NormalizeInventory(state.Player.Inventory)
+
+	return &state, repaired, nil
+}
+
 func intToString(v int64) string {
 	return filepath.Base(time.Unix(v, 0).Format("20060102_150405"))
 }
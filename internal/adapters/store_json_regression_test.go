@@ -1,10 +1,14 @@
 package adapters
 
 import (
+	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/divijg19/Grimoire/internal/engine"
 )
 
 func TestJSONStoreLoad_MissingFileReturnsDefault(t *testing.T) {
@@ -80,3 +84,156 @@ func TestJSONStoreSave_ThenLoadRoundTrip(t *testing.T) {
 		t.Fatalf("expected persisted healing_potion count 3, got %d", reloaded.Player.Inventory["healing_potion"])
 	}
 }
+
+func TestJSONStoreSave_GzipRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json.gz")
+	store := &JSONStore{Path: path}
+
+	state := engine.DefaultState()
+	state.Player.Gold = 777
+	if err := store.Save(&state); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read save: %v", err)
+	}
+	if !bytes.HasPrefix(data, gzipMagic) {
+		t.Fatalf("expected a gzipped save, got %q", data[:min(len(data), 16)])
+	}
+
+	reloaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	if reloaded.Player.Gold != 777 {
+		t.Fatalf("expected persisted gold 777, got %d", reloaded.Player.Gold)
+	}
+
+	// A plain store still reads the gzipped file by its magic bytes.
+	if plain, err := ReadStateFile(path); err != nil || plain.Player.Gold != 777 {
+		t.Fatalf("expected plain read of gzipped save, got %v (err %v)", plain, err)
+	}
+}
+
+func TestJSONStoreLoad_CorruptGzipRenamed(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "save.json.gz")
+	if err := os.WriteFile(path, append(gzipMagic, "not-gzip"...), 0o644); err != nil {
+		t.Fatalf("write corrupt payload: %v", err)
+	}
+
+	state, err := (&JSONStore{Path: path}).Load()
+	if err == nil {
+		t.Fatalf("expected an error for a corrupt gzip save")
+	}
+	if state.Player.Name != "Traveller" {
+		t.Fatalf("expected default state fallback, got player name %q", state.Player.Name)
+	}
+	if _, statErr := os.Stat(path); !errors.Is(statErr, os.ErrNotExist) {
+		t.Fatalf("expected corrupt save moved aside, stat err %v", statErr)
+	}
+}
+
+func TestJSONStoreLoad_RepairsBrokenInvariants(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	payload := `{
+  "player": {
+    "name": "Traveller", "class": "Adventurer",
+    "gold": -10, "hp": 150, "max_hp": 100, "sp": 3, "max_sp": 10,
+    "level": 0, "xp": -5,
+    "inventory": {"torch": 0, "healing_potion": 2}
+  },
+  "meta": {"location": "Starting Village"}
+}`
+	if err := os.WriteFile(path, []byte(payload), 0o644); err != nil {
+		t.Fatalf("write payload: %v", err)
+	}
+
+	state, err := (&JSONStore{Path: path}).Load()
+	if err != nil {
+		t.Fatalf("expected repairable save to load, got: %v", err)
+	}
+	p := state.Player
+	if p.Level != 1 || p.HP != 100 || p.Gold != 0 || p.XP != 0 {
+		t.Fatalf("expected level 1, HP 100, gold 0, XP 0; got level %d, HP %d, gold %d, XP %d", p.Level, p.HP, p.Gold, p.XP)
+	}
+	if _, ok := p.Inventory["torch"]; ok || p.Inventory["healing_potion"] != 2 {
+		t.Fatalf("expected zero-count torch dropped, got %v", p.Inventory)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected repaired save left in place: %v", err)
+	}
+}
+
+func TestJSONStoreLoad_UnrepairableSaveFallsBackToDefault(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "save.json")
+	payload := `{"player": {"name": "Ghost", "hp": 5, "max_hp": 0, "level": 3, "inventory": {}}}`
+	if err := os.WriteFile(path, []byte(payload), 0o644); err != nil {
+		t.Fatalf("write payload: %v", err)
+	}
+
+	state, err := (&JSONStore{Path: path}).Load()
+	if err == nil {
+		t.Fatal("expected error for max_hp 0")
+	}
+	if state.Player.Name != "Traveller" {
+		t.Fatalf("expected default state fallback, got player name %q", state.Player.Name)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected broken save moved aside, stat err: %v", err)
+	}
+}
+
+func TestJSONStore_ResumesDungeonRunMidway(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	store := &JSONStore{Path: path}
+
+	state := engine.DefaultState()
+	state.Player.Level = 30 // every fight is a one-hit win
+	rng := NewStreamRNG(7)
+	if _, err := engine.RunCommand(&state, "dungeon enter goblin_warren", rng); err != nil {
+		t.Fatalf("enter: %v", err)
+	}
+	if _, err := engine.RunCommand(&state, "dungeon next", rng); err != nil {
+		t.Fatalf("first fight: %v", err)
+	}
+	run := *state.Dungeon
+	if run.Stage != 1 || run.XP != engine.Enemies["goblin"].XP || run.Gold == 0 || run.EnteredHP != state.Player.HP {
+		t.Fatalf("unexpected run after one fight: %+v", run)
+	}
+	if err := store.Save(&state); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	reloaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	got := reloaded.Dungeon
+	if got == nil || got.Stage != run.Stage || got.XP != run.XP || got.Gold != run.Gold || got.EnteredHP != run.EnteredHP {
+		t.Fatalf("expected run %+v restored, got %+v", run, got)
+	}
+	if _, err := engine.RunCommand(reloaded, "explore", rng); err == nil {
+		t.Fatalf("expected explore blocked mid-dungeon")
+	}
+
+	events, err := engine.RunCommand(reloaded, "dungeon next", rng)
+	if err != nil {
+		t.Fatalf("resumed fight: %v", err)
+	}
+	var fought string
+	for _, ev := range events {
+		if enc, ok := ev.(engine.EncounterStarted); ok {
+			fought = enc.EnemyID
+		}
+	}
+	if want := engine.Dungeons["goblin_warren"].Enemies[1]; fought != want {
+		t.Fatalf("expected the resumed run to fight %s, got %q", want, fought)
+	}
+	if reloaded.Dungeon.Stage != 2 || reloaded.Dungeon.XP != run.XP+engine.Enemies[fought].XP {
+		t.Fatalf("expected partial rewards to keep adding up, got %+v", reloaded.Dungeon)
+	}
+}
@@ -1,9 +1,13 @@
 package adapters
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/divijg19/Grimoire/internal/engine"
 )
 
 func TestJSONStoreLoad_NormalizesInventoryIDs(t *testing.T) {
@@ -53,3 +57,125 @@ func TestJSONStoreLoad_NormalizesInventoryIDs(t *testing.T) {
 		t.Fatalf("unexpected non-canonical key present")
 	}
 }
+
+func TestJSONStoreLoad_MigratesMissingMaxSP(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "save.json")
+
+	payload := `{
+  "player": {"name": "Traveller", "hp": 100, "max_hp": 100, "sp": 14, "level": 3, "inventory": {}},
+  "meta": {"location": "Starting Village"}
+}`
+	if err := os.WriteFile(path, []byte(payload), 0o644); err != nil {
+		t.Fatalf("write payload: %v", err)
+	}
+
+	state, err := (&JSONStore{Path: path}).Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if state.Player.MaxSP != 14 || state.Player.SP != 14 {
+		t.Fatalf("expected banked SP kept as MaxSP 14, got SP %d MaxSP %d", state.Player.SP, state.Player.MaxSP)
+	}
+}
+
+func TestJSONStoreLoad_LeavesNewerSchemaInPlace(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "save.json")
+
+	payload := `{"player": {"name": "Traveller", "hp": 100, "max_hp": 100, "level": 1, "inventory": {}}, "meta": {"schema_version": 99}}`
+	if err := os.WriteFile(path, []byte(payload), 0o644); err != nil {
+		t.Fatalf("write payload: %v", err)
+	}
+
+	_, err := (&JSONStore{Path: path}).Load()
+	if !errors.Is(err, ErrNewerSchema) {
+		t.Fatalf("expected ErrNewerSchema, got %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != payload {
+		t.Fatalf("expected the save left untouched, got %q (%v)", data, err)
+	}
+	if matches, _ := filepath.Glob(path + ".corrupt.*"); len(matches) > 0 {
+		t.Fatalf("expected no corrupt copy, got %v", matches)
+	}
+}
+
+func TestJSONStoreArchive_RapidResetsDoNotCollide(t *testing.T) {
+	dir := t.TempDir()
+	store := NewJSONStore(filepath.Join(dir, "save.json"))
+
+	seen := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		state := engine.DefaultState()
+		state.Player.Gold = i
+		if err := store.Save(&state); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+		archived, err := store.Archive()
+		if err != nil {
+			t.Fatalf("Archive returned error: %v", err)
+		}
+		if archived == "" || seen[archived] {
+			t.Fatalf("expected a fresh archive path, got %q", archived)
+		}
+		seen[archived] = true
+	}
+
+	if archived, err := store.Archive(); err != nil || archived != "" {
+		t.Fatalf("expected no-op archive without a save, got %q, %v", archived, err)
+	}
+}
+
+func TestJSONStoreSlots_ListsSiblingSaves(t *testing.T) {
+	dir := t.TempDir()
+	store := &JSONStore{Path: filepath.Join(dir, "grimoire.json")}
+
+	state := engine.DefaultState()
+	for _, name := range []string{"grimoire.json", "alt.json", "grimoire.json.archive.20260101_000000", DefaultProfilePath, DefaultTunablesPath, DefaultArenaRecordsPath} {
+		data, _ := json.Marshal(state)
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	slots, err := store.Slots()
+	if err != nil {
+		t.Fatalf("Slots returned error: %v", err)
+	}
+	if len(slots) != 2 || slots[0] != "alt" || slots[1] != "grimoire" {
+		t.Fatalf("unexpected slots %v", slots)
+	}
+	if _, err := store.LoadSlot("alt"); err != nil {
+		t.Fatalf("LoadSlot returned error: %v", err)
+	}
+}
+
+func TestJSONStoreSlots_IncludesCompressedSaves(t *testing.T) {
+	dir := t.TempDir()
+	store := &JSONStore{Path: filepath.Join(dir, "grimoire.json")}
+
+	state := engine.DefaultState()
+	state.Player.Gold = 77
+	if err := (&JSONStore{Path: filepath.Join(dir, "packed.json.gz")}).Save(&state); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := store.Save(&state); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := (&JSONStore{Path: filepath.Join(dir, "grimoire.json.gz")}).Save(&state); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	slots, err := store.Slots()
+	if err != nil {
+		t.Fatalf("Slots returned error: %v", err)
+	}
+	if len(slots) != 2 || slots[0] != "grimoire" || slots[1] != "packed" {
+		t.Fatalf("expected grimoire listed once and packed included, got %v", slots)
+	}
+	loaded, err := store.LoadSlot("packed")
+	if err != nil || loaded.Player.Gold != 77 {
+		t.Fatalf("expected the gzipped slot loaded, got %+v, %v", loaded, err)
+	}
+}
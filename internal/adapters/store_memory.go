@@ -0,0 +1,35 @@
+package adapters
+
+import (
+	"github.com/divijg19/Grimoire/internal/engine"
+	"github.com/divijg19/Grimoire/internal/ports"
+)
+
+// MemoryStore implements ports.Store without touching disk, for runs that
+// must not overwrite the real save (such as the daily challenge).
+type MemoryStore struct {
+	state engine.State
+}
+
+// NewMemoryStore creates a store holding a copy of state.
+func NewMemoryStore(state engine.State) ports.Store {
+	return &MemoryStore{state: state}
+}
+
+// Load returns a copy of the held state.
+func (s *MemoryStore) Load() (*engine.State, error) {
+	state := s.state
+	return &state, nil
+}
+
+// Save replaces the held state.
+func (s *MemoryStore) Save(state *engine.State) error {
+	s.state = *state
+	return nil
+}
+
+// Archive resets the held state; there is no file to move aside.
+func (s *MemoryStore) Archive() (string, error) {
+	s.state = engine.DefaultState()
+	return "", nil
+}
@@ -0,0 +1,38 @@
+package adapters
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+// DefaultTunablesPath is where main looks for tunable overrides.
+const DefaultTunablesPath = "grimoire.config.json"
+
+// LoadTunables reads a tunables config over the engine's current values,
+// so a file only needs the keys it changes. A missing file yields the
+// current values. Unknown keys and out-of-range values are an error.
+func LoadTunables(path string) (engine.Tunables, error) {
+	t := engine.CurrentTunables()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return t, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&t); err != nil {
+		return engine.CurrentTunables(), fmt.Errorf("config %s: %w", path, err)
+	}
+	if errs := engine.ValidateTunables(t); len(errs) > 0 {
+		return engine.CurrentTunables(), fmt.Errorf("config %s: %w", path, errors.Join(errs...))
+	}
+	return t, nil
+}
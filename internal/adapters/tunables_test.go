@@ -0,0 +1,80 @@
+package adapters
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+func writeConfig(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "grimoire.config.json")
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadTunables_XPPercentScalesGrantXP(t *testing.T) {
+	defer engine.ApplyTunables(engine.CurrentTunables())
+
+	tunables, err := LoadTunables(writeConfig(t, `{"xp_percent": 200}`))
+	if err != nil {
+		t.Fatalf("LoadTunables returned error: %v", err)
+	}
+	if tunables.XPPercent != 200 || tunables.EncounterRate != engine.EncounterRate {
+		t.Fatalf("expected only xp_percent overridden, got %+v", tunables)
+	}
+	engine.ApplyTunables(tunables)
+
+	state := engine.DefaultState()
+	engine.GrantXP(&state, 30)
+	if state.Player.XP != 60 {
+		t.Fatalf("expected 30 XP doubled to 60, got %d", state.Player.XP)
+	}
+}
+
+func TestLoadTunables_NestedKeysKeepTheirSiblings(t *testing.T) {
+	tunables, err := LoadTunables(writeConfig(t, `{"hunt": {"reward_per_sp": 0.5}, "camp_hp": 20}`))
+	if err != nil {
+		t.Fatalf("LoadTunables returned error: %v", err)
+	}
+	if tunables.Hunt.RewardPerSP != 0.5 || tunables.Hunt.WeightPerSP != engine.HuntTunables.WeightPerSP || tunables.CampHP != 20 {
+		t.Fatalf("expected only hunt.reward_per_sp and camp_hp overridden, got %+v", tunables)
+	}
+}
+
+func TestLoadTunables_MissingFileKeepsDefaults(t *testing.T) {
+	tunables, err := LoadTunables(filepath.Join(t.TempDir(), "absent.json"))
+	if err != nil {
+		t.Fatalf("expected no error for a missing config, got %v", err)
+	}
+	if tunables != engine.CurrentTunables() {
+		t.Fatalf("expected the current tunables, got %+v", tunables)
+	}
+}
+
+func TestLoadTunables_RejectsBadValues(t *testing.T) {
+	for _, body := range []string{
+		`{"encounter_rate": 150}`,
+		`{"xp_percent": 0}`,
+		`{"buy_markup": 50}`,
+		`{"treasure_choice_gold": -5}`,
+		`{"camp_ambush_chance": 2}`,
+		`{"merchant": {"chance": 101}}`,
+		`{"hunt": {"reward_per_sp": 1, "bonus": 2}}`,
+		`{"xp_percnt": 200}`,
+	} {
+		if _, err := LoadTunables(writeConfig(t, body)); err == nil {
+			t.Fatalf("expected %s to be rejected", body)
+		}
+	}
+
+	_, err := LoadTunables(writeConfig(t, `{"encounter_rate": -1, "interest_percent": 101}`))
+	if err == nil || !strings.Contains(err.Error(), "encounter_rate") || !strings.Contains(err.Error(), "interest_percent") {
+		t.Fatalf("expected both bad values reported, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+// Package buildinfo reports which build of Grimoire is running.
+package buildinfo
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+// Version and Revision describe the build. main sets them from its
+// link-time variables; an empty Revision falls back to the VCS stamp Go
+// embeds in module builds.
+var (
+	Version  = "dev"
+	Revision = ""
+)
+
+// String is the one-line version report for --version and `version`.
+func String() string {
+	revision, modified := Revision, false
+	if revision == "" {
+		revision, modified = vcsStamp()
+	}
+	return Format(Version, revision, modified, engine.SchemaVersion)
+}
+
+// Format renders "grimoire v1.2.0 (save schema 1, revision 1a2b3c4d5e6f,
+// modified)". Revisions are cut to 12 characters; an unknown one is left
+// out.
+func Format(version, revision string, modified bool, schema int) string {
+	out := fmt.Sprintf("grimoire %s (save schema %d", version, schema)
+	if revision != "" {
+		if len(revision) > 12 {
+			revision = revision[:12]
+		}
+		out += ", revision " + revision
+		if modified {
+			out += ", modified"
+		}
+	}
+	return out + ")"
+}
+
+// vcsStamp reads the revision and dirty flag from the embedded build info.
+func vcsStamp() (revision string, modified bool) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", false
+	}
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.modified":
+			modified = s.Value == "true"
+		}
+	}
+	return revision, modified
+}
@@ -0,0 +1,36 @@
+package buildinfo
+
+import (
+	"testing"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		revision string
+		modified bool
+		want     string
+	}{
+		{"release", "v1.2.0", "1a2b3c4d5e6f7a8b9c0d", false, "grimoire v1.2.0 (save schema 3, revision 1a2b3c4d5e6f)"},
+		{"dirty tree", "dev", "abc123", true, "grimoire dev (save schema 3, revision abc123, modified)"},
+		{"no revision", "v0.9.0", "", true, "grimoire v0.9.0 (save schema 3)"},
+	}
+	for _, tc := range tests {
+		if got := Format(tc.version, tc.revision, tc.modified, 3); got != tc.want {
+			t.Errorf("%s: Format = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestString_UsesInjectedValues(t *testing.T) {
+	oldVersion, oldRevision := Version, Revision
+	Version, Revision = "v2.0.0", "feedface"
+	defer func() { Version, Revision = oldVersion, oldRevision }()
+
+	if got, want := String(), Format("v2.0.0", "feedface", false, engine.SchemaVersion); got != want {
+		t.Fatalf("String = %q, want %q", got, want)
+	}
+}
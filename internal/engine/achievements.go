@@ -0,0 +1,76 @@
+package engine
+
+// ================================
+// Achievements
+// ================================
+
+// Achievement is a goal checked against the state after every command.
+type Achievement struct {
+	ID          string
+	Name        string
+	Description string
+	Unlocked    func(s *State) bool
+}
+
+// Achievements is the ordered achievement registry.
+var Achievements = []Achievement{
+	{
+		ID:          "first_blood",
+		Name:        "First Blood",
+		Description: "Defeat your first enemy",
+		Unlocked: func(s *State) bool {
+			for _, e := range s.Bestiary {
+				if e.Killed > 0 {
+					return true
+				}
+			}
+			return false
+		},
+	},
+	{
+		ID:          "seasoned",
+		Name:        "Seasoned Adventurer",
+		Description: "Reach level 10",
+		Unlocked: func(s *State) bool {
+			return s.Player.Level >= 10
+		},
+	},
+	{
+		ID:          "hoarder",
+		Name:        "Hoarder",
+		Description: "Hold 1000 gold",
+		Unlocked: func(s *State) bool {
+			return s.Player.Gold >= 1000
+		},
+	},
+	{
+		ID:          "monster_hunter",
+		Name:        "Monster Hunter",
+		Description: "Defeat every enemy type",
+		Unlocked: func(s *State) bool {
+			for id := range Enemies {
+				if s.Bestiary[id].Killed == 0 {
+					return false
+				}
+			}
+			return true
+		},
+	},
+}
+
+// CheckAchievements unlocks every achievement whose goal is newly met,
+// emitting AchievementUnlocked once per achievement.
+func CheckAchievements(state *State) Events {
+	events := Events{}
+	for _, a := range Achievements {
+		if state.Meta.Achievements[a.ID] || !a.Unlocked(state) {
+			continue
+		}
+		if state.Meta.Achievements == nil {
+			state.Meta.Achievements = map[string]bool{}
+		}
+		state.Meta.Achievements[a.ID] = true
+		events = emit(events, AchievementUnlocked{ID: a.ID, Name: a.Name})
+	}
+	return events
+}
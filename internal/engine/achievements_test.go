@@ -0,0 +1,75 @@
+package engine
+
+import "testing"
+
+func countAchievement(events Events, id string) int {
+	n := 0
+	for _, ev := range events {
+		if a, ok := ev.(AchievementUnlocked); ok && a.ID == id {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRunCommand_AchievementFiresOnceWhenThresholdCrossed(t *testing.T) {
+	state := DefaultState()
+	state.Player.Gold = 996
+
+	// roll 15 -> gold find, +5 gold
+	events, err := RunCommand(&state, "explore", &seqRNG{ints: []int{14, 0}})
+	if err != nil {
+		t.Fatalf("explore returned error: %v", err)
+	}
+	if countAchievement(events, "hoarder") != 1 {
+		t.Fatalf("expected hoarder unlocked once, events=%v", events)
+	}
+	if !state.Meta.Achievements["hoarder"] {
+		t.Fatalf("expected hoarder recorded in meta")
+	}
+
+	events, err = RunCommand(&state, "explore", &seqRNG{ints: []int{14, 0}})
+	if err != nil {
+		t.Fatalf("explore returned error: %v", err)
+	}
+	if countAchievement(events, "hoarder") != 0 {
+		t.Fatalf("expected hoarder not to fire again")
+	}
+}
+
+func TestRunCommand_FirstKillRecordsTallyAndAchievement(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 10
+
+	events, err := RunCommand(&state, "hunt", &seqRNG{ints: []int{0, 0}, floats: []float64{1, 1}})
+	if err != nil {
+		t.Fatalf("hunt returned error: %v", err)
+	}
+	if state.Bestiary["goblin"].Killed != 1 {
+		t.Fatalf("expected goblin kill tallied, got %v", state.Bestiary)
+	}
+	if countAchievement(events, "first_blood") != 1 {
+		t.Fatalf("expected first_blood unlocked")
+	}
+	// level 10 is already met too
+	if countAchievement(events, "seasoned") != 1 {
+		t.Fatalf("expected seasoned unlocked for a level 10 player")
+	}
+}
+
+func TestCheckAchievements_MonsterHunterNeedsEveryEnemy(t *testing.T) {
+	state := DefaultState()
+	state.Bestiary = map[string]BestiaryEntry{}
+	for id := range Enemies {
+		state.Bestiary[id] = BestiaryEntry{Seen: 1, Killed: 1}
+	}
+	delete(state.Bestiary, "orc")
+
+	if countAchievement(CheckAchievements(&state), "monster_hunter") != 0 {
+		t.Fatalf("monster_hunter unlocked without an orc kill")
+	}
+	state.Bestiary["orc"] = BestiaryEntry{Seen: 1, Killed: 1}
+	if countAchievement(CheckAchievements(&state), "monster_hunter") != 1 {
+		t.Fatalf("expected monster_hunter after every enemy type defeated")
+	}
+}
@@ -1,6 +1,9 @@
 package engine
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // ================================
 // Explore
@@ -11,93 +14,283 @@ func Explore(state *State, rng RNG) (Events, error) {
 	events := Events{}
 
 	if !state.Player.IsAlive() {
-		return events, errors.New("player is down (HP 0)")
+		return events, ErrPlayerDown
 	}
 
 	state.Meta.CommandCount++
 
 	roll := rng.Intn(100) + 1
 
+	// Luck and the world's find scale resize the treasure and item bands;
+	// gold and encounters keep their size and the "nothing" band absorbs
+	// the difference.
+	find := CurrentWorld(state).FindScale
+	treasureMax := int(effectiveChance(0.02*find, state.Player.Luck)*100 + 0.5)
+	itemMax := treasureMax + int(effectiveChance(0.08*find, state.Player.Luck)*100+0.5)
+
 	// Treasure (<=2%)
-	if roll <= 2 {
+	if roll <= treasureMax {
 		gold := 100 + rng.Intn(401) // 100–500
-		state.Player.Gold += gold
-		events = append(events,
-			ExplorationResult{Kind: "treasure"},
-			GoldGained{Amount: gold},
-		)
+		item := treasureItems[rng.Intn(len(treasureItems))]
+		events = emit(events, ExplorationResult{Kind: "treasure"})
 
-		items := []string{"healing_potion", "rusty_dagger", "torch"}
-		item := items[rng.Intn(len(items))]
-		AddItem(state.PlayerPtr(), item, 1)
-		events = append(events, ItemAdded{ItemID: item, Count: 1})
+		// Large treasures may wait for the player to choose.
+		if TreasureChoiceGold > 0 && gold >= TreasureChoiceGold {
+			return append(events, offerTreasure(state, gold, item)...), nil
+		}
+
+		state.Player.Gold += gold
+		events = emit(events, GoldGained{Amount: gold})
+		events = append(events, GrantLoot(state, []string{item}, rng)...)
 
 		return events, nil
 	}
 
 	// Item find (<=10%)
-	if roll <= 10 {
-		items := []string{"healing_potion", "torch"}
-		item := items[rng.Intn(len(items))]
-		AddItem(state.PlayerPtr(), item, 1)
-		events = append(events,
-			ExplorationResult{Kind: "item"},
-			ItemAdded{ItemID: item, Count: 1},
-		)
+	if roll <= itemMax {
+		item := foundItems[rng.Intn(len(foundItems))]
+		events = emit(events, ExplorationResult{Kind: "item"})
+		events = append(events, GrantLoot(state, []string{item}, rng)...)
 		return events, nil
 	}
 
 	// Gold find (<=30%)
-	if roll <= 30 {
+	if roll <= itemMax+20 {
 		gold := 5 + rng.Intn(46) // 5–50
 		state.Player.Gold += gold
-		events = append(events,
+		events = emit(events,
 			ExplorationResult{Kind: "gold"},
 			GoldGained{Amount: gold},
 		)
 		return events, nil
 	}
 
-	// Enemy encounter (<=50%)
-	if roll <= 50 {
-		enemyID := ChooseEnemy(state, 0, rng)
-		enemy := Enemies[enemyID]
+	// Enemy encounter (<=50% at the default rate)
+	encounterMax := itemMax + 20 + encounterBand(state, itemMax+20)
+	if roll <= encounterMax {
+		enemies := ChooseEncounter(state, 0, stream(rng, StreamSelection))
+		if ShouldRetreat(&state.Player, enemies) {
+			return emit(events, EncounterAvoided{EnemyID: enemies[0].ID}), nil
+		}
+		return append(events, fightEncounter(state, enemies, rng)...), nil
+	}
+
+	// Merchant caravan, carved out of the "nothing" band
+	if roll <= encounterMax+MerchantTunables.Chance {
+		events = emit(events, ExplorationResult{Kind: "merchant"})
+		return append(events, offerMerchant(state, rng)...), nil
+	}
+
+	// Nothing
+	events = emit(events, ExplorationResult{Kind: "nothing"})
+	return events, nil
+}
 
-		result, combatEvents := ResolveCombat(state, enemy, rng)
-		events = append(events, combatEvents...)
+// EncounterRate is the percent of explores that meet an enemy, before the
+// world's EncounterScale. Raising it makes exploring grindier; the extra
+// band comes out of "nothing" and merchants.
+var EncounterRate = 20
+
+// encounterBand is the width of explore's encounter band in percent, after
+// the world's scale, clamped to the rolls left above the find bands.
+func encounterBand(state *State, below int) int {
+	scale := CurrentWorld(state).EncounterScale
+	if scale <= 0 {
+		scale = 1
+	}
+	band := int(float64(EncounterRate)*scale + 0.5)
+	return max(0, min(band, 100-below))
+}
 
-		state.Player.HP = max(state.Player.HP, 0)
+// fightRandomEnemy picks an unstaked encounter, fights it and pays out
+// rewards on a win. Camp ambushes use it; explore picks its encounter
+// separately so it can retreat first.
+func fightRandomEnemy(state *State, rng RNG) Events {
+	return fightEncounter(state, ChooseEncounter(state, 0, stream(rng, StreamSelection)), rng)
+}
 
-		if result.Outcome == "win" {
-			events = append(events, GrantXP(state, result.XP)...)
+// fightEncounter fights enemies and pays out on a win.
+func fightEncounter(state *State, enemies []EnemyTemplate, rng RNG) Events {
+	var events Events
+	mult := encounterRewardScale(&state.Player, enemies)
+	if state.Meta.TargetedCombat && len(enemies) > 1 {
+		return StartBattle(state, enemies, mult)
+	}
 
-			state.Player.Gold += result.Gold
-			events = append(events, GoldGained{Amount: result.Gold})
+	result, combatEvents := ResolveGroupCombat(state, enemies, stream(rng, StreamCombat))
+	events = append(events, combatEvents...)
 
-			for _, it := range result.Loot {
-				AddItem(state.PlayerPtr(), it, 1)
-				events = append(events, ItemAdded{ItemID: it, Count: 1})
-			}
-		}
+	state.Player.HP = max(state.Player.HP, 0)
 
-		return events, nil
+	if result.Outcome == "win" {
+		events = append(events, payCombat(state, result, mult, rng)...)
 	}
+	return events
+}
 
-	// Nothing
-	events = append(events, ExplorationResult{Kind: "nothing"})
+// payCombat grants a won fight's XP, gold and loot, with XP and gold
+// scaled by mult.
+func payCombat(state *State, result CombatResult, mult float64, rng RNG) Events {
+	xp := int(float64(result.XP) * mult)
+	gold := int(float64(result.Gold) * mult)
+
+	events := GrantXP(state, xp)
+
+	state.Player.Gold += gold
+	events = emit(events, GoldGained{Amount: gold})
+
+	return append(events, GrantLoot(state, result.Loot, rng)...)
+}
+
+// RetreatXPPerLevel sets when explore backs away from a fight: an
+// encounter worth more than this much XP per player level is avoided.
+// 0 disables retreating.
+var RetreatXPPerLevel = 15
+
+// ShouldRetreat reports whether enemies are too strong for the player to
+// engage, judged by the XP they are worth.
+func ShouldRetreat(p *Player, enemies []EnemyTemplate) bool {
+	if RetreatXPPerLevel <= 0 {
+		return false
+	}
+	xp := 0
+	for _, e := range enemies {
+		xp += e.XP
+	}
+	return xp > RetreatXPPerLevel*max(p.Level, 1)
+}
+
+// ================================
+// Camp
+// ================================
+
+// Camp passes time to recover CampHP and CampSP, unless an enemy ambushes
+// the camp first.
+//
+// RNG draw order: one Float64 for the ambush check (ambushed when below
+// CampAmbushChance). A safe camp draws nothing else; an ambush then draws
+// exactly like an explore encounter (ChooseEnemy's Intn, then combat).
+func Camp(state *State, rng RNG) (Events, error) {
+	events := Events{}
+
+	if !state.Player.IsAlive() {
+		return events, ErrPlayerDown
+	}
+	if state.Dungeon != nil {
+		return events, ErrNoRestInDungeon
+	}
+
+	state.Meta.CommandCount++
+
+	if rng.Float64() < CampAmbushChance {
+		return append(events, fightRandomEnemy(state, rng)...), nil
+	}
+
+	hp, sp := state.Player.HP, state.Player.SP
+	state.Player.HP += CampHP
+	state.Player.SP += CampSP
+	state.Player.ClampHP()
+	state.Player.ClampSP()
+
+	if gained := state.Player.HP - hp; gained > 0 {
+		events = emit(events, HPRestored{Amount: gained})
+	}
+	if gained := state.Player.SP - sp; gained > 0 {
+		events = emit(events, SPRegained{Amount: gained})
+	}
 	return events, nil
 }
 
+// ================================
+// Anti-Farming
+// ================================
+
+// AntiFarm shrinks XP and gold from hunt and explore wins over enemies far
+// below the player's level. Off by default, so every win pays in full.
+var AntiFarm = false
+
+// AntiFarmTuning shapes the falloff: the first FreeLevels of gap cost
+// nothing, each level past them takes Step off the reward, and it never
+// drops below Floor.
+type AntiFarmTuning struct {
+	FreeLevels int     `json:"free_levels"`
+	Step       float64 `json:"step"`
+	Floor      float64 `json:"floor"`
+}
+
+var AntiFarmTunables = AntiFarmTuning{FreeLevels: 5, Step: 0.15, Floor: 0.25}
+
+// rewardScale is the share of XP and gold a win over an enemyLevel enemy
+// pays a playerLevel player: 1 when the levels are close, falling toward
+// AntiFarmTunables.Floor as the gap grows.
+func rewardScale(playerLevel, enemyLevel int) float64 {
+	over := playerLevel - enemyLevel - AntiFarmTunables.FreeLevels
+	if !AntiFarm || over <= 0 {
+		return 1
+	}
+	if scale := 1 - float64(over)*AntiFarmTunables.Step; scale > AntiFarmTunables.Floor {
+		return scale
+	}
+	return AntiFarmTunables.Floor
+}
+
+// encounterRewardScale applies rewardScale against the toughest enemy in
+// the encounter.
+func encounterRewardScale(player *Player, enemies []EnemyTemplate) float64 {
+	level := 0
+	for _, e := range enemies {
+		level = max(level, EnemyLevel(e))
+	}
+	return rewardScale(player.Level, level)
+}
+
 // ================================
 // Hunt
 // ================================
 
+// HuntTuning shapes hunt's risk/reward curve for extra SP staked.
+type HuntTuning struct {
+	// RewardPerSP scales XP and gold: the multiplier is 1 + RewardPerSP*extra.
+	RewardPerSP float64 `json:"reward_per_sp"`
+
+	// WeightPerSP is the ChooseEnemy weight moved from goblins to orcs per
+	// extra SP.
+	WeightPerSP int `json:"weight_per_sp"`
+}
+
+// HuntTunables is the active hunt tuning.
+var HuntTunables = HuntTuning{RewardPerSP: 0.25, WeightPerSP: 8}
+
+// Multiplier returns the reward multiplier for an extra SP stake.
+func (t HuntTuning) Multiplier(extraSP int) float64 {
+	return 1.0 + t.RewardPerSP*float64(extraSP)
+}
+
 // Hunt resolves a hunt action with optional extra SP stake.
 func Hunt(state *State, extraSP int, rng RNG) (Events, error) {
+	return hunt(state, extraSP, rng, func() []EnemyTemplate {
+		return ChooseEncounter(state, extraSP, stream(rng, StreamSelection))
+	})
+}
+
+// HuntTarget hunts one enemy of the given type, skipping the weighted
+// pick. It costs and pays like Hunt.
+func HuntTarget(state *State, enemyID string, extraSP int, rng RNG) (Events, error) {
+	enemy, ok := Enemies[enemyID]
+	if !ok {
+		return Events{}, fmt.Errorf("%w: %s", ErrUnknownEnemy, enemyID)
+	}
+	return hunt(state, extraSP, rng, func() []EnemyTemplate {
+		return []EnemyTemplate{enemy}
+	})
+}
+
+// hunt pays the SP stake, then fights whatever choose returns.
+func hunt(state *State, extraSP int, rng RNG, choose func() []EnemyTemplate) (Events, error) {
 	events := Events{}
 
 	if !state.Player.IsAlive() {
-		return events, errors.New("player is down (HP 0)")
+		return events, ErrPlayerDown
 	}
 
 	if extraSP < 0 {
@@ -109,39 +302,24 @@ func Hunt(state *State, extraSP int, rng RNG) (Events, error) {
 
 	cost := HuntBaseSP + extraSP
 	if state.Player.SP < cost {
-		return events, errors.New("not enough SP")
+		return events, ErrNotEnoughSP
 	}
 
 	state.Player.SP -= cost
 	state.Meta.CommandCount++
-	events = append(events, SPSpent{Amount: cost})
+	events = emit(events, SPSpent{Amount: cost})
 
-	enemyID := ChooseEnemy(state, extraSP, rng)
-	enemy := Enemies[enemyID]
+	enemies := choose()
+	mult := HuntTunables.Multiplier(extraSP) * encounterRewardScale(&state.Player, enemies)
+	if state.Meta.TargetedCombat && len(enemies) > 1 {
+		return append(events, StartBattle(state, enemies, mult)...), nil
+	}
 
-	result, combatEvents := ResolveCombat(state, enemy, rng)
+	result, combatEvents := ResolveGroupCombat(state, enemies, stream(rng, StreamCombat))
 	events = append(events, combatEvents...)
 
 	if result.Outcome == "win" {
-		mult := 1.0 + 0.25*float64(extraSP)
-		xp := int(float64(result.XP) * mult)
-		gold := int(float64(result.Gold) * mult)
-
-		events = append(events, GrantXP(state, xp)...)
-
-		state.Player.Gold += gold
-		events = append(events, GoldGained{Amount: gold})
-
-		for _, it := range result.Loot {
-			AddItem(state.PlayerPtr(), it, 1)
-			events = append(events, ItemAdded{ItemID: it, Count: 1})
-		}
-
-		// revive-to-1 rule
-		if state.Player.HP == 0 {
-			state.Player.HP = 1
-			events = append(events, HPRestored{Amount: 1})
-		}
+		events = append(events, payCombat(state, result, mult, rng)...)
 	}
 
 	return events, nil
@@ -156,23 +334,86 @@ func Rest(state *State, sp int) (Events, error) {
 	events := Events{}
 
 	if sp <= 0 {
-		return events, errors.New("invalid SP amount")
+		return events, ErrInvalidAmount
+	}
+	if state.Dungeon != nil {
+		return events, ErrNoRestInDungeon
 	}
 	if state.Player.SP < sp {
-		return events, errors.New("not enough SP")
+		return events, ErrNotEnoughSP
 	}
 
 	state.Player.SP -= sp
-	events = append(events, SPSpent{Amount: sp})
+	events = emit(events, SPSpent{Amount: sp})
 
-	hpGain := sp * RestHPPerSP
+	hpGain := sp * RestRate(&state.Player)
 	state.Player.HP += hpGain
 	state.Player.ClampHP()
 
-	events = append(events, HPRestored{Amount: hpGain})
+	events = emit(events, HPRestored{Amount: hpGain})
+	return events, nil
+}
+
+// ================================
+// Revive
+// ================================
+
+// ErrPlayerDown is returned by actions that need the player standing.
+var ErrPlayerDown = errors.New("player is down (HP 0); type 'revive' to return to town")
+
+// ReviveCost is the gold a revive costs at the given level.
+func ReviveCost(level int) int {
+	return ReviveBaseCost + ReviveCostPerLevel*max(level, 1)
+}
+
+// Revive brings a downed player back at the starting village with part of
+// their HP, for a level-scaled gold fee.
+func Revive(state *State) (Events, error) {
+	events := Events{}
+
+	if state.Player.IsAlive() {
+		return events, errors.New("you are not down")
+	}
+	cost := ReviveCost(state.Player.Level)
+	if state.Player.Gold < cost {
+		return events, fmt.Errorf("not enough gold to revive (need %d)", cost)
+	}
+
+	state.Player.Gold -= cost
+	events = emit(events, GoldSpent{Amount: cost})
+
+	hp := max(1, state.Player.MaxHP*RevivePercentHP/100)
+	state.Player.HP = hp
+	state.Meta.Location = "Starting Village"
+	events = emit(events, HPRestored{Amount: hp})
+
 	return events, nil
 }
 
+// ================================
+// SP Regen
+// ================================
+
+// RegenSP counts one non-combat command toward passive regen and restores
+// 1 SP each time the counter reaches SPRegenInterval, up to MaxSP.
+func RegenSP(state *State) Events {
+	if SPRegenInterval <= 0 {
+		return nil
+	}
+
+	state.Meta.SPRegenCounter++
+	if state.Meta.SPRegenCounter < SPRegenInterval {
+		return nil
+	}
+	state.Meta.SPRegenCounter = 0
+
+	if state.Player.SP >= state.Player.MaxSP {
+		return nil
+	}
+	state.Player.SP++
+	return emit(nil, SPRegained{Amount: 1})
+}
+
 // ================================
 // Use Item
 // ================================
@@ -182,62 +423,113 @@ func UseItem(state *State, itemID string, rng RNG) (Events, error) {
 	events := Events{}
 	itemID = NormalizeItemID(itemID)
 	if itemID == "" {
-		return events, errors.New("invalid item id")
+		return events, ErrInvalidItem
 	}
 
 	if !HasItem(&state.Player, itemID, 1) {
-		return events, errors.New("item not in inventory")
+		return events, ErrItemNotFound
 	}
 
 	item, ok := Items[itemID]
 	if !ok {
-		return events, errors.New("unknown item")
+		return events, ErrUnknownItem
 	}
 
-	hpGain := 0
-	if item.HPMax > 0 {
-		hpMin := item.HPMin
-		hpMax := item.HPMax
-		if hpMax < hpMin {
-			hpMax = hpMin
-		}
-		hpGain = hpMin + rng.Intn(hpMax-hpMin+1)
-		state.Player.HP += hpGain
+	if len(item.Effects) == 0 {
+		return events, ErrNoUseEffect
 	}
-
-	spGain := 0
-	if item.SPMax > 0 {
-		spMin := item.SPMin
-		spMax := item.SPMax
-		if spMax < spMin {
-			spMax = spMin
+	if err := checkCooldown(state, item); err != nil {
+		return events, err
+	}
+	escape, uncurse := false, false
+	for _, eff := range item.Effects {
+		switch eff.Kind {
+		case EffectEscape:
+			escape = true
+		case EffectUncurse:
+			uncurse = true
 		}
-		spGain = spMin + rng.Intn(spMax-spMin+1)
-		state.Player.SP += spGain
+	}
+	// A smoke bomb escapes a dungeon run or a staged battle.
+	if escape && state.Dungeon == nil && state.Battle == nil {
+		return events, errors.New("no encounter to escape")
+	}
+	if uncurse && !WearingCursed(&state.Player) {
+		return events, errors.New("nothing you wear is cursed")
 	}
 
-	if hpGain == 0 && spGain == 0 {
-		return events, errors.New("item has no use effect")
+	hpGain := 0
+	var buffs []Buff
+	for _, eff := range item.Effects {
+		switch eff.Kind {
+		case EffectHeal:
+			gain := rollEffect(eff, rng)
+			state.Player.HP += gain
+			hpGain += gain
+		case EffectRestoreSP:
+			state.Player.SP += rollEffect(eff, rng)
+		case EffectBuff:
+			buffs = append(buffs, Buff{
+				Stat:      eff.Stat,
+				Amount:    rollEffect(eff, rng),
+				Remaining: eff.Encounters,
+			})
+		}
 	}
 
 	state.Player.ClampHP()
+	state.Player.ClampSP()
 	RemoveItem(&state.Player, itemID, 1)
+	startCooldown(state, item)
 
-	events = append(events, ItemRemoved{ItemID: itemID, Count: 1})
+	events = emit(events, ItemRemoved{ItemID: itemID, Count: 1})
 	if hpGain > 0 {
-		events = append(events, HPRestored{Amount: hpGain})
+		events = emit(events, HPRestored{Amount: hpGain})
+	}
+	for _, b := range buffs {
+		events = append(events, AddBuff(&state.Player, b)...)
+	}
+	if uncurse {
+		events = append(events, liftCurses(&state.Player)...)
+	}
+	if escape && state.Battle != nil {
+		events = append(events, endBattle(state, "escaped", rng)...)
+		events = emit(events, EncounterEnded{Reason: "escaped"})
+	}
+	if escape && state.Dungeon != nil {
+		id := state.Dungeon.ID
+		state.Dungeon = nil
+		events = emit(events, EncounterEnded{DungeonID: id, Reason: "escaped"})
 	}
 
 	return events, nil
 }
 
+// rollEffect draws an effect's magnitude from its Min–Max range.
+func rollEffect(eff Effect, rng RNG) int {
+	hi := eff.Max
+	if hi < eff.Min {
+		hi = eff.Min
+	}
+	return eff.Min + rng.Intn(hi-eff.Min+1)
+}
+
 // ================================
 // Helpers
 // ================================
 
+// Explore's item tables: a treasure's item, and a plain item find.
+var (
+	treasureItems = []string{"healing_potion", "rusty_dagger", "torch"}
+	foundItems    = []string{"healing_potion", "torch"}
+)
+
+// enemyPool is ChooseEnemy's candidates, in the order of its weights.
+var enemyPool = []string{"goblin", "skeleton", "bandit", "wolf", "bear", "orc"}
+
 // ChooseEnemy mirrors Python enemy selection logic.
 func ChooseEnemy(state *State, extraSP int, rng RNG) string {
-	pool := []string{"goblin", "skeleton", "bandit", "wolf", "bear", "orc"}
+	pool := enemyPool
 	weights := []int{25, 20, 15, 10, 5, 2}
 
 	if state.Player.Level >= 3 {
@@ -247,9 +539,12 @@ func ChooseEnemy(state *State, extraSP int, rng RNG) string {
 		weights[2] += 5 // bandit bias
 	}
 
+	timeOfDayWeights(state, weights)
+
 	if extraSP > 0 {
-		weights[0] = max(0, weights[0]-extraSP*8)
-		weights[len(weights)-1] += extraSP * 8
+		shift := extraSP * HuntTunables.WeightPerSP
+		weights[0] = max(0, weights[0]-shift)
+		weights[len(weights)-1] += shift
 	}
 
 	total := 0
@@ -269,6 +564,26 @@ func ChooseEnemy(state *State, extraSP int, rng RNG) string {
 	return pool[0]
 }
 
+// ChooseEncounter picks the enemies for a fight: ChooseEnemy's pick, which
+// from PackMinLevel on may come as a pack of 2–PackMax. Pack rolls draw from
+// rng only for enemies that form packs. With EnemyVariants on, the pick
+// rolls a variant first, shared by its whole pack.
+func ChooseEncounter(state *State, extraSP int, rng RNG) []EnemyTemplate {
+	enemy := Enemies[ChooseEnemy(state, extraSP, rng)]
+	if EnemyVariants {
+		enemy = RollVariant(enemy, rng)
+	}
+	if enemy.PackMax < 2 || state.Player.Level < PackMinLevel || rng.Float64() >= PackChance {
+		return []EnemyTemplate{enemy}
+	}
+	size := 2 + rng.Intn(enemy.PackMax-1)
+	pack := make([]EnemyTemplate, size)
+	for i := range pack {
+		pack[i] = enemy
+	}
+	return pack
+}
+
 // PlayerPtr helper (clarity)
 func (s *State) PlayerPtr() *Player {
 	return &s.Player
@@ -0,0 +1,171 @@
+package engine
+
+// ================================
+// Item Affixes
+// ================================
+
+// ItemAffixes rolls an affix on every equippable item as it is added: a
+// blessed copy gets a bonus, a cursed one a penalty and can't be taken off.
+// Off by default, so pickups draw nothing extra from the RNG.
+var ItemAffixes = false
+
+// Affixes.
+const (
+	AffixBlessed = "blessed"
+	AffixCursed  = "cursed"
+)
+
+// AffixTuning weights the affix roll. The chances are probabilities out of
+// 1; Bonus is the stat shift either affix applies to the item's stats.
+type AffixTuning struct {
+	BlessedChance float64
+	CursedChance  float64
+	Bonus         int
+}
+
+var AffixTunables = AffixTuning{BlessedChance: 0.08, CursedChance: 0.06, Bonus: 1}
+
+// RollAffix draws one Float64: cursed, blessed or "" for a plain copy.
+func RollAffix(rng RNG) string {
+	roll := rng.Float64()
+	switch {
+	case roll < AffixTunables.CursedChance:
+		return AffixCursed
+	case roll < AffixTunables.CursedChance+AffixTunables.BlessedChance:
+		return AffixBlessed
+	}
+	return ""
+}
+
+// rollAffix rolls the affix of a batch of itemID as it is added, recording
+// it on one of the added copies. It is rolled before ItemAdded is emitted,
+// so observers see the affix too. rng is only drawn from for equippable
+// items while ItemAffixes is on.
+func rollAffix(p *Player, itemID string, rng RNG) string {
+	itemID = NormalizeItemID(itemID)
+	if !ItemAffixes || Items[itemID].Slot == "" {
+		return ""
+	}
+	affix := RollAffix(rng)
+	putAffix(p, itemID, affix)
+	return affix
+}
+
+// AffixShift is how far an affix moves a non-zero stat.
+func AffixShift(affix string, stat int) int {
+	if stat == 0 {
+		return 0
+	}
+	switch affix {
+	case AffixBlessed:
+		return AffixTunables.Bonus
+	case AffixCursed:
+		return -min(AffixTunables.Bonus, stat)
+	}
+	return 0
+}
+
+// ================================
+// Instances
+// ================================
+
+// putAffix records the affix of one carried copy of itemID.
+func putAffix(p *Player, itemID, affix string) {
+	if affix == "" {
+		return
+	}
+	if p.Affixes == nil {
+		p.Affixes = map[string][]string{}
+	}
+	p.Affixes[itemID] = append(p.Affixes[itemID], affix)
+}
+
+// takeAffix picks the copy of itemID to equip, blessed before plain before
+// cursed, and returns its affix.
+func takeAffix(p *Player, itemID string) string {
+	copies := p.Affixes[itemID]
+	pick := -1
+	for i, a := range copies {
+		if a == AffixBlessed {
+			pick = i
+			break
+		}
+	}
+	if pick < 0 && len(copies) > 0 && p.Inventory[itemID] <= len(copies) {
+		pick = 0
+	}
+	if pick < 0 {
+		return ""
+	}
+	affix := copies[pick]
+	copies = append(copies[:pick:pick], copies[pick+1:]...)
+	if len(copies) == 0 {
+		delete(p.Affixes, itemID)
+	} else {
+		p.Affixes[itemID] = copies
+	}
+	return affix
+}
+
+// trimAffixes drops recorded affixes beyond the copies still carried.
+// Plain copies go first, so sales and crafting spend them before affixed
+// ones.
+func trimAffixes(p *Player, itemID string) {
+	copies, ok := p.Affixes[itemID]
+	if !ok {
+		return
+	}
+	have := p.Inventory[itemID]
+	if have <= 0 {
+		delete(p.Affixes, itemID)
+		return
+	}
+	if len(copies) > have {
+		p.Affixes[itemID] = copies[:have]
+	}
+}
+
+// ================================
+// Curses
+// ================================
+
+// equippedAffix is the affix of the item worn in slot.
+func equippedAffix(p *Player, slot string) string {
+	return p.EquippedAffixes[slot]
+}
+
+// setEquippedAffix records the affix of the item now in slot.
+func setEquippedAffix(p *Player, slot, affix string) {
+	if affix == "" {
+		delete(p.EquippedAffixes, slot)
+		return
+	}
+	if p.EquippedAffixes == nil {
+		p.EquippedAffixes = map[string]string{}
+	}
+	p.EquippedAffixes[slot] = affix
+}
+
+// WearingCursed reports whether any equipped item is cursed.
+func WearingCursed(p *Player) bool {
+	for _, affix := range p.EquippedAffixes {
+		if affix == AffixCursed {
+			return true
+		}
+	}
+	return false
+}
+
+// liftCurses clears the curse from every equipped item, emitting
+// CurseLifted per slot in slot order.
+func liftCurses(p *Player) Events {
+	var events Events
+	for _, slot := range sortedIDs(p.EquippedAffixes) {
+		if p.EquippedAffixes[slot] != AffixCursed {
+			continue
+		}
+		delete(p.EquippedAffixes, slot)
+		events = emit(events, CurseLifted{ItemID: p.Equipment[slot], Slot: slot})
+	}
+	return events
+}
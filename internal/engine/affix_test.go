@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddItemWithEvent_CursedItemCantBeRemoved(t *testing.T) {
+	oldOn := ItemAffixes
+	ItemAffixes = true
+	defer func() { ItemAffixes = oldOn }()
+
+	state := DefaultState()
+	state.Player.Inventory = map[string]int{}
+	p := &state.Player
+
+	// A roll of 0 lands in the cursed band, and observers see it too.
+	var observed string
+	unsubscribe := Subscribe(func(e Event) {
+		if added, ok := e.(ItemAdded); ok {
+			observed = added.Affix
+		}
+	})
+	events := AddItemWithEvent(p, "rusty_dagger", 1, &seqRNG{floats: []float64{0}})
+	unsubscribe()
+	if added := events[0].(ItemAdded); added.Affix != AffixCursed || observed != AffixCursed {
+		t.Fatalf("expected a cursed roll, got %+v (observed %q)", added, observed)
+	}
+
+	if _, err := Equip(&state, "rusty_dagger"); err != nil {
+		t.Fatalf("Equip: %v", err)
+	}
+	if got := EquipTotal(p, StatAttack); got != Items["rusty_dagger"].Attack-AffixTunables.Bonus {
+		t.Fatalf("cursed attack = %d", got)
+	}
+	if _, err := RunCommand(&state, "unequip weapon", &seqRNG{}); !errors.Is(err, ErrCursed) {
+		t.Fatalf("unequip should be blocked by the curse, got %v", err)
+	}
+	AddItem(p, "orcish_blade", 1)
+	if _, err := Equip(&state, "orcish_blade"); !errors.Is(err, ErrCursed) {
+		t.Fatalf("equipping over a curse should be blocked, got %v", err)
+	}
+
+	AddItem(p, "remove_curse_scroll", 1)
+	events, err := UseItem(&state, "remove_curse_scroll", &seqRNG{})
+	if err != nil {
+		t.Fatalf("UseItem: %v", err)
+	}
+	lifted := false
+	for _, ev := range events {
+		_, ok := ev.(CurseLifted)
+		lifted = lifted || ok
+	}
+	if !lifted {
+		t.Fatalf("expected CurseLifted, got %#v", events)
+	}
+	if _, err := Unequip(&state, "weapon"); err != nil {
+		t.Fatalf("unequip after the curse lifted: %v", err)
+	}
+	if !HasItem(p, "rusty_dagger", 1) || len(p.Affixes["rusty_dagger"]) != 0 {
+		t.Fatalf("expected a plain dagger back, got %v / %v", p.Inventory, p.Affixes)
+	}
+}
+
+func TestEquip_PrefersBlessedThenPlainCopies(t *testing.T) {
+	state := DefaultState()
+	p := &state.Player
+	p.Inventory = map[string]int{"rusty_dagger": 3}
+	p.Affixes = map[string][]string{"rusty_dagger": {AffixCursed, AffixBlessed}}
+
+	if _, err := Equip(&state, "rusty_dagger"); err != nil {
+		t.Fatalf("Equip: %v", err)
+	}
+	if got := p.EquippedAffixes[SlotWeapon]; got != AffixBlessed {
+		t.Fatalf("expected the blessed copy equipped, got %q", got)
+	}
+
+	// Selling spends plain copies before affixed ones.
+	RemoveItem(p, "rusty_dagger", 1)
+	if got := p.Affixes["rusty_dagger"]; len(got) != 1 || got[0] != AffixCursed {
+		t.Fatalf("expected the cursed copy kept, got %v", got)
+	}
+}
@@ -0,0 +1,55 @@
+package engine
+
+import "sort"
+
+// ================================
+// Event Analytics
+// ================================
+
+// TrackEventCounts subscribes to engine events and, after each command,
+// adds their tally by EventType to Meta.EventCounts of the state the
+// command left behind. It returns a function that stops tracking.
+func TrackEventCounts() (stop func()) {
+	var pending []string
+	detachEvents := Subscribe(func(e Event) {
+		pending = append(pending, e.EventType())
+	})
+	detachCommands := SubscribeCommands(func(_ string, state *State, _ error) {
+		if len(pending) == 0 {
+			return
+		}
+		if state.Meta.EventCounts == nil {
+			state.Meta.EventCounts = map[string]int{}
+		}
+		for _, t := range pending {
+			state.Meta.EventCounts[t]++
+		}
+		pending = pending[:0]
+	})
+	return func() {
+		detachEvents()
+		detachCommands()
+	}
+}
+
+// EventCount is one row of EventTally.
+type EventCount struct {
+	Type  string
+	Count int
+}
+
+// EventTally returns the save's event counts, most frequent first and
+// alphabetical among ties.
+func EventTally(state *State) []EventCount {
+	out := make([]EventCount, 0, len(state.Meta.EventCounts))
+	for t, n := range state.Meta.EventCounts {
+		out = append(out, EventCount{Type: t, Count: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Type < out[j].Type
+	})
+	return out
+}
@@ -0,0 +1,42 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrackEventCounts_TalliesByType(t *testing.T) {
+	stop := TrackEventCounts()
+	defer stop()
+
+	state := DefaultState()
+	state.Player.HP = 50
+	AddItem(&state.Player, "healing_potion", 2)
+	rng := &seqRNG{}
+
+	for _, line := range []string{"rest 1", "use healing_potion", "use healing_potion"} {
+		if _, err := RunCommand(&state, line, rng); err != nil {
+			t.Fatalf("%s: %v", line, err)
+		}
+	}
+
+	counts := state.Meta.EventCounts
+	if counts["sp_spent"] != 1 || counts["item_removed"] != 2 {
+		t.Fatalf("unexpected counts: %v", counts)
+	}
+	if counts["hp_restored"] < 2 {
+		t.Fatalf("expected an hp_restored per heal, got %v", counts)
+	}
+
+	tally := EventTally(&state)
+	for i := 1; i < len(tally); i++ {
+		if tally[i].Count > tally[i-1].Count {
+			t.Fatalf("tally not sorted by count: %v", tally)
+		}
+	}
+
+	// Counts survive a clone, as they do a save.
+	if clone := state.Clone(); !reflect.DeepEqual(clone.Meta.EventCounts, counts) {
+		t.Fatalf("clone lost counts: %v", clone.Meta.EventCounts)
+	}
+}
@@ -0,0 +1,88 @@
+package engine
+
+import "testing"
+
+func TestRunArena_ScoreIsWavesPlusGold(t *testing.T) {
+	old := ArenaMaxWaves
+	ArenaMaxWaves = 3
+	defer func() { ArenaMaxWaves = old }()
+
+	// Zero draws: minimum damage both ways and each enemy's minimum gold.
+	res := RunArena(7, &seqRNG{})
+	if res.Waves != 3 {
+		t.Fatalf("expected all 3 waves cleared, got %d", res.Waves)
+	}
+	gold := 0
+	for wave := 1; wave <= 3; wave++ {
+		gold += ArenaEnemy(wave).Gold
+	}
+	if res.Gold != gold {
+		t.Fatalf("expected %d gold from the waves, got %d", gold, res.Gold)
+	}
+	if res.Score() != res.Waves+res.Gold {
+		t.Fatalf("expected score %d, got %d", res.Waves+res.Gold, res.Score())
+	}
+	if again := RunArena(7, &seqRNG{}); again != res {
+		t.Fatalf("expected a replay to match, got %+v then %+v", res, again)
+	}
+}
+
+func TestRunArena_DoesNotNotifyObservers(t *testing.T) {
+	seen := 0
+	defer Subscribe(func(Event) { seen++ })()
+
+	RunArena(7, &seqRNG{})
+	if seen != 0 {
+		t.Fatalf("expected arena fights to stay off observers, saw %d events", seen)
+	}
+}
+
+func TestArenaEnemy_ScalesPerWave(t *testing.T) {
+	first, later := ArenaEnemy(1), ArenaEnemy(1+len(ArenaRoster))
+	if first.ID != later.ID {
+		t.Fatalf("expected the roster to cycle, got %s then %s", first.ID, later.ID)
+	}
+	if later.HP <= first.HP || later.AttackMax <= first.AttackMax {
+		t.Fatalf("expected a later wave to be tougher: %+v vs %+v", first, later)
+	}
+}
+
+func TestArenaRecords_KeepsBestPerSeed(t *testing.T) {
+	records := ArenaRecords{}
+	if !records.Record(ArenaResult{Seed: 1, Waves: 2, Gold: 10}) {
+		t.Fatal("expected the first run to set a record")
+	}
+	if records.Record(ArenaResult{Seed: 1, Waves: 1, Gold: 5}) {
+		t.Fatal("expected a lower score to leave the record alone")
+	}
+	if !records.Record(ArenaResult{Seed: 2, Waves: 1}) || records[1] != 12 {
+		t.Fatalf("expected separate records per seed, got %v", records)
+	}
+}
+
+func TestArenaState_LevelsLikeGrantXP(t *testing.T) {
+	state := ArenaState()
+	played := DefaultState()
+	for played.Player.Level < ArenaStartLevel {
+		GrantXP(&played, XPToNext(played.Player.Level))
+	}
+	if state.Player.Level != ArenaStartLevel || state.Player.MaxHP != played.Player.MaxHP || state.Player.MaxSP != played.Player.MaxSP {
+		t.Fatalf("arena character %+v doesn't match a levelled one %+v", state.Player, played.Player)
+	}
+	if state.Player.HP != state.Player.MaxHP {
+		t.Fatalf("expected full HP, got %d/%d", state.Player.HP, state.Player.MaxHP)
+	}
+}
+
+func TestArenaStandard_RejectsCustomRules(t *testing.T) {
+	if !ArenaStandard() {
+		t.Fatal("expected the built-in rules to be standard")
+	}
+	defer ApplyTunables(CurrentTunables())
+	custom := CurrentTunables()
+	custom.MaxCombatTurns++
+	ApplyTunables(custom)
+	if ArenaStandard() {
+		t.Fatal("expected an overridden tunable to leave the standard rules")
+	}
+}
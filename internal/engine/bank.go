@@ -0,0 +1,97 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ================================
+// Bank
+// ================================
+
+// InterestInterval is how many commands pass between interest payments on
+// banked gold. It is a variable so it can be tuned; zero disables interest.
+var InterestInterval = 25
+
+// InterestPercent is the share of Player.BankedGold paid each interval,
+// rounded down.
+var InterestPercent = 2
+
+// errBankAmount rejects a non-positive deposit or withdrawal.
+var errBankAmount = errors.New("amount must be a positive number or 'all'")
+
+// Deposit moves amount gold from the purse into the bank, where death and
+// revive fees can't touch it.
+func Deposit(state *State, amount int) (Events, error) {
+	events := Events{}
+	p := &state.Player
+	if amount <= 0 {
+		return events, errBankAmount
+	}
+	if amount > p.Gold {
+		return events, fmt.Errorf("you only carry %d gold", p.Gold)
+	}
+
+	p.Gold -= amount
+	p.BankedGold += amount
+	return emit(events, GoldBanked{Amount: amount, Banked: p.BankedGold}), nil
+}
+
+// Withdraw moves amount banked gold back into the purse.
+func Withdraw(state *State, amount int) (Events, error) {
+	events := Events{}
+	p := &state.Player
+	if amount <= 0 {
+		return events, errBankAmount
+	}
+	if amount > p.BankedGold {
+		return events, fmt.Errorf("you only have %d gold banked", p.BankedGold)
+	}
+
+	p.BankedGold -= amount
+	p.Gold += amount
+	return emit(events, GoldWithdrawn{Amount: amount, Banked: p.BankedGold}), nil
+}
+
+// AccrueInterest counts one command toward the next interest payment while
+// gold is banked, and pays InterestPercent when it is due. The count only
+// runs while something is banked.
+func AccrueInterest(state *State) Events {
+	p := &state.Player
+	if InterestInterval <= 0 || p.BankedGold <= 0 {
+		return nil
+	}
+
+	state.Meta.InterestTicks++
+	if state.Meta.InterestTicks < InterestInterval {
+		return nil
+	}
+	state.Meta.InterestTicks = 0
+
+	interest := p.BankedGold * InterestPercent / 100
+	if interest <= 0 {
+		return nil
+	}
+	p.BankedGold += interest
+	return emit(nil, InterestAccrued{Amount: interest, Banked: p.BankedGold})
+}
+
+// bankAmount parses a deposit or withdraw argument: a number, or "all"
+// for everything available.
+func bankAmount(args []string, all int) (int, error) {
+	if len(args) == 0 {
+		return 0, errBankAmount
+	}
+	if args[0] == "all" {
+		if all <= 0 {
+			return 0, errBankAmount
+		}
+		return all, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, errBankAmount
+	}
+	return n, nil
+}
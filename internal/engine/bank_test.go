@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDepositAndWithdraw_Bounds(t *testing.T) {
+	state := DefaultState()
+	state.Player.Gold = 100
+
+	if _, err := Deposit(&state, 0); !errors.Is(err, errBankAmount) {
+		t.Fatalf("expected a zero deposit rejected, got %v", err)
+	}
+	if _, err := Deposit(&state, 101); err == nil {
+		t.Fatal("expected a deposit over the purse rejected")
+	}
+	events, err := Deposit(&state, 60)
+	if err != nil {
+		t.Fatalf("Deposit returned error: %v", err)
+	}
+	if state.Player.Gold != 40 || state.Player.BankedGold != 60 {
+		t.Fatalf("after deposit gold=%d banked=%d, want 40 and 60", state.Player.Gold, state.Player.BankedGold)
+	}
+	if ev, ok := events[0].(GoldBanked); !ok || ev.Amount != 60 || ev.Banked != 60 {
+		t.Fatalf("expected GoldBanked{60, 60}, got %#v", events)
+	}
+
+	if _, err := Withdraw(&state, -5); !errors.Is(err, errBankAmount) {
+		t.Fatalf("expected a negative withdrawal rejected, got %v", err)
+	}
+	if _, err := Withdraw(&state, 61); err == nil {
+		t.Fatal("expected a withdrawal over the balance rejected")
+	}
+	if _, err := Withdraw(&state, 25); err != nil {
+		t.Fatalf("Withdraw returned error: %v", err)
+	}
+	if state.Player.Gold != 65 || state.Player.BankedGold != 35 {
+		t.Fatalf("after withdraw gold=%d banked=%d, want 65 and 35", state.Player.Gold, state.Player.BankedGold)
+	}
+}
+
+func TestBankCommands_AllAndDungeonGate(t *testing.T) {
+	state := DefaultState()
+	state.Player.Gold = 30
+	rng := &seqRNG{}
+
+	if _, err := RunCommand(&state, "deposit all", rng); err != nil {
+		t.Fatalf("deposit all failed: %v", err)
+	}
+	if state.Player.Gold != 0 || state.Player.BankedGold != 30 {
+		t.Fatalf("after deposit all gold=%d banked=%d", state.Player.Gold, state.Player.BankedGold)
+	}
+	if _, err := RunCommand(&state, "deposit all", rng); !errors.Is(err, errBankAmount) {
+		t.Fatalf("expected deposit all with an empty purse rejected, got %v", err)
+	}
+
+	state.Dungeon = &DungeonRun{ID: "crypt"}
+	if _, err := RunCommand(&state, "withdraw 10", rng); !errors.Is(err, errInDungeon) {
+		t.Fatalf("expected the bank closed in a dungeon, got %v", err)
+	}
+}
+
+func TestAccrueInterest_PaysEveryInterval(t *testing.T) {
+	oldInterval, oldPercent := InterestInterval, InterestPercent
+	InterestInterval, InterestPercent = 3, 10
+	defer func() { InterestInterval, InterestPercent = oldInterval, oldPercent }()
+
+	observed := 0
+	defer Subscribe(func(e Event) {
+		if _, ok := e.(InterestAccrued); ok {
+			observed++
+		}
+	})()
+
+	state := DefaultState()
+	state.Player.BankedGold = 100
+
+	var paid []int
+	for range 7 {
+		for _, ev := range AccrueInterest(&state) {
+			paid = append(paid, ev.(InterestAccrued).Amount)
+		}
+	}
+	// Interest compounds: 10% of 100, then 10% of 110.
+	if len(paid) != 2 || paid[0] != 10 || paid[1] != 11 {
+		t.Fatalf("expected payments [10 11], got %v", paid)
+	}
+	if state.Player.BankedGold != 121 {
+		t.Fatalf("expected 121 banked, got %d", state.Player.BankedGold)
+	}
+	if observed != 2 {
+		t.Fatalf("expected observers to see both payments, saw %d", observed)
+	}
+
+	// Nothing banked, nothing counted.
+	empty := DefaultState()
+	for range 5 {
+		AccrueInterest(&empty)
+	}
+	if empty.Meta.InterestTicks != 0 {
+		t.Fatalf("expected no ticks without banked gold, got %d", empty.Meta.InterestTicks)
+	}
+}
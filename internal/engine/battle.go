@@ -0,0 +1,161 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ================================
+// Targeted Combat
+// ================================
+
+// Foe is one enemy in a battle and the HP it has left. Variant is the
+// adjective of its rolled variant, if it has one.
+type Foe struct {
+	ID      string `json:"id"`
+	HP      int    `json:"hp"`
+	Variant string `json:"variant,omitempty"`
+}
+
+// template is the foe's enemy, with its variant applied again.
+func (f Foe) template() EnemyTemplate {
+	enemy := Enemies[f.ID]
+	for _, v := range Variants {
+		if v.Adjective == f.Variant {
+			return ApplyVariant(enemy, v)
+		}
+	}
+	return enemy
+}
+
+// Battle is a group fight in progress, resumed one round per Attack. XP,
+// gold and loot accumulate as foes fall and are paid, scaled by Mult, on a
+// win.
+type Battle struct {
+	Foes  []Foe   `json:"foes"`
+	Mult  float64 `json:"mult"`
+	Turns int     `json:"turns"`
+
+	XP   int      `json:"xp"`
+	Gold int      `json:"gold"`
+	Loot []string `json:"loot,omitempty"`
+
+	// StolenGold and StolenItems are handed back if the fight is won.
+	StolenGold  int      `json:"stolen_gold,omitempty"`
+	StolenItems []string `json:"stolen_items,omitempty"`
+}
+
+// errInBattle blocks every command but `attack` and `use` mid-fight.
+var errInBattle = errors.New("you're in a fight: attack <target>")
+
+// Targets lists the foes still standing, numbered for Attack.
+func (b *Battle) Targets() []Target {
+	var targets []Target
+	for i, f := range b.Foes {
+		if f.HP > 0 {
+			targets = append(targets, Target{Index: i + 1, EnemyID: f.ID, HP: f.HP})
+		}
+	}
+	return targets
+}
+
+// StartBattle opens a staged fight against enemies. mult scales the rewards
+// paid on a win.
+func StartBattle(state *State, enemies []EnemyTemplate, mult float64) Events {
+	var events Events
+	b := &Battle{Mult: mult}
+	for _, enemy := range enemies {
+		b.Foes = append(b.Foes, Foe{ID: enemy.ID, HP: enemy.HP, Variant: enemy.Variant})
+		events = emit(events, EncounterStarted{EnemyID: enemy.ID, Name: encounterName(enemy)})
+	}
+	state.Battle = b
+	return emit(events, TurnStarted{Turn: 1, Targets: b.Targets()})
+}
+
+// Attack plays one round of the current battle: the player strikes the
+// foe numbered index, then every foe still standing strikes back. Draws
+// from rng in the same order as ResolveGroupCombat.
+func Attack(state *State, index int, rng RNG) (Events, error) {
+	events := Events{}
+	b := state.Battle
+	if b == nil {
+		return events, errors.New("no fight in progress")
+	}
+	if index < 1 || index > len(b.Foes) || b.Foes[index-1].HP <= 0 {
+		return events, fmt.Errorf("no standing enemy %d", index)
+	}
+
+	rng = stream(rng, StreamCombat)
+	player := &state.Player
+	foe := &b.Foes[index-1]
+	enemy := foe.template()
+
+	pDmg := playerDamage(player, rng)
+	overkill := max(0, pDmg-foe.HP)
+	foe.HP = max(0, foe.HP-pDmg)
+	events = emit(events, DamageDealt{
+		Source:   "player",
+		Target:   foe.ID,
+		Amount:   pDmg,
+		HPLeft:   foe.HP,
+		Overkill: overkill,
+	})
+
+	if foe.HP == 0 {
+		won := CombatResult{XP: b.XP, Gold: b.Gold, Loot: b.Loot}
+		events = emit(events, defeatEnemy(state, enemy, &won, rng))
+		b.XP, b.Gold, b.Loot = won.XP, won.Gold, won.Loot
+		if len(b.Targets()) == 0 {
+			return append(events, endBattle(state, "win", rng)...), nil
+		}
+	}
+
+	for _, t := range b.Targets() {
+		enemy := b.Foes[t.Index-1].template()
+		eDmg := enemyDamage(player, enemy, rng)
+		player.HP = max(0, player.HP-eDmg)
+		events = emit(events, DamageDealt{
+			Source: t.EnemyID,
+			Target: "player",
+			Amount: eDmg,
+			HPLeft: player.HP,
+		})
+		if theft, ok := stealFrom(player, enemy, rng); ok {
+			b.StolenGold += theft.Gold
+			if theft.ItemID != "" {
+				b.StolenItems = append(b.StolenItems, theft.ItemID)
+			}
+			events = emit(events, theft)
+		}
+		if player.HP == 0 {
+			events = emit(events, PlayerDefeated{})
+			return append(events, endBattle(state, "lose", rng)...), nil
+		}
+	}
+
+	b.Turns++
+	if b.Turns >= MaxCombatTurns {
+		events = emit(events, CombatStalemate{EnemyID: b.Targets()[0].EnemyID, Turns: b.Turns})
+		return append(events, endBattle(state, "stalemate", rng)...), nil
+	}
+	return emit(events, TurnStarted{Turn: b.Turns + 1, Targets: b.Targets()}), nil
+}
+
+// endBattle clears the battle, uses up a buff encounter and a durability
+// point and, on a win, pays out and returns anything stolen.
+func endBattle(state *State, outcome string, rng RNG) Events {
+	b := state.Battle
+	state.Battle = nil
+	events := tickBuffs(&state.Player)
+	events = append(events, wearGear(&state.Player)...)
+	if outcome == "win" {
+		events = append(events, payCombat(state, CombatResult{
+			Outcome: outcome,
+			XP:      b.XP,
+			Gold:    b.Gold,
+			Loot:    b.Loot,
+		}, b.Mult, rng)...)
+		events = append(events, recoverStolen(&state.Player, b.StolenGold, b.StolenItems)...)
+	}
+	return events
+}
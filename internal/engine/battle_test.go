@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAttack_FocusFireKillsChosenEnemyFirst(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 10 // 11–12 damage: each wolf takes two hits
+	gold := state.Player.Gold
+
+	wolf := Enemies["wolf"]
+	events := StartBattle(&state, []EnemyTemplate{wolf, wolf, wolf}, 1)
+	if state.Battle == nil {
+		t.Fatal("expected a battle in progress")
+	}
+	if turn, ok := events[len(events)-1].(TurnStarted); !ok || len(turn.Targets) != 3 {
+		t.Fatalf("expected TurnStarted listing 3 targets, got %#v", events[len(events)-1])
+	}
+
+	// seqRNG yields zeros: 11 damage per hit, 3 per bite, minimum gold, no loot.
+	rng := &seqRNG{floats: []float64{0.9, 0.9, 0.9, 0.9, 0.9, 0.9}}
+	for i := 0; i < 2; i++ {
+		if _, err := RunCommand(&state, "attack 3", rng); err != nil {
+			t.Fatalf("attack 3 (hit %d): %v", i+1, err)
+		}
+	}
+	if hp := []int{state.Battle.Foes[0].HP, state.Battle.Foes[1].HP, state.Battle.Foes[2].HP}; hp[2] != 0 || hp[0] != wolf.HP || hp[1] != wolf.HP {
+		t.Fatalf("expected only wolf 3 down, got HP %v", hp)
+	}
+	if _, err := Attack(&state, 3, rng); err == nil {
+		t.Fatal("expected attacking a fallen wolf to fail")
+	}
+	if _, err := RunCommand(&state, "explore", rng); !errors.Is(err, errInBattle) {
+		t.Fatalf("expected explore blocked mid-fight, got %v", err)
+	}
+
+	for _, target := range []int{1, 1, 2} {
+		if _, err := Attack(&state, target, rng); err != nil {
+			t.Fatalf("attack %d: %v", target, err)
+		}
+	}
+	last, err := Attack(&state, 2, rng)
+	if err != nil {
+		t.Fatalf("final attack: %v", err)
+	}
+
+	if state.Battle != nil {
+		t.Fatal("expected the battle over")
+	}
+	if state.Player.Gold != gold+3*wolf.Gold {
+		t.Fatalf("expected %d gold, got %d", gold+3*wolf.Gold, state.Player.Gold)
+	}
+	// Bites: 3 + 2 + 2 + 1 + 1 rounds of wolves still standing, 3 damage each.
+	if want := DefaultMaxHP - 9*3; state.Player.HP != want {
+		t.Fatalf("expected %d HP, got %d", want, state.Player.HP)
+	}
+	for _, ev := range last {
+		if _, ok := ev.(TurnStarted); ok {
+			t.Fatal("expected no further turn after the last wolf falls")
+		}
+	}
+}
+
+func TestHunt_TargetedCombatStagesPacks(t *testing.T) {
+	state := DefaultState()
+	state.Meta.TargetedCombat = true
+	state.Player.Level = PackMinLevel
+	state.Player.SP = 10
+
+	// roll 50 is a wolf at level 3; a pack of 3.
+	if _, err := Hunt(&state, 0, &seqRNG{ints: []int{50, 1}, floats: []float64{0}}); err != nil {
+		t.Fatalf("hunt: %v", err)
+	}
+	if state.Battle == nil || len(state.Battle.Foes) != 3 {
+		t.Fatalf("expected a staged fight with 3 wolves, got %+v", state.Battle)
+	}
+	if state.Battle.Mult != HuntTunables.Multiplier(0) {
+		t.Fatalf("expected the hunt multiplier carried into the battle")
+	}
+}
+
+func TestUseItem_SmokeBombEscapesBattle(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = PackMinLevel
+	state.Player.SP = 10
+	state.Meta.TargetedCombat = true
+	AddItem(&state.Player, "smoke_bomb", 1)
+	if _, err := Hunt(&state, 0, &seqRNG{ints: []int{50, 1}, floats: []float64{0}}); err != nil || state.Battle == nil {
+		t.Fatalf("expected a staged fight, got %v, %+v", err, state.Battle)
+	}
+	gold := state.Player.Gold
+
+	events, err := RunCommand(&state, "use smoke_bomb", &seqRNG{})
+	if err != nil {
+		t.Fatalf("use smoke_bomb mid-battle: %v", err)
+	}
+	if state.Battle != nil {
+		t.Fatalf("expected the battle over, got %+v", state.Battle)
+	}
+	if state.Player.Gold != gold {
+		t.Fatalf("escaping should pay nothing, gold %d -> %d", gold, state.Player.Gold)
+	}
+	escaped := false
+	for _, ev := range events {
+		if e, ok := ev.(EncounterEnded); ok && e.Reason == "escaped" {
+			escaped = true
+		}
+	}
+	if !escaped {
+		t.Fatalf("expected EncounterEnded, got %#v", events)
+	}
+}
+
+func TestAttack_VariantFoesKeepTheirShift(t *testing.T) {
+	scarred := ApplyVariant(Enemies["wolf"], Variants[0])
+	state := DefaultState()
+	StartBattle(&state, []EnemyTemplate{scarred, Enemies["wolf"]}, 1)
+
+	if got := state.Battle.Foes[0].template(); got.AttackMin != scarred.AttackMin || got.Name != scarred.Name {
+		t.Fatalf("expected the scarred wolf back, got %+v", got)
+	}
+	// Striking the plain wolf leaves both standing to hit back, each at
+	// the low end of its attack range.
+	hp := state.Player.HP
+	events, err := Attack(&state, 2, &seqRNG{})
+	if err != nil {
+		t.Fatalf("attack: %v", err)
+	}
+	var hits []int
+	for _, ev := range events {
+		if d, ok := ev.(DamageDealt); ok && d.Target == "player" {
+			hits = append(hits, d.Amount)
+		}
+	}
+	plain := enemyDamage(&state.Player, Enemies["wolf"], &seqRNG{})
+	if len(hits) == 0 || hits[0] == plain || hits[0] != enemyDamage(&state.Player, scarred, &seqRNG{}) {
+		t.Fatalf("expected the scarred wolf to hit with its shifted attack, got %v (HP %d -> %d)", hits, hp, state.Player.HP)
+	}
+}
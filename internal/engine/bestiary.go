@@ -0,0 +1,33 @@
+package engine
+
+// ================================
+// Bestiary
+// ================================
+
+// RecordBestiary updates the bestiary from a command's events: encounters
+// mark an enemy as seen and defeats add to its kill count.
+func RecordBestiary(state *State, events Events) {
+	for _, ev := range events {
+		switch e := ev.(type) {
+		case EncounterStarted:
+			entry := bestiaryEntry(state, e.EnemyID)
+			if entry.Seen == 0 {
+				entry.FirstSeen = state.Meta.CommandCount
+			}
+			entry.Seen++
+			entry.LastSeen = state.Meta.CommandCount
+			state.Bestiary[e.EnemyID] = entry
+		case EnemyDefeated:
+			entry := bestiaryEntry(state, e.EnemyID)
+			entry.Killed++
+			state.Bestiary[e.EnemyID] = entry
+		}
+	}
+}
+
+func bestiaryEntry(state *State, enemyID string) BestiaryEntry {
+	if state.Bestiary == nil {
+		state.Bestiary = map[string]BestiaryEntry{}
+	}
+	return state.Bestiary[enemyID]
+}
@@ -0,0 +1,37 @@
+package engine
+
+import "testing"
+
+func TestRunCommand_ExploreIntoGoblinFightRecordsBestiary(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 10
+
+	// roll 31 -> encounter, weighted pick 0 -> goblin
+	if _, err := RunCommand(&state, "explore", &seqRNG{ints: []int{30, 0, 0}, floats: []float64{1, 1}}); err != nil {
+		t.Fatalf("explore returned error: %v", err)
+	}
+
+	entry, ok := state.Bestiary["goblin"]
+	if !ok {
+		t.Fatalf("expected goblin recorded in bestiary, got %v", state.Bestiary)
+	}
+	if entry.Seen != 1 || entry.Killed != 1 {
+		t.Fatalf("expected seen=1 killed=1, got %+v", entry)
+	}
+	if entry.FirstSeen != 1 || entry.LastSeen != 1 {
+		t.Fatalf("expected first/last seen at command 1, got %+v", entry)
+	}
+	if len(state.Bestiary) != 1 {
+		t.Fatalf("expected only the goblin discovered, got %v", state.Bestiary)
+	}
+}
+
+func TestRecordBestiary_SeenWithoutKill(t *testing.T) {
+	state := DefaultState()
+	state.Meta.CommandCount = 4
+	RecordBestiary(&state, Events{EncounterStarted{EnemyID: "orc"}, PlayerDefeated{}})
+
+	if got := state.Bestiary["orc"]; got.Seen != 1 || got.Killed != 0 || got.FirstSeen != 4 {
+		t.Fatalf("unexpected orc entry: %+v", got)
+	}
+}
@@ -0,0 +1,58 @@
+package engine
+
+// ================================
+// Buffs
+// ================================
+
+// Buffable stats.
+const (
+	StatAttack  = "attack"
+	StatDefense = "defense"
+)
+
+// Buff is a temporary stat bonus that lasts a number of encounters.
+type Buff struct {
+	Stat      string `json:"stat"`
+	Amount    int    `json:"amount"`
+	Remaining int    `json:"remaining"` // encounters left
+}
+
+// BuffTotal sums the active buffs for stat.
+func BuffTotal(p *Player, stat string) int {
+	total := 0
+	for _, b := range p.Buffs {
+		if b.Stat == stat && b.Remaining > 0 {
+			total += b.Amount
+		}
+	}
+	return total
+}
+
+// AddBuff grants a buff and emits BuffGained.
+func AddBuff(p *Player, b Buff) Events {
+	if b.Remaining <= 0 {
+		return nil
+	}
+	p.Buffs = append(p.Buffs, b)
+	return emit(nil, BuffGained{Stat: b.Stat, Amount: b.Amount, Encounters: b.Remaining})
+}
+
+// tickBuffs uses up one encounter of every buff, dropping and reporting
+// the ones that run out.
+func tickBuffs(p *Player) Events {
+	var events Events
+	kept := p.Buffs[:0]
+	for _, b := range p.Buffs {
+		b.Remaining--
+		if b.Remaining <= 0 {
+			events = emit(events, BuffExpired{Stat: b.Stat, Amount: b.Amount})
+			continue
+		}
+		kept = append(kept, b)
+	}
+	if len(kept) == 0 {
+		kept = nil
+	}
+	p.Buffs = kept
+	return events
+}
@@ -0,0 +1,52 @@
+package engine
+
+import "testing"
+
+func TestAttackBuff_LastsConfiguredFightsThenExpires(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 1
+	AddItem(&state.Player, "berserker_brew", 1)
+
+	if _, err := UseItem(&state, "berserker_brew", &seqRNG{}); err != nil {
+		t.Fatalf("UseItem returned error: %v", err)
+	}
+	if got := BuffTotal(&state.Player, StatAttack); got != 5 {
+		t.Fatalf("expected +5 attack buff, got %d", got)
+	}
+
+	// Level 1 base damage is 2 (roll 0), so a buffed first hit deals 7.
+	firstHit := func() (int, Events) {
+		state.Player.HP = state.Player.MaxHP
+		_, events := ResolveCombat(&state, Enemies["orc"], &seqRNG{ints: []int{0}})
+		for _, ev := range events {
+			if d, ok := ev.(DamageDealt); ok && d.Source == "player" {
+				return d.Amount, events
+			}
+		}
+		t.Fatalf("no player hit in %v", events)
+		return 0, nil
+	}
+
+	for fight := 1; fight <= 3; fight++ {
+		dmg, events := firstHit()
+		if dmg != 7 {
+			t.Fatalf("fight %d: expected buffed hit of 7, got %d", fight, dmg)
+		}
+		expired := false
+		for _, ev := range events {
+			if _, ok := ev.(BuffExpired); ok {
+				expired = true
+			}
+		}
+		if expired != (fight == 3) {
+			t.Fatalf("fight %d: BuffExpired emitted=%v", fight, expired)
+		}
+	}
+
+	if dmg, _ := firstHit(); dmg != 2 {
+		t.Fatalf("expected unbuffed hit of 2 after expiry, got %d", dmg)
+	}
+	if len(state.Player.Buffs) != 0 {
+		t.Fatalf("expected buffs cleared, got %v", state.Player.Buffs)
+	}
+}
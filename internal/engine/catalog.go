@@ -1,5 +1,7 @@
 package engine
 
+import "sort"
+
 // ================================
 // Item Catalog
 // ================================
@@ -10,62 +12,208 @@ type Item struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 
-	// Optional use-effects (used by rules)
-	HPMin int `json:"hp_min,omitempty"`
-	HPMax int `json:"hp_max,omitempty"`
-	SPMin int `json:"sp_min,omitempty"`
-	SPMax int `json:"sp_max,omitempty"`
+	// Description is flavor text shown by `examine`.
+	Description string `json:"description,omitempty"`
+
+	// Rare items are uncommon drops worth calling out.
+	Rare bool `json:"rare,omitempty"`
+
+	// Weight counts against Player.MaxCarryWeight; 0 means 1.
+	Weight int `json:"weight,omitempty"`
+
+	// Slot makes an item equippable; Attack and Defense apply while it is
+	// equipped.
+	Slot    string `json:"slot,omitempty"`
+	Attack  int    `json:"attack,omitempty"`
+	Defense int    `json:"defense,omitempty"`
+
+	// MaxDurability is how many fights gear lasts before it breaks and
+	// gives no bonus; 0 means it never wears.
+	MaxDurability int `json:"max_durability,omitempty"`
+
+	// Price is the gold an item sells for; 0 means it can't be sold.
+	// Junk items have no use and are sold by `sell junk`.
+	Price int  `json:"price,omitempty"`
+	Junk  bool `json:"junk,omitempty"`
+
+	// Optional use-effects, applied in order by UseItem.
+	Effects []Effect `json:"effects,omitempty"`
+
+	// CooldownCommands is how many commands must pass after a use before
+	// the item can be used again; 0 means no cooldown.
+	CooldownCommands int `json:"cooldown_commands,omitempty"`
+}
+
+// EffectKind selects how UseItem applies an Effect.
+type EffectKind string
+
+const (
+	EffectHeal      EffectKind = "heal"       // restore Min–Max HP
+	EffectRestoreSP EffectKind = "restore_sp" // restore Min–Max SP
+	EffectBuff      EffectKind = "buff"       // +Min–Max to Stat for Encounters fights
+	EffectEscape    EffectKind = "escape"     // end the current encounter
+	EffectUncurse   EffectKind = "uncurse"    // lift curses from equipped items
+)
+
+// Effect is one data-driven use-effect of an item.
+type Effect struct {
+	Kind EffectKind `json:"kind"`
+	Min  int        `json:"min,omitempty"`
+	Max  int        `json:"max,omitempty"`
+
+	// Buff effects only.
+	Stat       string `json:"stat,omitempty"`
+	Encounters int    `json:"encounters,omitempty"`
 }
 
 // Items is the global item registry.
 var Items = map[string]Item{
 	"healing_potion": {
-		ID:    "healing_potion",
-		Name:  "Healing Potion",
-		HPMin: 10,
-		HPMax: 25,
-		SPMin: 1,
-		SPMax: 3,
+		ID:          "healing_potion",
+		Name:        "Healing Potion",
+		Description: "A stoppered vial of red tonic. Tastes of iron and honey.",
+		Price:       10,
+		Effects: []Effect{
+			{Kind: EffectHeal, Min: 10, Max: 25},
+			{Kind: EffectRestoreSP, Min: 1, Max: 3},
+		},
 	},
 	"torch": {
-		ID:   "torch",
-		Name: "Torch",
+		ID:          "torch",
+		Name:        "Torch",
+		Description: "Pitch-soaked rags on a stick. Keeps the dark at arm's length.",
+		Price:       2,
 	},
 	"rusty_dagger": {
-		ID:   "rusty_dagger",
-		Name: "Rusty Dagger",
+		ID:            "rusty_dagger",
+		Name:          "Rusty Dagger",
+		Description:   "More rust than blade. Goblins carry them anyway.",
+		Slot:          SlotWeapon,
+		Attack:        1,
+		Price:         4,
+		Junk:          true,
+		Weight:        2,
+		MaxDurability: 10,
 	},
 	"bone_shield": {
-		ID:   "bone_shield",
-		Name: "Bone Shield",
+		ID:            "bone_shield",
+		Name:          "Bone Shield",
+		Description:   "Ribs lashed over a wooden frame. Grim, but it holds.",
+		Slot:          SlotArmor,
+		Defense:       2,
+		Price:         15,
+		Weight:        3,
+		MaxDurability: 20,
 	},
 	"ancient_coin": {
-		ID:   "ancient_coin",
-		Name: "Ancient Coin",
+		ID:          "ancient_coin",
+		Name:        "Ancient Coin",
+		Description: "Stamped with a king nobody remembers. Collectors pay well.",
+		Price:       30,
+		Rare:        true,
 	},
 	"coin_pouch": {
-		ID:   "coin_pouch",
-		Name: "Coin Pouch",
+		ID:          "coin_pouch",
+		Name:        "Coin Pouch",
+		Description: "A bandit's purse, already half spent.",
+		Price:       12,
+		Junk:        true,
 	},
 	"wolf_pelt": {
-		ID:   "wolf_pelt",
-		Name: "Wolf Pelt",
+		ID:          "wolf_pelt",
+		Name:        "Wolf Pelt",
+		Description: "Thick grey fur. A tanner could make something of it.",
+		Price:       6,
 	},
 	"meat": {
-		ID:    "meat",
-		Name:  "Meat",
-		HPMin: 40,
-		HPMax: 40,
-		SPMin: 2,
-		SPMax: 2,
+		ID:          "meat",
+		Name:        "Meat",
+		Description: "A slab of wolf haunch. Filling, if not refined.",
+		Price:       3,
+		Effects: []Effect{
+			{Kind: EffectHeal, Min: 40, Max: 40},
+			{Kind: EffectRestoreSP, Min: 2, Max: 2},
+		},
 	},
 	"bear_claw": {
-		ID:   "bear_claw",
-		Name: "Bear Claw",
+		ID:          "bear_claw",
+		Name:        "Bear Claw",
+		Description: "A curved claw as long as a finger.",
+		Price:       8,
 	},
 	"orcish_blade": {
-		ID:   "orcish_blade",
-		Name: "Orcish Blade",
+		ID:            "orcish_blade",
+		Name:          "Orcish Blade",
+		Description:   "A heavy, notched cleaver forged for orc hands.",
+		Slot:          SlotWeapon,
+		Attack:        3,
+		Price:         40,
+		Weight:        4,
+		Rare:          true,
+		MaxDurability: 30,
+	},
+
+	// Crafted items
+	"fur_cloak": {
+		ID:            "fur_cloak",
+		Name:          "Fur Cloak",
+		Description:   "Wolf pelts stitched into a warm, sturdy cloak.",
+		Slot:          SlotArmor,
+		Defense:       1,
+		Price:         20,
+		Weight:        2,
+		MaxDurability: 25,
+	},
+	"bear_charm": {
+		ID:          "bear_charm",
+		Name:        "Bear Charm",
+		Description: "A claw on a cord. Wearers swear it sharpens the eye.",
+		Slot:        SlotTrinket,
+		Attack:      1,
+		Price:       25,
+	},
+	"orcish_greatblade": {
+		ID:            "orcish_greatblade",
+		Name:          "Orcish Greatblade",
+		Description:   "Two orcish blades hammered into one brutal edge.",
+		Slot:          SlotWeapon,
+		Attack:        5,
+		Price:         80,
+		Weight:        6,
+		MaxDurability: 40,
+	},
+	"berserker_brew": {
+		ID:          "berserker_brew",
+		Name:        "Berserker Brew",
+		Description: "Dark, bitter and foaming. Drink before a fight, not after.",
+		Price:       15,
+		Effects: []Effect{
+			{Kind: EffectBuff, Stat: StatAttack, Min: 5, Max: 5, Encounters: 3},
+		},
+	},
+	"smoke_bomb": {
+		ID:          "smoke_bomb",
+		Name:        "Smoke Bomb",
+		Description: "A clay ball of ash and saltpeter. Throw, then run.",
+		Price:       6,
+		Effects:     []Effect{{Kind: EffectEscape}},
+	},
+	"remove_curse_scroll": {
+		ID:          "remove_curse_scroll",
+		Name:        "Remove Curse Scroll",
+		Description: "A prayer in faded ink. Read aloud, it loosens whatever clings to you.",
+		Price:       15,
+		Effects:     []Effect{{Kind: EffectUncurse}},
+	},
+	"hearty_stew": {
+		ID:          "hearty_stew",
+		Name:        "Hearty Stew",
+		Description: "Meat and roots simmered for hours. Restores body and spirit.",
+		Price:       12,
+		Effects: []Effect{
+			{Kind: EffectHeal, Min: 60, Max: 60},
+			{Kind: EffectRestoreSP, Min: 3, Max: 3},
+		},
 	},
 }
 
@@ -87,8 +235,24 @@ type EnemyTemplate struct {
 	AttackMin int         `json:"attack_min"`
 	AttackMax int         `json:"attack_max"`
 	XP        int         `json:"xp"`
-	Gold      int         `json:"gold"`
+	Gold      int         `json:"gold"`               // minimum gold reward
+	GoldMax   int         `json:"gold_max,omitempty"` // rolled up to this; 0 means fixed
+	PackMax   int         `json:"pack_max,omitempty"` // largest pack; 0 or 1 means always alone
+	Speed     int         `json:"speed,omitempty"`    // beats Player.Speed to strike first under Initiative
 	Loot      []LootEntry `json:"loot"`
+
+	// Level is the enemy's base level for reward scaling; 0 derives it
+	// from HP. See EnemyLevel.
+	Level int `json:"level,omitempty"`
+
+	// StealChance is the chance each strike also lifts StealAmount gold,
+	// or an item when the player has none. 0 never steals.
+	StealChance float64 `json:"steal_chance,omitempty"`
+	StealAmount int     `json:"steal_amount,omitempty"`
+
+	// Variant is the adjective of a rolled variant; catalog templates
+	// leave it empty. See RollVariant.
+	Variant string `json:"-"`
 }
 
 // Enemies is the global enemy registry.
@@ -101,6 +265,8 @@ var Enemies = map[string]EnemyTemplate{
 		AttackMax: 3,
 		XP:        5,
 		Gold:      3,
+		GoldMax:   5,
+		PackMax:   2,
 		Loot: []LootEntry{
 			{ItemID: "rusty_dagger", Chance: 0.20},
 			{ItemID: "healing_potion", Chance: 0.10},
@@ -114,19 +280,24 @@ var Enemies = map[string]EnemyTemplate{
 		AttackMax: 4,
 		XP:        8,
 		Gold:      5,
+		GoldMax:   8,
 		Loot: []LootEntry{
 			{ItemID: "bone_shield", Chance: 0.10},
 			{ItemID: "ancient_coin", Chance: 0.25},
 		},
 	},
 	"bandit": {
-		ID:        "bandit",
-		Name:      "Bandit",
-		HP:        12,
-		AttackMin: 2,
-		AttackMax: 5,
-		XP:        10,
-		Gold:      8,
+		ID:          "bandit",
+		Name:        "Bandit",
+		HP:          12,
+		AttackMin:   2,
+		AttackMax:   5,
+		XP:          10,
+		Speed:       1,
+		Gold:        8,
+		GoldMax:     16,
+		StealChance: 0.15,
+		StealAmount: 5,
 		Loot: []LootEntry{
 			{ItemID: "coin_pouch", Chance: 0.30},
 			{ItemID: "healing_potion", Chance: 0.15},
@@ -139,7 +310,10 @@ var Enemies = map[string]EnemyTemplate{
 		AttackMin: 3,
 		AttackMax: 6,
 		XP:        12,
+		Speed:     2,
 		Gold:      6,
+		GoldMax:   9,
+		PackMax:   3,
 		Loot: []LootEntry{
 			{ItemID: "wolf_pelt", Chance: 0.30},
 			{ItemID: "meat", Chance: 0.40},
@@ -153,6 +327,7 @@ var Enemies = map[string]EnemyTemplate{
 		AttackMax: 8,
 		XP:        20,
 		Gold:      10,
+		GoldMax:   15,
 		Loot: []LootEntry{
 			{ItemID: "bear_claw", Chance: 0.25},
 		},
@@ -165,9 +340,62 @@ var Enemies = map[string]EnemyTemplate{
 		AttackMax: 10,
 		XP:        25,
 		Gold:      15,
+		GoldMax:   25,
 		Loot: []LootEntry{
 			{ItemID: "orcish_blade", Chance: 0.15},
 			{ItemID: "coin_pouch", Chance: 0.25},
 		},
 	},
 }
+
+// ================================
+// Catalog Access
+// ================================
+
+// AllItems returns every catalog item sorted by ID.
+func AllItems() []Item {
+	items := make([]Item, 0, len(Items))
+	for _, id := range sortedIDs(Items) {
+		items = append(items, Items[id])
+	}
+	return items
+}
+
+// AllEnemies returns every enemy template sorted by ID.
+func AllEnemies() []EnemyTemplate {
+	enemies := make([]EnemyTemplate, 0, len(Enemies))
+	for _, id := range EnemyIDs() {
+		enemies = append(enemies, Enemies[id])
+	}
+	return enemies
+}
+
+// ItemByID looks up an item, normalizing the ID first.
+func ItemByID(id string) (Item, bool) {
+	it, ok := Items[NormalizeItemID(id)]
+	return it, ok
+}
+
+// EnemyByID looks up an enemy template.
+func EnemyByID(id string) (EnemyTemplate, bool) {
+	e, ok := Enemies[id]
+	return e, ok
+}
+
+// EnemyLevel is enemy's Level, or one level per 5 HP when none is set.
+func EnemyLevel(enemy EnemyTemplate) int {
+	if enemy.Level > 0 {
+		return enemy.Level
+	}
+	return max(1, enemy.HP/5)
+}
+
+// EnemyIDs returns every enemy template ID in sorted order.
+func EnemyIDs() []string {
+	ids := make([]string, 0, len(Enemies))
+	for id := range Enemies {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ================================
+// Pending Choices
+// ================================
+
+// TreasureChoiceGold makes treasures worth at least this much gold wait for
+// the player to choose what to take. 0 keeps the default of taking
+// everything automatically.
+var TreasureChoiceGold = 0
+
+// Treasure choice options.
+const (
+	TakeGold = "gold"
+	TakeItem = "item"
+	TakeBoth = "both"
+)
+
+// PendingChoice is a decision the next gameplay command must answer. It
+// lives in State so a reload keeps it waiting.
+type PendingChoice struct {
+	Kind string `json:"kind"` // "treasure" or "merchant"
+	Gold int    `json:"gold"`
+	Item string `json:"item"`
+
+	// A merchant sells Item for Price and buys one Wants for Offer.
+	Price int    `json:"price,omitempty"`
+	Wants string `json:"wants,omitempty"`
+	Offer int    `json:"offer,omitempty"`
+}
+
+// Options lists what the player may answer. A treasure offers both only if
+// the item fits; a merchant offers buy only if the player can pay and
+// carry, and sell only if they have what it wants.
+func (c PendingChoice) Options(p *Player) []string {
+	if c.Kind == "merchant" {
+		var opts []string
+		if p.Gold >= c.Price && CarryRoom(p, c.Item, 1) == 1 {
+			opts = append(opts, TradeBuy)
+		}
+		if c.Wants != "" && HasItem(p, c.Wants, 1) {
+			opts = append(opts, TradeSell)
+		}
+		return append(opts, TradeLeave)
+	}
+	if CarryRoom(p, c.Item, 1) == 1 {
+		return []string{TakeGold, TakeItem, TakeBoth}
+	}
+	return []string{TakeGold, TakeItem}
+}
+
+// blocks returns the error for a command the choice doesn't allow, or nil
+// for the one command that answers it.
+func (c PendingChoice) blocks(cmd string) error {
+	if c.Kind == "merchant" {
+		if cmd == "trade" {
+			return nil
+		}
+		return errMerchantPending
+	}
+	if cmd == "take" {
+		return nil
+	}
+	return errChoicePending
+}
+
+func (c PendingChoice) allows(p *Player, option string) bool {
+	for _, o := range c.Options(p) {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+func joinOptions(opts []string) string {
+	return strings.Join(opts, " | ")
+}
+
+// errChoicePending blocks every command but `take` while a choice waits.
+var errChoicePending = errors.New("a choice is pending: take gold | item | both")
+
+// offerTreasure leaves a treasure pending and emits ChoiceOffered.
+func offerTreasure(state *State, gold int, item string) Events {
+	state.Pending = &PendingChoice{Kind: "treasure", Gold: gold, Item: item}
+	return emit(nil, ChoiceOffered{
+		Gold:    gold,
+		Item:    item,
+		Options: state.Pending.Options(&state.Player),
+	})
+}
+
+// Take answers the pending choice.
+func Take(state *State, option string, rng RNG) (Events, error) {
+	events := Events{}
+	c := state.Pending
+	if c == nil || c.Kind != "treasure" {
+		return events, errors.New("nothing to take")
+	}
+	if !c.allows(&state.Player, option) {
+		return events, fmt.Errorf("can't take %q here", option)
+	}
+
+	state.Pending = nil
+	if option == TakeGold || option == TakeBoth {
+		state.Player.Gold += c.Gold
+		events = emit(events, GoldGained{Amount: c.Gold})
+	}
+	if option == TakeItem || option == TakeBoth {
+		events = append(events, GrantLoot(state, []string{c.Item}, rng)...)
+	}
+	return events, nil
+}
@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExplore_LargeTreasureWaitsForChoice(t *testing.T) {
+	old := TreasureChoiceGold
+	TreasureChoiceGold = 100
+	defer func() { TreasureChoiceGold = old }()
+
+	state := DefaultState()
+	state.Player.Gold = 0
+
+	events, err := RunCommand(&state, "explore", &seqRNG{ints: []int{0, 0, 1}})
+	if err != nil {
+		t.Fatalf("explore: %v", err)
+	}
+	if state.Pending == nil || state.Pending.Gold != 100 || state.Pending.Item != "rusty_dagger" {
+		t.Fatalf("expected pending 100 gold + rusty_dagger, got %+v", state.Pending)
+	}
+	if state.Player.Gold != 0 || HasItem(&state.Player, "rusty_dagger", 2) {
+		t.Fatalf("nothing should be taken before the choice")
+	}
+	found := false
+	for _, ev := range events {
+		if offer, ok := ev.(ChoiceOffered); ok {
+			found = len(offer.Options) == 3
+		}
+	}
+	if !found {
+		t.Fatalf("expected ChoiceOffered with three options, got %#v", events)
+	}
+
+	if _, err := RunCommand(&state, "hunt", &seqRNG{}); !errors.Is(err, errChoicePending) {
+		t.Fatalf("expected hunt blocked by pending choice, got %v", err)
+	}
+	if _, err := RunCommand(&state, "take coins", &seqRNG{}); err == nil {
+		t.Fatalf("expected invalid option rejected")
+	}
+
+	if _, err := RunCommand(&state, "take gold", &seqRNG{}); err != nil {
+		t.Fatalf("take gold: %v", err)
+	}
+	if state.Pending != nil || state.Player.Gold != 100 || HasItem(&state.Player, "rusty_dagger", 2) {
+		t.Fatalf("expected only gold taken, got gold=%d pending=%+v", state.Player.Gold, state.Pending)
+	}
+	if _, err := RunCommand(&state, "take item", &seqRNG{}); err == nil {
+		t.Fatalf("expected error with nothing pending")
+	}
+}
+
+func TestTake_BothOnlyWhenItemFits(t *testing.T) {
+	state := DefaultState()
+	state.Player.MaxCarryWeight = CarryWeight(&state.Player)
+	state.Pending = &PendingChoice{Kind: "treasure", Gold: 200, Item: "rusty_dagger"}
+
+	if _, err := Take(&state, TakeBoth, &seqRNG{}); err == nil {
+		t.Fatalf("expected both refused when the item doesn't fit")
+	}
+	if _, err := Take(&state, TakeItem, &seqRNG{}); err != nil {
+		t.Fatalf("take item: %v", err)
+	}
+	if state.Pending != nil || state.Player.Gold == 200 {
+		t.Fatalf("expected the gold left behind")
+	}
+}
@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"sort"
+	"strings"
+)
+
+// ================================
+// Classes
+// ================================
+
+// Class is a character archetype. Player.Class holds its display name.
+type Class struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	// RestHPPerSP is HP gained per SP spent resting.
+	RestHPPerSP int `json:"rest_hp_per_sp"`
+}
+
+// Classes is the global class registry.
+var Classes = map[string]Class{
+	"adventurer": {
+		ID:          "adventurer",
+		Name:        "Adventurer",
+		Description: "A jack of all trades.",
+		RestHPPerSP: RestHPPerSP,
+	},
+	"warrior": {
+		ID:          "warrior",
+		Name:        "Warrior",
+		Description: "Hardy; recovers quickly at rest.",
+		RestHPPerSP: 35,
+	},
+	"mage": {
+		ID:          "mage",
+		Name:        "Mage",
+		Description: "Frail; rest restores little.",
+		RestHPPerSP: 15,
+	},
+}
+
+// ClassIDs returns every class ID in sorted order.
+func ClassIDs() []string {
+	ids := make([]string, 0, len(Classes))
+	for id := range Classes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// LookupClass finds a class by ID or display name, case-insensitively.
+func LookupClass(name string) (Class, bool) {
+	c, ok := Classes[strings.ToLower(strings.TrimSpace(name))]
+	return c, ok
+}
+
+// RestRate returns the HP gained per SP rested for the player's class,
+// falling back to RestHPPerSP for unknown classes.
+func RestRate(p *Player) int {
+	if c, ok := LookupClass(p.Class); ok && c.RestHPPerSP > 0 {
+		return c.RestHPPerSP
+	}
+	return RestHPPerSP
+}
@@ -0,0 +1,25 @@
+package engine
+
+import "testing"
+
+func TestRest_RateVariesByClass(t *testing.T) {
+	gain := func(class string) int {
+		state := DefaultState()
+		state.Player.Class = class
+		state.Player.HP = 10
+		if _, err := Rest(&state, 2); err != nil {
+			t.Fatalf("Rest returned error for %s: %v", class, err)
+		}
+		return state.Player.HP - 10
+	}
+
+	if got := gain("Warrior"); got != 2*Classes["warrior"].RestHPPerSP {
+		t.Fatalf("warrior: expected %d HP, got %d", 2*Classes["warrior"].RestHPPerSP, got)
+	}
+	if got := gain("Mage"); got != 2*Classes["mage"].RestHPPerSP {
+		t.Fatalf("mage: expected %d HP, got %d", 2*Classes["mage"].RestHPPerSP, got)
+	}
+	if got := gain("Bard"); got != 2*RestHPPerSP {
+		t.Fatalf("unknown class: expected fallback %d HP, got %d", 2*RestHPPerSP, got)
+	}
+}
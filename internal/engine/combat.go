@@ -10,129 +10,281 @@ type RNG interface {
 	Float64() float64 // returns [0.0, 1.0)
 }
 
+// ================================
+// Luck
+// ================================
+
+// effectiveChance raises a base chance by the player's luck, clamped to
+// [0, 1]. Zero luck leaves the chance untouched.
+func effectiveChance(base float64, luck int) float64 {
+	c := base + float64(luck)*LuckChancePerPoint
+	if c < 0 {
+		return 0
+	}
+	if c > 1 {
+		return 1
+	}
+	return c
+}
+
+// LootPity smooths out dry streaks: every roll an item misses raises its
+// next drop chance by PityStep, until it drops and the count resets. Off
+// by default, so drop chances are exactly the catalog's.
+var LootPity = false
+
+// PityStep is how much each consecutive miss adds to a drop chance.
+var PityStep = 0.05
+
+// pityAdjustedChance raises a base chance by PityStep per failure, capped
+// at a certain drop.
+func pityAdjustedChance(base float64, failures int) float64 {
+	return min(1, base+float64(max(failures, 0))*PityStep)
+}
+
+// recordDropRoll counts a miss toward itemID's pity, or resets it on a hit.
+func recordDropRoll(state *State, itemID string, hit bool) {
+	if hit {
+		delete(state.Meta.DropMisses, itemID)
+		return
+	}
+	if state.Meta.DropMisses == nil {
+		state.Meta.DropMisses = map[string]int{}
+	}
+	state.Meta.DropMisses[itemID]++
+}
+
+// rollGold draws an enemy's gold reward from Gold–GoldMax. Enemies without
+// a GoldMax pay exactly Gold and draw nothing from rng.
+func rollGold(enemy EnemyTemplate, rng RNG) int {
+	if enemy.GoldMax <= enemy.Gold {
+		return enemy.Gold
+	}
+	return enemy.Gold + rng.Intn(enemy.GoldMax-enemy.Gold+1)
+}
+
 // ================================
 // Combat Resolution
 // ================================
 
+// MaxCombatTurns bounds a fight. A fight still undecided after this many
+// rounds ends in a stalemate, so data where neither side can win never
+// loops forever.
+var MaxCombatTurns = 1000
+
+// Initiative lets an enemy faster than the player strike first. Off, the
+// player always opens the fight.
+var Initiative = false
+
 // CombatResult summarizes terminal combat outcomes.
 type CombatResult struct {
-	Outcome string // "win" or "lose"
+	Outcome string // "win", "lose" or "stalemate"
 	XP      int
 	Gold    int
 	Loot    []string
 }
 
 // ResolveCombat runs a full combat loop between player and enemy template.
-// - Player attacks first
-// - Player damage scales with level
-// - Enemy damage uses template ranges
+// - Player attacks first, unless Initiative is on and an enemy is faster
+// - Player damage scales with level, plus attack from buffs and gear
+// - Enemy damage uses template ranges, minus defense from buffs and gear
+// - Gold reward is rolled from the template's Gold–GoldMax
+// - Ends in a stalemate, with no rewards, after MaxCombatTurns rounds
 // - Emits detailed combat events
+// Every active buff uses up one encounter, and every equipped item one
+// durability point, once the fight ends.
 func ResolveCombat(
 	state *State,
 	enemy EnemyTemplate,
 	rng RNG,
+) (CombatResult, Events) {
+	return ResolveGroupCombat(state, []EnemyTemplate{enemy}, rng)
+}
+
+// ResolveGroupCombat runs the player against several enemies at once. Each
+// turn the player strikes the first enemy still standing, then every
+// standing enemy attacks in order. XP, gold and loot are summed over the
+// whole group and only paid on a win. A one-enemy group fights exactly like
+// ResolveCombat, drawing the same numbers from rng.
+func ResolveGroupCombat(
+	state *State,
+	enemies []EnemyTemplate,
+	rng RNG,
+) (CombatResult, Events) {
+	result, events := resolveCombat(state, enemies, rng)
+	events = append(events, tickBuffs(&state.Player)...)
+	return result, append(events, wearGear(&state.Player)...)
+}
+
+func resolveCombat(
+	state *State,
+	enemies []EnemyTemplate,
+	rng RNG,
 ) (CombatResult, Events) {
 	events := Events{}
 	player := &state.Player
 
 	playerHP := player.HP
-	enemyHP := enemy.HP
-	level := player.Level
+
+	if len(enemies) == 0 {
+		return CombatResult{Outcome: "win"}, events
+	}
 
 	// Encounter start
-	events = append(events, EncounterStarted{EnemyID: enemy.ID})
+	enemyHP := make([]int, len(enemies))
+	for i, enemy := range enemies {
+		enemyHP[i] = enemy.HP
+		events = emit(events, EncounterStarted{EnemyID: enemy.ID, Name: encounterName(enemy)})
+	}
+	if playerHP <= 0 {
+		return CombatResult{Outcome: "lose"}, events
+	}
 
-	for playerHP > 0 && enemyHP > 0 {
+	won := CombatResult{Outcome: "win"}
+	stolenGold, stolenItems := 0, []string(nil)
+	target := 0 // first enemy still standing
+	enemyFirst := Initiative && outpaced(player, enemies)
+
+	for turn := 0; ; turn++ {
+		if turn >= MaxCombatTurns {
+			player.HP = playerHP
+			events = emit(events, CombatStalemate{EnemyID: enemies[target].ID, Turns: turn})
+			return CombatResult{
+				Outcome: "stalemate",
+			}, events
+		}
 
 		// ----------------
 		// Player attack
 		// ----------------
-		pMin := 1 + level
-		pMax := 2 + level
-		// safety: ensure range is non-negative to avoid panic in RNG.Intn
-		pRange := pMax - pMin + 1
-		if pRange <= 0 {
-			pRange = 1
-		}
-		pDmg := pMin + rng.Intn(pRange)
-
-		enemyHP -= pDmg
-		if enemyHP < 0 {
-			enemyHP = 0
-		}
-
-		events = append(events, DamageDealt{
-			Source: "player",
-			Target: enemy.ID,
-			Amount: pDmg,
-			HPLeft: enemyHP,
-		})
-
-		if enemyHP <= 0 {
-			// Victory: persist player's remaining HP into the state
-			result := CombatResult{
-				Outcome: "win",
-				XP:      enemy.XP,
-				Gold:    enemy.Gold,
-			}
+		if turn > 0 || !enemyFirst {
+			enemy := enemies[target]
+			pDmg := playerDamage(player, rng)
 
-			// Roll loot
-			for _, drop := range enemy.Loot {
-				if rng.Float64() < drop.Chance {
-					result.Loot = append(result.Loot, drop.ItemID)
-				}
+			overkill := max(0, pDmg-enemyHP[target])
+			enemyHP[target] -= pDmg
+			if enemyHP[target] < 0 {
+				enemyHP[target] = 0
 			}
 
-			events = append(events, EnemyDefeated{
-				EnemyID: enemy.ID,
-				XP:      enemy.XP,
-				Gold:    enemy.Gold,
+			events = emit(events, DamageDealt{
+				Source:   "player",
+				Target:   enemy.ID,
+				Amount:   pDmg,
+				HPLeft:   enemyHP[target],
+				Overkill: overkill,
 			})
 
-			player.HP = playerHP
-			return result, events
+			if enemyHP[target] <= 0 {
+				events = emit(events, defeatEnemy(state, enemy, &won, rng))
+
+				target++
+				if target == len(enemies) {
+					// Victory: persist player's remaining HP into the state
+					player.HP = playerHP
+					return won, append(events, recoverStolen(player, stolenGold, stolenItems)...)
+				}
+			}
 		}
 
 		// ----------------
-		// Enemy attack
+		// Enemy attacks
 		// ----------------
-		eMin := enemy.AttackMin
-		eMax := enemy.AttackMax
-		// guard against malformed templates where max < min
-		if eMax < eMin {
-			eMax = eMin
-		}
-		eRange := eMax - eMin + 1
-		if eRange <= 0 {
-			eRange = 1
+		for _, enemy := range enemies[target:] {
+			eDmg := enemyDamage(player, enemy, rng)
+
+			playerHP -= eDmg
+			if playerHP < 0 {
+				playerHP = 0
+			}
+
+			events = emit(events, DamageDealt{
+				Source: enemy.ID,
+				Target: "player",
+				Amount: eDmg,
+				HPLeft: playerHP,
+			})
+			if theft, ok := stealFrom(player, enemy, rng); ok {
+				stolenGold += theft.Gold
+				if theft.ItemID != "" {
+					stolenItems = append(stolenItems, theft.ItemID)
+				}
+				events = emit(events, theft)
+			}
+
+			if playerHP <= 0 {
+				// Defeat
+				player.HP = 0
+				events = emit(events, PlayerDefeated{})
+				return CombatResult{
+					Outcome: "lose",
+				}, events
+			}
 		}
-		eDmg := eMin + rng.Intn(eRange)
+	}
+}
 
-		playerHP -= eDmg
-		if playerHP < 0 {
-			playerHP = 0
+// outpaced reports whether any enemy is faster than the player. Ties go to
+// the player.
+func outpaced(player *Player, enemies []EnemyTemplate) bool {
+	for _, enemy := range enemies {
+		if enemy.Speed > player.Speed {
+			return true
 		}
+	}
+	return false
+}
 
-		events = append(events, DamageDealt{
-			Source: enemy.ID,
-			Target: "player",
-			Amount: eDmg,
-			HPLeft: playerHP,
-		})
-
-		if playerHP <= 0 {
-			// Defeat
-			player.HP = 0
-			events = append(events, PlayerDefeated{})
-			return CombatResult{
-				Outcome: "lose",
-			}, events
+// playerDamage rolls one player strike from AttackRange.
+func playerDamage(player *Player, rng RNG) int {
+	pMin, pMax := AttackRange(player)
+	// safety: ensure range is non-negative to avoid panic in RNG.Intn
+	pRange := pMax - pMin + 1
+	if pRange <= 0 {
+		pRange = 1
+	}
+	return pMin + rng.Intn(pRange)
+}
+
+// enemyDamage rolls one enemy strike, less the player's defense.
+func enemyDamage(player *Player, enemy EnemyTemplate, rng RNG) int {
+	eMin := enemy.AttackMin
+	eMax := enemy.AttackMax
+	// guard against malformed templates where max < min
+	if eMax < eMin {
+		eMax = eMin
+	}
+	eRange := eMax - eMin + 1
+	if eRange <= 0 {
+		eRange = 1
+	}
+	return max(0, eMin+rng.Intn(eRange)-Defense(player))
+}
+
+// defeatEnemy rolls a slain enemy's gold and loot into won.
+func defeatEnemy(state *State, enemy EnemyTemplate, won *CombatResult, rng RNG) EnemyDefeated {
+	gold := rollGold(enemy, rng)
+	won.XP += enemy.XP
+	won.Gold += gold
+
+	// Roll loot
+	lootScale := CurrentWorld(state).LootScale
+	for _, drop := range enemy.Loot {
+		chance := effectiveChance(drop.Chance*lootScale, state.Player.Luck)
+		if LootPity {
+			chance = pityAdjustedChance(chance, state.Meta.DropMisses[drop.ItemID])
+		}
+		hit := rng.Float64() < chance
+		if hit {
+			won.Loot = append(won.Loot, drop.ItemID)
+		}
+		if LootPity {
+			recordDropRoll(state, drop.ItemID, hit)
 		}
 	}
 
-	// Should not reach here, but be safe
-	player.HP = playerHP
-	return CombatResult{
-		Outcome: "lose",
-	}, events
+	return EnemyDefeated{
+		EnemyID: enemy.ID,
+		XP:      enemy.XP,
+		Gold:    gold,
+	}
 }
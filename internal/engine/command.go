@@ -0,0 +1,432 @@
+package engine
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ================================
+// Command Dispatch
+// ================================
+
+// ErrUnknownCommand is returned when a line names no engine command.
+// UIs use it to fall back to their own commands (help, save, ...).
+var ErrUnknownCommand = errors.New("unknown command")
+
+// RunCommand parses a command line and applies the matching action to state
+// in place. It is the shared entry point UIs route gameplay commands through,
+// so cross-cutting rules (bestiary, SP regen, world rotation, achievements)
+// are applied here once.
+func RunCommand(state *State, line string, rng RNG) (Events, error) {
+	parts := SplitArgs(line)
+	if len(parts) == 0 {
+		return nil, ErrUnknownCommand
+	}
+
+	cmd, args := parts[0], parts[1:]
+	args, times, err := splitRepeat(cmd, args)
+	if err != nil {
+		notifyCommand(line, state, err)
+		return nil, err
+	}
+
+	events, err := runAction(state, cmd, args, rng)
+	if errors.Is(err, ErrUnknownCommand) {
+		return events, err
+	}
+	if err == nil && !isQuery(cmd, args) {
+		events = append(events, afterCommand(state, cmd, events, rng)...)
+		events = append(events, repeatAction(state, cmd, args, times, rng)...)
+	}
+
+	notifyCommand(line, state, err)
+	return events, err
+}
+
+// ================================
+// Repeats
+// ================================
+
+// MaxRepeat caps the repeat count of `explore [times]` and
+// `hunt [enemy] [extra_sp] [times]`; larger counts are clamped to it.
+var MaxRepeat = 20
+
+// splitRepeat takes the trailing repeat count off a repeatable command's
+// arguments: explore's first argument, or hunt's number after extra_sp.
+// Other commands, and repeatable ones without a count, run once.
+func splitRepeat(cmd string, args []string) ([]string, int, error) {
+	at := -1
+	switch cmd {
+	case "explore":
+		at = 0
+	case "hunt":
+		at = 1
+		if len(args) > 0 {
+			if _, err := strconv.Atoi(args[0]); err != nil {
+				at = 2 // after the enemy ID
+			}
+		}
+	}
+	if at < 0 || len(args) <= at {
+		return args, 1, nil
+	}
+	times, err := strconv.Atoi(args[at])
+	if err != nil || times < 1 {
+		return nil, 0, errors.New(cmd + " expects a repeat count of at least 1")
+	}
+	return args[:at], min(times, MaxRepeat), nil
+}
+
+// repeatAction runs cmd's remaining times-1 repeats after the first has
+// succeeded, each as a full command of its own. It stops early, with a
+// RepeatStopped, when the player is defeated or a repeat fails, such as
+// running out of SP or meeting a merchant who wants an answer.
+func repeatAction(state *State, cmd string, args []string, times int, rng RNG) Events {
+	var events Events
+	for done := 1; done < times; done++ {
+		if !state.Player.IsAlive() {
+			return emit(events, RepeatStopped{Command: cmd, Done: done, Times: times, Reason: "you were defeated"})
+		}
+		ev, err := runAction(state, cmd, args, rng)
+		events = append(events, ev...)
+		if err != nil {
+			return emit(events, RepeatStopped{Command: cmd, Done: done, Times: times, Reason: err.Error()})
+		}
+		events = append(events, afterCommand(state, cmd, ev, rng)...)
+	}
+	return events
+}
+
+// SanitizeInput cleans a typed or pasted line before parsing: control and
+// zero-width characters are dropped, and every run of whitespace, tabs
+// included, becomes one space with none at either end.
+func SanitizeInput(line string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range line {
+		switch {
+		case unicode.IsSpace(r):
+			space = b.Len() > 0
+		case !unicode.IsPrint(r):
+			// Control, format (zero-width) and unassigned runes.
+		default:
+			if space {
+				b.WriteByte(' ')
+				space = false
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// SplitArgs splits a command line into words like strings.Fields, except
+// that double quotes group words into one argument: `rename "Sir Reginald"`
+// gives ["rename", "Sir Reginald"]. A backslash escapes a quote or another
+// backslash, and an unterminated quote runs to the end of the line.
+func SplitArgs(line string) []string {
+	line = SanitizeInput(line)
+	var (
+		args    []string
+		cur     strings.Builder
+		inWord  bool
+		quoted  bool
+		escaped bool
+	)
+	for _, r := range line {
+		switch {
+		case escaped:
+			if r != '"' && r != '\\' {
+				cur.WriteRune('\\')
+			}
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped, inWord = true, true
+		case r == '"':
+			quoted, inWord = !quoted, true
+		case unicode.IsSpace(r) && !quoted:
+			if inWord {
+				args = append(args, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+	if escaped {
+		cur.WriteRune('\\')
+	}
+	if inWord {
+		args = append(args, cur.String())
+	}
+	return args
+}
+
+// isQuery reports whether a command only reports and changes nothing, like
+// `sell price`. Queries skip afterCommand, so asking doesn't pass time.
+func isQuery(cmd string, args []string) bool {
+	return cmd == "sell" && len(args) > 0 && args[0] == "price"
+}
+
+// afterCommand applies the rules that react to any successful command.
+func afterCommand(state *State, cmd string, events Events, rng RNG) Events {
+	state.Meta.CommandTicks++
+	RecordBestiary(state, events)
+	RecordLoot(state, events)
+	RecordOverkill(state, events)
+
+	out := FlagUpgrades(&state.Player, events)
+	if !spentOrFought(events) {
+		out = append(out, RegenSP(state)...)
+	}
+	out = append(out, RotateWorld(state, rng)...)
+	out = append(out, AdvanceTime(state)...)
+	out = append(out, AccrueInterest(state)...)
+	out = append(out, CheckAchievements(state)...)
+	return append(out, GiveHints(state, cmd)...)
+}
+
+// spentOrFought reports whether a command spent SP or started a fight; such
+// commands don't count toward passive regen.
+func spentOrFought(events Events) bool {
+	for _, ev := range events {
+		switch ev.(type) {
+		case SPSpent, EncounterStarted, TurnStarted:
+			return true
+		}
+	}
+	return false
+}
+
+// actionCommands are the command words runAction handles; UIs handle the
+// rest.
+var actionCommands = map[string]bool{
+	"take": true, "explore": true, "hunt": true, "rest": true, "use": true,
+	"equip": true, "unequip": true, "camp": true, "wait": true,
+	"dungeon": true, "revive": true, "prestige": true, "sell": true,
+	"craft": true, "attack": true, "repair": true, "train": true,
+	"trade": true, "deposit": true, "withdraw": true,
+}
+
+// commandAliases maps the engine's alternate command words to the command
+// they run.
+var commandAliases = map[string]string{"wait": "camp"}
+
+// canonicalCommand resolves an alias to its command; other words are
+// returned unchanged.
+func canonicalCommand(cmd string) string {
+	if name, ok := commandAliases[cmd]; ok {
+		return name
+	}
+	return cmd
+}
+
+func runAction(state *State, cmd string, args []string, rng RNG) (Events, error) {
+	if state.Pending != nil && actionCommands[cmd] {
+		if err := state.Pending.blocks(cmd); err != nil {
+			return nil, err
+		}
+	}
+	if state.Battle != nil && cmd != "attack" && cmd != "use" && actionCommands[cmd] {
+		return nil, errInBattle
+	}
+	if state.Dungeon != nil && dungeonBlocked[cmd] {
+		return nil, errInDungeon
+	}
+
+	switch cmd {
+	case "take":
+		if len(args) == 0 {
+			return nil, errors.New("usage: take gold | item | both")
+		}
+		return Take(state, args[0], rng)
+
+	case "deposit":
+		n, err := bankAmount(args, state.Player.Gold)
+		if err != nil {
+			return nil, err
+		}
+		return Deposit(state, n)
+
+	case "withdraw":
+		n, err := bankAmount(args, state.Player.BankedGold)
+		if err != nil {
+			return nil, err
+		}
+		return Withdraw(state, n)
+
+	case "trade":
+		if len(args) == 0 {
+			return nil, errors.New("usage: trade buy | sell | leave")
+		}
+		return Trade(state, args[0], rng)
+
+	case "attack":
+		if len(args) == 0 {
+			return nil, errors.New("usage: attack <target>")
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return nil, errors.New("attack expects a target number")
+		}
+		return Attack(state, n, rng)
+
+	case "explore":
+		return Explore(state, rng)
+
+	case "hunt":
+		// A first token that isn't a number names the enemy to hunt.
+		target := ""
+		if len(args) > 0 {
+			if _, err := strconv.Atoi(args[0]); err != nil {
+				target, args = args[0], args[1:]
+			}
+		}
+		extra := 0
+		if len(args) > 0 {
+			v, err := strconv.Atoi(args[0])
+			if err != nil {
+				return nil, errors.New("hunt expects an integer extra_sp")
+			}
+			extra = v
+		}
+		if target != "" {
+			return HuntTarget(state, target, extra, rng)
+		}
+		return Hunt(state, extra, rng)
+
+	case "rest":
+		sp := 1
+		if len(args) > 0 {
+			v, err := strconv.Atoi(args[0])
+			if err != nil {
+				return nil, errors.New("rest expects an integer sp amount")
+			}
+			sp = v
+		}
+		return Rest(state, sp)
+
+	case "use":
+		if len(args) == 0 {
+			return nil, errors.New("usage: use <item_id>")
+		}
+		return UseItem(state, args[0], rng)
+
+	case "equip":
+		if len(args) == 0 {
+			return nil, errors.New("usage: equip <item_id>")
+		}
+		return Equip(state, args[0])
+
+	case "unequip":
+		if len(args) == 0 {
+			return nil, errors.New("usage: unequip <slot|item_id>")
+		}
+		return Unequip(state, args[0])
+
+	case "train":
+		if len(args) == 0 {
+			return nil, errors.New("usage: train hp | sp | attack")
+		}
+		return Train(state, strings.ToLower(args[0]))
+
+	case "repair":
+		if len(args) == 0 {
+			return nil, errors.New("usage: repair <item_id|slot>")
+		}
+		return Repair(state, args[0])
+
+	case "camp", "wait":
+		return Camp(state, rng)
+
+	case "dungeon":
+		if len(args) == 0 {
+			return nil, errors.New("usage: dungeon enter <id> | next | leave")
+		}
+		switch args[0] {
+		case "enter":
+			if len(args) < 2 {
+				return nil, errors.New("usage: dungeon enter <id>")
+			}
+			return EnterDungeon(state, args[1])
+		case "next":
+			return DungeonNext(state, rng)
+		case "leave":
+			return LeaveDungeon(state)
+		default:
+			return nil, errors.New("usage: dungeon enter <id> | next | leave")
+		}
+
+	case "revive":
+		return Revive(state)
+
+	case "prestige":
+		return Prestige(state)
+
+	case "sell":
+		if len(args) == 0 {
+			return nil, errSellUsage
+		}
+		switch args[0] {
+		case "junk":
+			return SellJunk(state)
+		case "all":
+			return SellAll(state)
+		case "price", "haggle":
+			if len(args) < 2 {
+				return nil, errSellUsage
+			}
+			qty, err := sellQty(args[2:])
+			if err != nil {
+				return nil, err
+			}
+			if args[0] == "price" {
+				return Quote(state, args[1], qty)
+			}
+			return Haggle(state, args[1], qty, rng)
+		default:
+			qty, err := sellQty(args[1:])
+			if err != nil {
+				return nil, err
+			}
+			return Sell(state, args[0], qty)
+		}
+
+	case "craft":
+		if len(args) == 0 {
+			return nil, errors.New("usage: craft <recipe>")
+		}
+		return Craft(state, args[0], rng)
+
+	default:
+		return nil, ErrUnknownCommand
+	}
+}
+
+// ApplyCommand is the immutable counterpart of RunCommand: it applies the
+// command to a deep copy of state and returns the copy, leaving the caller's
+// state untouched. UIs keep prior values around to support undo.
+func ApplyCommand(state State, line string, rng RNG) (State, Events, error) {
+	next := cloneState(state)
+	events, err := RunCommand(&next, line, rng)
+	return next, events, err
+}
+
+var errSellUsage = errors.New("usage: sell junk | all | <item> [qty] | price <item> [qty] | haggle <item> [qty]")
+
+// sellQty parses sell's optional quantity, 1 when absent.
+func sellQty(args []string) (int, error) {
+	if len(args) == 0 {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n <= 0 {
+		return 0, errors.New("sell expects a positive quantity")
+	}
+	return n, nil
+}
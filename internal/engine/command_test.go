@@ -0,0 +1,281 @@
+package engine
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestApplyCommand_LeavesOriginalUntouched(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 10
+	state.Player.SP = 10
+
+	rng := &seqRNG{ints: []int{0, 0}, floats: []float64{0.05, 0.05}}
+	next, events, err := ApplyCommand(state, "hunt 2", rng)
+	if err != nil {
+		t.Fatalf("ApplyCommand returned error: %v", err)
+	}
+	if len(events) == 0 {
+		t.Fatalf("expected hunt events")
+	}
+
+	if state.Player.SP != 10 || state.Player.XP != 0 || state.Player.Gold != 50 {
+		t.Fatalf("original state mutated: sp=%d xp=%d gold=%d", state.Player.SP, state.Player.XP, state.Player.Gold)
+	}
+	if HasItem(&state.Player, "healing_potion", 1) {
+		t.Fatalf("original inventory mutated by loot")
+	}
+	if state.Meta.CommandCount != 0 {
+		t.Fatalf("original command count mutated: %d", state.Meta.CommandCount)
+	}
+
+	if next.Player.SP != 7 {
+		t.Fatalf("expected returned state SP 7, got %d", next.Player.SP)
+	}
+	if !HasItem(&next.Player, "healing_potion", 1) {
+		t.Fatalf("expected loot in returned state")
+	}
+}
+
+func TestRunCommand_UnknownCommand(t *testing.T) {
+	state := DefaultState()
+	_, err := RunCommand(&state, "dance", &seqRNG{})
+	if !errors.Is(err, ErrUnknownCommand) {
+		t.Fatalf("expected ErrUnknownCommand, got %v", err)
+	}
+}
+
+func TestRunCommand_RejectsNonIntegerArgs(t *testing.T) {
+	state := DefaultState()
+	if _, err := RunCommand(&state, "hunt lots", &seqRNG{}); err == nil {
+		t.Fatalf("expected error for non-integer hunt stake")
+	}
+	if _, err := RunCommand(&state, "rest some", &seqRNG{}); err == nil {
+		t.Fatalf("expected error for non-integer rest amount")
+	}
+	if state.Player.SP != 10 {
+		t.Fatalf("expected SP unchanged after rejected commands, got %d", state.Player.SP)
+	}
+}
+
+func TestRunCommand_RegeneratesSPOverQuietCommands(t *testing.T) {
+	state := DefaultState()
+	state.Player.SP = 5
+
+	// explore rolls of 100 find nothing, so every command is non-combat.
+	var regained int
+	for i := 0; i < 2*SPRegenInterval; i++ {
+		events, err := RunCommand(&state, "explore", &seqRNG{ints: []int{99}})
+		if err != nil {
+			t.Fatalf("explore returned error: %v", err)
+		}
+		for _, ev := range events {
+			if r, ok := ev.(SPRegained); ok {
+				regained += r.Amount
+			}
+		}
+	}
+
+	if state.Player.SP != 7 || regained != 2 {
+		t.Fatalf("expected 2 SP regained (SP 7), got SP %d regained %d", state.Player.SP, regained)
+	}
+}
+
+func TestRunCommand_SPSpendDoesNotAdvanceRegen(t *testing.T) {
+	state := DefaultState()
+	state.Player.SP = 5
+	state.Meta.SPRegenCounter = SPRegenInterval - 1
+
+	events, err := RunCommand(&state, "rest 1", &seqRNG{})
+	if err != nil {
+		t.Fatalf("rest returned error: %v", err)
+	}
+	for _, ev := range events {
+		if _, ok := ev.(SPRegained); ok {
+			t.Fatalf("regen fired on the same command as an SP spend")
+		}
+	}
+	if state.Player.SP != 4 || state.Meta.SPRegenCounter != SPRegenInterval-1 {
+		t.Fatalf("expected SP 4 and counter unchanged, got SP %d counter %d", state.Player.SP, state.Meta.SPRegenCounter)
+	}
+}
+
+func TestRegenSP_StopsAtCap(t *testing.T) {
+	state := DefaultState()
+	state.Player.SP = state.Player.MaxSP
+	state.Meta.SPRegenCounter = SPRegenInterval - 1
+
+	if events := RegenSP(&state); len(events) != 0 || state.Player.SP != state.Player.MaxSP {
+		t.Fatalf("expected no regen at cap, got %v (SP %d)", events, state.Player.SP)
+	}
+}
+
+func TestSubscribe_ObserverSeesReturnedEvents(t *testing.T) {
+	var seen Events
+	unsubscribe := Subscribe(func(e Event) { seen = append(seen, e) })
+
+	state := DefaultState()
+	state.Player.Level = 10
+	state.Player.XP = 95
+	events, err := RunCommand(&state, "hunt 0", &seqRNG{ints: []int{0, 0}, floats: []float64{0.05, 0.05}})
+	unsubscribe()
+	if err != nil {
+		t.Fatalf("hunt returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(seen, events) {
+		t.Fatalf("observer saw %v\nreturned %v", seen, events)
+	}
+
+	before := len(seen)
+	if _, err := RunCommand(&state, "rest 1", &seqRNG{}); err != nil {
+		t.Fatalf("rest returned error: %v", err)
+	}
+	if len(seen) != before {
+		t.Fatalf("expected no notifications after unsubscribe")
+	}
+}
+
+func TestSubscribe_SafeAcrossGoroutines(t *testing.T) {
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unsubscribe := Subscribe(func(Event) {})
+			state := DefaultState()
+			if _, err := RunCommand(&state, "rest 1", &seqRNG{}); err != nil {
+				t.Errorf("rest returned error: %v", err)
+			}
+			unsubscribe()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"hunt  orc 2", []string{"hunt", "orc", "2"}},
+		{"  ", nil},
+		{`rename "Sir Reginald"`, []string{"rename", "Sir Reginald"}},
+		{`use "healing potion" now`, []string{"use", "healing potion", "now"}},
+		{`say "the \"bold\" one"`, []string{"say", `the "bold" one`}},
+		{`say \"hi\"`, []string{"say", `"hi"`}},
+		{`path C:\saves`, []string{"path", `C:\saves`}},
+		{`rename ""`, []string{"rename", ""}},
+		{`rename "Sir Reg`, []string{"rename", "Sir Reg"}},
+		{`rename Sir\`, []string{"rename", `Sir\`}},
+	}
+	for _, tc := range tests {
+		if got := SplitArgs(tc.line); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("SplitArgs(%q) = %q, want %q", tc.line, got, tc.want)
+		}
+	}
+}
+
+func TestRunCommand_QuotedItemArgument(t *testing.T) {
+	state := DefaultState()
+	state.Player.HP = 50
+	AddItem(&state.Player, "healing_potion", 1)
+
+	if _, err := RunCommand(&state, `use "healing potion"`, &seqRNG{}); err != nil {
+		t.Fatalf("quoted item name should resolve, got %v", err)
+	}
+	if HasItem(&state.Player, "healing_potion", 1) {
+		t.Fatal("expected the potion used")
+	}
+}
+
+func TestSanitizeInput_StripsInvisibleRunes(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"hunt\torc\t 2", []string{"hunt", "orc", "2"}},
+		{"\u200bexplore\u200b", []string{"explore"}},
+		{"\ufeffrest 1\r", []string{"rest", "1"}},
+		{"use heal\x07ing_potion", []string{"use", "healing_potion"}},
+		{"use \"healing  potion\"", []string{"use", "healing potion"}},
+		{"\x1b\u200d", nil},
+	}
+	for _, tc := range tests {
+		if got := SplitArgs(tc.line); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("SplitArgs(%q) = %q, want %q", tc.line, got, tc.want)
+		}
+	}
+
+	for _, raw := range []string{"healing\u200b_potion", "\tHealing Potion\x00", "healing-potion\u2060"} {
+		if got := NormalizeItemID(raw); got != "healing_potion" {
+			t.Errorf("NormalizeItemID(%q) = %q", raw, got)
+		}
+	}
+
+	state := DefaultState()
+	if _, err := RunCommand(&state, "\u200bexplore\t", &seqRNG{ints: []int{99}}); err != nil {
+		t.Fatalf("explore with stray runes: %v", err)
+	}
+}
+
+func TestRunCommand_ExploreRepeats(t *testing.T) {
+	state := DefaultState()
+
+	// Rolls of 99 land in the "nothing" band every time.
+	events, err := RunCommand(&state, "explore 3", &seqRNG{ints: []int{98, 98, 98}})
+	if err != nil {
+		t.Fatalf("explore 3: %v", err)
+	}
+	explores := 0
+	for _, e := range events {
+		switch e.(type) {
+		case ExplorationResult:
+			explores++
+		case RepeatStopped:
+			t.Fatalf("expected no early stop, got %+v", e)
+		}
+	}
+	if explores != 3 || state.Meta.CommandCount != 3 || state.Meta.CommandTicks != 3 {
+		t.Fatalf("expected three full explores, got %d results, count %d, ticks %d",
+			explores, state.Meta.CommandCount, state.Meta.CommandTicks)
+	}
+}
+
+func TestRunCommand_RepeatStopsOnDefeat(t *testing.T) {
+	state := DefaultState()
+	state.Player.HP = 1
+
+	// A roll of 40 meets an enemy, which finishes the player off.
+	events, err := RunCommand(&state, "explore 3", &seqRNG{ints: []int{39}})
+	if err != nil {
+		t.Fatalf("explore 3: %v", err)
+	}
+	if state.Player.HP != 0 || state.Meta.CommandCount != 1 {
+		t.Fatalf("expected one fatal explore, got HP %d after %d explores", state.Player.HP, state.Meta.CommandCount)
+	}
+	last, ok := events[len(events)-1].(RepeatStopped)
+	if !ok || last.Done != 1 || last.Times != 3 {
+		t.Fatalf("expected a RepeatStopped after 1 of 3, got %+v", events[len(events)-1])
+	}
+}
+
+func TestRunCommand_RepeatCountValidatedAndCapped(t *testing.T) {
+	state := DefaultState()
+	if _, err := RunCommand(&state, "explore 0", &seqRNG{}); err == nil {
+		t.Fatal("expected a zero repeat count to be rejected")
+	}
+	if state.Meta.CommandCount != 0 {
+		t.Fatalf("expected nothing run, got %d explores", state.Meta.CommandCount)
+	}
+
+	args, times, err := splitRepeat("hunt", []string{"wolf", "1", "500"})
+	if err != nil || times != MaxRepeat || len(args) != 2 {
+		t.Fatalf("expected hunt wolf 1 capped at %d, got %v x%d, %v", MaxRepeat, args, times, err)
+	}
+	if args, times, _ := splitRepeat("hunt", []string{"2"}); times != 1 || len(args) != 1 {
+		t.Fatalf("expected a lone stake to hunt once, got %v x%d", args, times)
+	}
+}
@@ -0,0 +1,57 @@
+package engine
+
+import "fmt"
+
+// ================================
+// Item Cooldowns
+// ================================
+
+// CooldownRemaining is how many more commands must pass before itemID can
+// be used again; 0 means it is ready.
+func CooldownRemaining(state *State, itemID string) int {
+	item, ok := ItemByID(itemID)
+	if !ok || item.CooldownCommands <= 0 {
+		return 0
+	}
+	last, ok := state.Meta.LastUsed[item.ID]
+	if !ok {
+		return 0
+	}
+	// The use itself is the first command counted.
+	return max(0, item.CooldownCommands-(state.Meta.CommandTicks-last)+1)
+}
+
+// Cooldowns maps every item still cooling down to its commands remaining.
+func Cooldowns(state *State) map[string]int {
+	out := map[string]int{}
+	for id := range state.Meta.LastUsed {
+		if n := CooldownRemaining(state, id); n > 0 {
+			out[id] = n
+		}
+	}
+	return out
+}
+
+// checkCooldown rejects using an item that is still cooling down.
+func checkCooldown(state *State, item Item) error {
+	n := CooldownRemaining(state, item.ID)
+	if n == 0 {
+		return nil
+	}
+	unit := "commands"
+	if n == 1 {
+		unit = "command"
+	}
+	return fmt.Errorf("%w: %s, %d %s remaining", ErrOnCooldown, item.Name, n, unit)
+}
+
+// startCooldown records a use of item so checkCooldown can time it.
+func startCooldown(state *State, item Item) {
+	if item.CooldownCommands <= 0 {
+		return
+	}
+	if state.Meta.LastUsed == nil {
+		state.Meta.LastUsed = map[string]int{}
+	}
+	state.Meta.LastUsed[item.ID] = state.Meta.CommandTicks
+}
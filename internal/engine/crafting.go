@@ -0,0 +1,156 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ================================
+// Recipes
+// ================================
+
+// Recipe turns a set of inventory items (and optionally gold) into a new item.
+type Recipe struct {
+	ID        string         `json:"id"`
+	Inputs    map[string]int `json:"inputs"` // item_id -> count consumed
+	Output    string         `json:"output"`
+	OutputQty int            `json:"output_qty"`
+	GoldCost  int            `json:"gold_cost,omitempty"`
+}
+
+// Recipes is the global recipe registry.
+var Recipes = map[string]Recipe{
+	"fur_cloak": {
+		ID:        "fur_cloak",
+		Inputs:    map[string]int{"wolf_pelt": 2},
+		Output:    "fur_cloak",
+		OutputQty: 1,
+	},
+	"bear_charm": {
+		ID:        "bear_charm",
+		Inputs:    map[string]int{"bear_claw": 2, "ancient_coin": 1},
+		Output:    "bear_charm",
+		OutputQty: 1,
+	},
+	"orcish_greatblade": {
+		ID:        "orcish_greatblade",
+		Inputs:    map[string]int{"orcish_blade": 1, "rusty_dagger": 1},
+		Output:    "orcish_greatblade",
+		OutputQty: 1,
+		GoldCost:  25,
+	},
+	"hearty_stew": {
+		ID:        "hearty_stew",
+		Inputs:    map[string]int{"meat": 2},
+		Output:    "hearty_stew",
+		OutputQty: 1,
+	},
+	"smoke_bomb": {
+		ID:        "smoke_bomb",
+		Inputs:    map[string]int{"torch": 2},
+		Output:    "smoke_bomb",
+		OutputQty: 1,
+	},
+	"remove_curse_scroll": {
+		ID:        "remove_curse_scroll",
+		Inputs:    map[string]int{"ancient_coin": 1, "torch": 1},
+		Output:    "remove_curse_scroll",
+		OutputQty: 1,
+		GoldCost:  10,
+	},
+	"berserker_brew": {
+		ID:        "berserker_brew",
+		Inputs:    map[string]int{"bear_claw": 1, "healing_potion": 1},
+		Output:    "berserker_brew",
+		OutputQty: 1,
+	},
+}
+
+// RecipeIDs returns every recipe ID in sorted order.
+func RecipeIDs() []string {
+	ids := make([]string, 0, len(Recipes))
+	for id := range Recipes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// FormatIngredients renders an item count map as "2x Wolf Pelt, 1x Torch",
+// sorted by item ID.
+func FormatIngredients(items map[string]int) string {
+	ids := make([]string, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		name := id
+		if it, ok := Items[id]; ok {
+			name = it.Name
+		}
+		parts = append(parts, fmt.Sprintf("%dx %s", items[id], name))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ================================
+// Craft
+// ================================
+
+// Craft consumes a recipe's inputs and gold cost and adds its output.
+// Nothing is consumed unless every ingredient is present.
+func Craft(state *State, recipeID string, rng RNG) (Events, error) {
+	events := Events{}
+
+	recipe, ok := Recipes[NormalizeItemID(recipeID)]
+	if !ok {
+		return events, errors.New("unknown recipe")
+	}
+
+	missing := map[string]int{}
+	for id, qty := range recipe.Inputs {
+		if have := GetItemCount(&state.Player, id); have < qty {
+			missing[id] = qty - have
+		}
+	}
+	if len(missing) > 0 {
+		return events, errors.New("missing ingredients: " + FormatIngredients(missing))
+	}
+	if state.Player.Gold < recipe.GoldCost {
+		return events, fmt.Errorf("not enough gold (need %d)", recipe.GoldCost)
+	}
+	qty := max(1, recipe.OutputQty)
+	if limit := state.Player.MaxCarryWeight; limit > 0 {
+		after := CarryWeight(&state.Player) + ItemWeight(recipe.Output)*qty
+		for id, n := range recipe.Inputs {
+			after -= ItemWeight(id) * n
+		}
+		if after > limit {
+			return events, fmt.Errorf("too heavy to carry (%d/%d)", after, limit)
+		}
+	}
+
+	ids := make([]string, 0, len(recipe.Inputs))
+	for id := range recipe.Inputs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		events = append(events, RemoveItemWithEvent(&state.Player, id, recipe.Inputs[id])...)
+	}
+
+	if recipe.GoldCost > 0 {
+		state.Player.Gold -= recipe.GoldCost
+		events = emit(events, GoldSpent{Amount: recipe.GoldCost})
+	}
+
+	events = append(events, AddItemWithEvent(&state.Player, recipe.Output, qty, rng)...)
+	events = emit(events, ItemCrafted{RecipeID: recipe.ID, ItemID: recipe.Output, Count: qty})
+
+	return events, nil
+}
@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCraft_ConsumesInputsAndAddsOutput(t *testing.T) {
+	state := DefaultState()
+	AddItem(&state.Player, "orcish_blade", 1)
+	state.Player.Gold = 30
+
+	events, err := Craft(&state, "orcish_greatblade", &seqRNG{})
+	if err != nil {
+		t.Fatalf("Craft returned error: %v", err)
+	}
+
+	if HasItem(&state.Player, "orcish_blade", 1) || HasItem(&state.Player, "rusty_dagger", 1) {
+		t.Fatalf("expected inputs consumed, inventory=%v", state.Player.Inventory)
+	}
+	if GetItemCount(&state.Player, "orcish_greatblade") != 1 {
+		t.Fatalf("expected crafted output in inventory")
+	}
+	if state.Player.Gold != 5 {
+		t.Fatalf("expected gold cost deducted to 5, got %d", state.Player.Gold)
+	}
+
+	var removed, added, crafted, spent int
+	for _, ev := range events {
+		switch ev.(type) {
+		case ItemRemoved:
+			removed++
+		case ItemAdded:
+			added++
+		case ItemCrafted:
+			crafted++
+		case GoldSpent:
+			spent++
+		}
+	}
+	if removed != 2 || added != 1 || crafted != 1 || spent != 1 {
+		t.Fatalf("unexpected events: removed=%d added=%d crafted=%d spent=%d", removed, added, crafted, spent)
+	}
+}
+
+func TestCraft_InsufficientIngredientsListsShortfall(t *testing.T) {
+	state := DefaultState()
+	AddItem(&state.Player, "bear_claw", 1)
+
+	_, err := Craft(&state, "bear_charm", &seqRNG{})
+	if err == nil {
+		t.Fatalf("expected missing ingredients error")
+	}
+	for _, want := range []string{"1x Bear Claw", "1x Ancient Coin"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in error, got %q", want, err.Error())
+		}
+	}
+	if GetItemCount(&state.Player, "bear_claw") != 1 {
+		t.Fatalf("expected no ingredients consumed on failure")
+	}
+}
+
+func TestCraft_UnknownRecipe(t *testing.T) {
+	state := DefaultState()
+	if _, err := Craft(&state, "moon_sword", &seqRNG{}); err == nil {
+		t.Fatalf("expected unknown recipe error")
+	}
+}
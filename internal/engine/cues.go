@@ -0,0 +1,118 @@
+package engine
+
+// ================================
+// Cues
+// ================================
+
+// CueFor returns a short, stable identifier for an event that a frontend
+// can map to a sound or a screen-reader announcement. Several events share
+// a cue where they should sound alike. Unknown events return "".
+func CueFor(e Event) string {
+	switch ev := e.(type) {
+	case DamageDealt:
+		if ev.Target == "player" {
+			return "hurt"
+		}
+		return "hit"
+	case EnemyDefeated:
+		return "kill"
+	case PlayerDefeated:
+		return "death"
+	case XPGained:
+		return "xp"
+	case LevelUp, LevelsGained:
+		return "levelup"
+	case AchievementUnlocked:
+		return "achievement"
+	case ItemAdded, LootFound:
+		return "loot"
+	case UpgradeAvailable:
+		return "upgrade"
+	case ItemRemoved:
+		return "item"
+	case InventoryFull:
+		return "full"
+	case ItemCrafted:
+		return "craft"
+	case GoldGained:
+		return "gold"
+	case GoldSpent:
+		return "spend"
+	case GoldBanked:
+		return "deposit"
+	case GoldWithdrawn:
+		return "withdraw"
+	case InterestAccrued:
+		return "interest"
+	case SPSpent:
+		return "effort"
+	case ItemEquipped:
+		return "equip"
+	case ItemUnequipped:
+		return "unequip"
+	case Hint:
+		return "hint"
+	case RepeatStopped:
+		return "stop"
+	case Stolen:
+		return "theft"
+	case StolenRecovered:
+		return "recover"
+	case CurseLifted:
+		return "uncurse"
+	case PriceQuoted:
+		return "quote"
+	case Haggled:
+		if ev.WalkedAway {
+			return "walkaway"
+		}
+		return "haggle"
+	case SetBonusActive, BuffGained:
+		return "buff"
+	case SetBonusEnded, BuffExpired:
+		return "fade"
+	case SPRegained:
+		return "restore"
+	case HPRestored:
+		return "heal"
+	case EncounterStarted:
+		return "encounter"
+	case ItemWorn:
+		return "wear"
+	case ItemRepaired:
+		return "repair"
+	case StatTrained:
+		return "train"
+	case TurnStarted:
+		return "turn"
+	case EncounterAvoided:
+		return "avoid"
+	case EncounterEnded:
+		return "end"
+	case Prestiged:
+		return "prestige"
+	case CombatStalemate:
+		return "stalemate"
+	case CombatTotals:
+		return "tally"
+	case WorldChanged:
+		return "world"
+	case TimeChanged:
+		return "time"
+	case DungeonEntered:
+		return "dungeon"
+	case DungeonCleared:
+		return "victory"
+	case DungeonFailed:
+		return "defeat"
+	case ChoiceOffered:
+		return "choice"
+	case MerchantOffered:
+		return "merchant"
+	case MerchantLeft:
+		return "depart"
+	case ExplorationResult:
+		return "explore"
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+package engine
+
+import "testing"
+
+func TestCueFor_EveryEventHasACue(t *testing.T) {
+	tests := []struct {
+		event Event
+		want  string
+	}{
+		{DamageDealt{Source: "wolf", Target: "player"}, "hurt"},
+		{DamageDealt{Source: "player", Target: "wolf"}, "hit"},
+		{EnemyDefeated{}, "kill"},
+		{PlayerDefeated{}, "death"},
+		{XPGained{}, "xp"},
+		{LevelUp{}, "levelup"},
+		{LevelsGained{}, "levelup"},
+		{AchievementUnlocked{}, "achievement"},
+		{ItemAdded{}, "loot"},
+		{UpgradeAvailable{}, "upgrade"},
+		{ItemRemoved{}, "item"},
+		{InventoryFull{}, "full"},
+		{ItemCrafted{}, "craft"},
+		{LootFound{}, "loot"},
+		{GoldGained{}, "gold"},
+		{GoldSpent{}, "spend"},
+		{GoldBanked{}, "deposit"},
+		{GoldWithdrawn{}, "withdraw"},
+		{InterestAccrued{}, "interest"},
+		{SPSpent{}, "effort"},
+		{ItemEquipped{}, "equip"},
+		{ItemUnequipped{}, "unequip"},
+		{Hint{}, "hint"},
+		{RepeatStopped{}, "stop"},
+		{Stolen{}, "theft"},
+		{StolenRecovered{}, "recover"},
+		{CurseLifted{}, "uncurse"},
+		{PriceQuoted{}, "quote"},
+		{Haggled{}, "haggle"},
+		{Haggled{WalkedAway: true}, "walkaway"},
+		{SetBonusActive{}, "buff"},
+		{SetBonusEnded{}, "fade"},
+		{BuffGained{}, "buff"},
+		{BuffExpired{}, "fade"},
+		{SPRegained{}, "restore"},
+		{HPRestored{}, "heal"},
+		{EncounterStarted{}, "encounter"},
+		{ItemWorn{}, "wear"},
+		{ItemRepaired{}, "repair"},
+		{StatTrained{}, "train"},
+		{TurnStarted{}, "turn"},
+		{EncounterAvoided{}, "avoid"},
+		{EncounterEnded{}, "end"},
+		{Prestiged{}, "prestige"},
+		{CombatStalemate{}, "stalemate"},
+		{CombatTotals{}, "tally"},
+		{WorldChanged{}, "world"},
+		{TimeChanged{}, "time"},
+		{DungeonEntered{}, "dungeon"},
+		{DungeonCleared{}, "victory"},
+		{DungeonFailed{}, "defeat"},
+		{ChoiceOffered{}, "choice"},
+		{MerchantOffered{}, "merchant"},
+		{MerchantLeft{}, "depart"},
+		{ExplorationResult{}, "explore"},
+	}
+	for _, tc := range tests {
+		if got := CueFor(tc.event); got != tc.want {
+			t.Errorf("CueFor(%s) = %q, want %q", tc.event.EventType(), got, tc.want)
+		}
+	}
+}
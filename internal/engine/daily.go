@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// ================================
+// Daily Challenge
+// ================================
+
+// DailyDate formats the challenge day for t, in UTC so every player shares
+// the same day.
+func DailyDate(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// DailySeed derives the RNG seed for the challenge on t's day. Everyone who
+// plays the same day gets the same enemies and loot.
+func DailySeed(t time.Time) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("grimoire-daily:" + DailyDate(t)))
+	return int64(h.Sum64())
+}
+
+// UseStandardRules puts every rule a flag or config file can change back to
+// its built-in value, so a daily challenge plays out the same for everyone
+// on its seed. It reports whether any rule had been changed.
+func UseStandardRules() (changed bool) {
+	changed = CurrentTunables() != DefaultTunables() ||
+		Initiative || EnemyVariants || ItemAffixes || Haggling || LootPity || AntiFarm
+	ApplyTunables(DefaultTunables())
+	Initiative, EnemyVariants, ItemAffixes = false, false, false
+	Haggling, LootPity, AntiFarm = false, false, false
+	return changed
+}
+
+// ChallengeScore rates a run: gold + level*100 + enemies killed.
+func ChallengeScore(state *State) int {
+	kills := 0
+	for _, e := range state.Bestiary {
+		kills += e.Killed
+	}
+	return state.Player.Gold + state.Player.Level*100 + kills
+}
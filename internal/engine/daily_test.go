@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDailySeed_SameDateSameFirstEncounter(t *testing.T) {
+	day := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	later := day.Add(10 * time.Hour)
+	if DailySeed(day) != DailySeed(later) {
+		t.Fatal("expected one seed for the whole day")
+	}
+	if DailySeed(day) == DailySeed(day.AddDate(0, 0, 1)) {
+		t.Fatal("expected a different seed the next day")
+	}
+
+	run := func(at time.Time) (State, Events) {
+		state := DefaultState()
+		events, err := Hunt(&state, 0, rand.New(rand.NewSource(DailySeed(at))))
+		if err != nil {
+			t.Fatalf("Hunt returned error: %v", err)
+		}
+		return state, events
+	}
+	s1, e1 := run(day)
+	s2, e2 := run(later)
+	if !reflect.DeepEqual(e1, e2) {
+		t.Fatalf("expected identical encounters:\n%v\n%v", e1, e2)
+	}
+	if !reflect.DeepEqual(s1, s2) {
+		t.Fatalf("expected identical states:\n%+v\n%+v", s1, s2)
+	}
+}
+
+func TestChallengeScore(t *testing.T) {
+	state := DefaultState()
+	state.Player.Gold = 40
+	state.Player.Level = 3
+	state.Bestiary = map[string]BestiaryEntry{"goblin": {Seen: 4, Killed: 3}, "wolf": {Seen: 1, Killed: 1}}
+	if got := ChallengeScore(&state); got != 344 {
+		t.Fatalf("expected 40 + 300 + 4 = 344, got %d", got)
+	}
+}
+
+func TestUseStandardRules_ResetsFlagsAndTunables(t *testing.T) {
+	oldTunables := CurrentTunables()
+	oldFlags := []bool{Initiative, EnemyVariants, ItemAffixes, Haggling, LootPity, AntiFarm}
+	defer func() {
+		ApplyTunables(oldTunables)
+		Initiative, EnemyVariants, ItemAffixes = oldFlags[0], oldFlags[1], oldFlags[2]
+		Haggling, LootPity, AntiFarm = oldFlags[3], oldFlags[4], oldFlags[5]
+	}()
+
+	if UseStandardRules() {
+		t.Fatal("expected the built-in rules to need no reset")
+	}
+
+	EncounterRate = 90
+	EnemyVariants, Haggling = true, true
+	if !UseStandardRules() {
+		t.Fatal("expected custom rules reported")
+	}
+	if CurrentTunables() != DefaultTunables() || EnemyVariants || Haggling {
+		t.Fatalf("expected the built-in rules back, got %+v variants=%v haggle=%v",
+			CurrentTunables(), EnemyVariants, Haggling)
+	}
+}
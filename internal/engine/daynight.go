@@ -0,0 +1,54 @@
+package engine
+
+// ================================
+// Time of Day
+// ================================
+
+// Time-of-day phases. An unset Meta.TimeOfDay is neutral: encounter weights
+// are unchanged until the first nightfall.
+const (
+	TimeDay   = "day"
+	TimeNight = "night"
+)
+
+// DayNightInterval is how many commands pass between phase changes. It is a
+// variable so it can be tuned; zero disables the cycle.
+var DayNightInterval = 20
+
+// TimeOfDayBias is the ChooseEnemy weight added to skeletons at night, and
+// split between wolves and bears by day.
+var TimeOfDayBias = 10
+
+// AdvanceTime counts one command toward the next phase and flips between
+// night and day when it is due. A neutral clock falls to night first.
+func AdvanceTime(state *State) Events {
+	if DayNightInterval <= 0 {
+		return nil
+	}
+
+	state.Meta.TimeTicks++
+	if state.Meta.TimeTicks < DayNightInterval {
+		return nil
+	}
+	state.Meta.TimeTicks = 0
+
+	from := state.Meta.TimeOfDay
+	to := TimeNight
+	if from == TimeNight {
+		to = TimeDay
+	}
+	state.Meta.TimeOfDay = to
+	return emit(nil, TimeChanged{From: from, To: to})
+}
+
+// timeOfDayWeights shifts ChooseEnemy's weights, indexed like its pool, for
+// the current phase.
+func timeOfDayWeights(state *State, weights []int) {
+	switch state.Meta.TimeOfDay {
+	case TimeNight:
+		weights[1] += TimeOfDayBias // skeleton
+	case TimeDay:
+		weights[3] += TimeOfDayBias / 2 // wolf
+		weights[4] += TimeOfDayBias / 2 // bear
+	}
+}
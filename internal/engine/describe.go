@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ================================
+// Item Descriptions
+// ================================
+
+// DescribeItem returns what `examine` shows for an item: its name, flavor
+// text, then what it does when used or worn and what it is worth.
+func DescribeItem(id string) ([]string, error) {
+	id = NormalizeItemID(id)
+	item, ok := Items[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownItem, id)
+	}
+
+	lines := []string{item.Name}
+	if item.Description != "" {
+		lines = append(lines, item.Description)
+	}
+	for _, eff := range item.Effects {
+		lines = append(lines, "Use: "+describeEffect(eff))
+	}
+	if item.Slot != "" {
+		var stats []string
+		if item.Attack > 0 {
+			stats = append(stats, fmt.Sprintf("+%d attack", item.Attack))
+		}
+		if item.Defense > 0 {
+			stats = append(stats, fmt.Sprintf("+%d defense", item.Defense))
+		}
+		if item.MaxDurability > 0 {
+			stats = append(stats, fmt.Sprintf("lasts %d fights", item.MaxDurability))
+		}
+		lines = append(lines, fmt.Sprintf("Equip (%s): %s", item.Slot, strings.Join(stats, ", ")))
+	}
+
+	var facts []string
+	if item.Rare {
+		facts = append(facts, "rare")
+	}
+	if item.Junk {
+		facts = append(facts, "junk")
+	}
+	facts = append(facts, fmt.Sprintf("weight %d", ItemWeight(id)))
+	if item.Price > 0 {
+		facts = append(facts, fmt.Sprintf("sells for %d gold", item.Price))
+	}
+	return append(lines, strings.Join(facts, ", ")), nil
+}
+
+// describeEffect summarizes one use-effect, e.g. "restores 10–25 HP".
+func describeEffect(eff Effect) string {
+	amount := fmt.Sprintf("%d", eff.Min)
+	if eff.Max > eff.Min {
+		amount = fmt.Sprintf("%d–%d", eff.Min, eff.Max)
+	}
+	switch eff.Kind {
+	case EffectHeal:
+		return "restores " + amount + " HP"
+	case EffectRestoreSP:
+		return "restores " + amount + " SP"
+	case EffectBuff:
+		return fmt.Sprintf("+%s %s for %d encounters", amount, eff.Stat, eff.Encounters)
+	case EffectEscape:
+		return "escapes the current encounter"
+	default:
+		return string(eff.Kind)
+	}
+}
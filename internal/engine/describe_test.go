@@ -0,0 +1,26 @@
+package engine
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDescribeItem_HealingPotionShowsRestoreRange(t *testing.T) {
+	lines, err := DescribeItem("Healing Potion")
+	if err != nil {
+		t.Fatalf("DescribeItem returned error: %v", err)
+	}
+	text := strings.Join(lines, "\n")
+	for _, want := range []string{"Healing Potion", "restores 10–25 HP", "restores 1–3 SP", Items["healing_potion"].Description} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in:\n%s", want, text)
+		}
+	}
+}
+
+func TestDescribeItem_UnknownItem(t *testing.T) {
+	if _, err := DescribeItem("dragon_egg"); !errors.Is(err, ErrUnknownItem) {
+		t.Fatalf("expected ErrUnknownItem, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ================================
+// State Diff
+// ================================
+
+// FieldChange records a field that differs between two states. Field is
+// the dotted JSON path, e.g. "player.gold"; maps, slices and nested structs
+// show their values as compact JSON.
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// ItemDelta records an inventory count difference for one item.
+type ItemDelta struct {
+	ItemID string
+	Before int
+	After  int
+}
+
+// StateDiff is a structured comparison of two states, independent of how
+// a UI renders it.
+type StateDiff struct {
+	Changed      []FieldChange
+	ItemsAdded   []ItemDelta // present only in the second state
+	ItemsRemoved []ItemDelta // present only in the first state
+	ItemsChanged []ItemDelta // present in both with different counts
+}
+
+// Empty reports whether the two states compared equal.
+func (d StateDiff) Empty() bool {
+	return len(d.Changed) == 0 &&
+		len(d.ItemsAdded) == 0 &&
+		len(d.ItemsRemoved) == 0 &&
+		len(d.ItemsChanged) == 0
+}
+
+// DiffStates compares player stats, inventory and meta fields of a and b.
+// Fields are discovered from the JSON tags, so new fields are picked up
+// without touching this function.
+func DiffStates(a, b *State) StateDiff {
+	var d StateDiff
+	d.Changed = append(d.Changed, diffFields("player", reflect.ValueOf(a.Player), reflect.ValueOf(b.Player))...)
+	d.Changed = append(d.Changed, diffFields("meta", reflect.ValueOf(a.Meta), reflect.ValueOf(b.Meta))...)
+
+	ids := map[string]bool{}
+	for id := range a.Player.Inventory {
+		ids[id] = true
+	}
+	for id := range b.Player.Inventory {
+		ids[id] = true
+	}
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+
+	for _, id := range sorted {
+		before, inA := a.Player.Inventory[id]
+		after, inB := b.Player.Inventory[id]
+		delta := ItemDelta{ItemID: id, Before: before, After: after}
+		switch {
+		case inA && !inB:
+			d.ItemsRemoved = append(d.ItemsRemoved, delta)
+		case !inA && inB:
+			d.ItemsAdded = append(d.ItemsAdded, delta)
+		case before != after:
+			d.ItemsChanged = append(d.ItemsChanged, delta)
+		}
+	}
+
+	return d
+}
+
+func diffFields(prefix string, a, b reflect.Value) []FieldChange {
+	var out []FieldChange
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		av, bv := a.Field(i), b.Field(i)
+		switch av.Kind() {
+		case reflect.Map, reflect.Slice, reflect.Struct, reflect.Pointer:
+			if prefix+"."+name == "player.inventory" {
+				continue // diffed item by item in DiffStates
+			}
+			if compoundEqual(av, bv) {
+				continue
+			}
+			out = append(out, FieldChange{
+				Field:  prefix + "." + name,
+				Before: compoundText(av),
+				After:  compoundText(bv),
+			})
+			continue
+		}
+		if av.Interface() != bv.Interface() {
+			out = append(out, FieldChange{
+				Field:  prefix + "." + name,
+				Before: fmt.Sprint(av.Interface()),
+				After:  fmt.Sprint(bv.Interface()),
+			})
+		}
+	}
+	return out
+}
+
+// compoundEqual compares two compound values, treating a nil and an empty
+// map or slice as equal, since omitempty saves them the same way.
+func compoundEqual(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Map, reflect.Slice:
+		if a.Len() == 0 && b.Len() == 0 {
+			return true
+		}
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// compoundText renders a compound value as compact JSON, with map keys
+// sorted, or "none" when it is nil or empty.
+func compoundText(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		if v.Len() == 0 {
+			return "none"
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			return "none"
+		}
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprint(v.Interface())
+	}
+	return string(data)
+}
@@ -0,0 +1,65 @@
+package engine
+
+import "testing"
+
+func TestDiffStates_ReportsGoldChangeAndItemDeltas(t *testing.T) {
+	a := DefaultState()
+	b := cloneState(a)
+	b.Player.Gold = 75
+	AddItem(&b.Player, "healing_potion", 2)
+	RemoveItem(&b.Player, "torch", 1)
+	AddItem(&b.Player, "rusty_dagger", 1)
+
+	d := DiffStates(&a, &b)
+	if d.Empty() {
+		t.Fatalf("expected non-empty diff")
+	}
+
+	if len(d.Changed) != 1 {
+		t.Fatalf("expected exactly one changed field, got %+v", d.Changed)
+	}
+	if got := d.Changed[0]; got.Field != "player.gold" || got.Before != "50" || got.After != "75" {
+		t.Fatalf("unexpected gold change: %+v", got)
+	}
+
+	if len(d.ItemsAdded) != 1 || d.ItemsAdded[0] != (ItemDelta{ItemID: "healing_potion", Before: 0, After: 2}) {
+		t.Fatalf("unexpected added items: %+v", d.ItemsAdded)
+	}
+	if len(d.ItemsRemoved) != 1 || d.ItemsRemoved[0].ItemID != "torch" {
+		t.Fatalf("unexpected removed items: %+v", d.ItemsRemoved)
+	}
+	if len(d.ItemsChanged) != 1 || d.ItemsChanged[0] != (ItemDelta{ItemID: "rusty_dagger", Before: 1, After: 2}) {
+		t.Fatalf("unexpected changed items: %+v", d.ItemsChanged)
+	}
+}
+
+func TestDiffStates_IdenticalStatesAreEmpty(t *testing.T) {
+	a := DefaultState()
+	b := cloneState(a)
+	if d := DiffStates(&a, &b); !d.Empty() {
+		t.Fatalf("expected empty diff, got %+v", d)
+	}
+}
+
+func TestDiffStates_ReportsCompoundFields(t *testing.T) {
+	a := DefaultState()
+	b := cloneState(a)
+	b.Player.Equipment = map[string]string{SlotWeapon: "rusty_dagger"}
+	b.Meta.Achievements = map[string]bool{"first_blood": true}
+	a.Meta.LastUsed = map[string]int{} // empty and nil compare equal
+
+	d := DiffStates(&a, &b)
+	got := map[string]FieldChange{}
+	for _, c := range d.Changed {
+		got[c.Field] = c
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected equipment and achievements, got %+v", d.Changed)
+	}
+	if c := got["player.equipment"]; c.After != `{"weapon":"rusty_dagger"}` {
+		t.Fatalf("unexpected equipment change: %+v", c)
+	}
+	if c := got["meta.achievements"]; c.Before != "none" || c.After != `{"first_blood":true}` {
+		t.Fatalf("unexpected achievements change: %+v", c)
+	}
+}
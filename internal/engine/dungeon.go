@@ -0,0 +1,165 @@
+package engine
+
+import (
+	"errors"
+	"sort"
+)
+
+// ================================
+// Dungeons
+// ================================
+
+// Dungeon is a fixed run of escalating encounters with a reward for
+// clearing all of them.
+type Dungeon struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Enemies []string `json:"enemies"` // fought in order
+	Gold    int      `json:"gold"`    // bonus on clear
+	XP      int      `json:"xp"`      // bonus on clear
+	Item    string   `json:"item"`    // guaranteed on clear
+}
+
+// DungeonRun is the player's progress through a dungeon. It lives in State
+// so a reload resumes the run.
+type DungeonRun struct {
+	ID    string `json:"id"`
+	Stage int    `json:"stage"` // index of the next enemy
+
+	// XP, Gold and Loot total what the run's fights have paid so far;
+	// EnteredHP is the player's HP on entry.
+	XP        int      `json:"xp,omitempty"`
+	Gold      int      `json:"gold,omitempty"`
+	Loot      []string `json:"loot,omitempty"`
+	EnteredHP int      `json:"entered_hp,omitempty"`
+}
+
+// errInDungeon blocks explore and hunt until the run is cleared or left.
+var errInDungeon = errors.New("you're in a dungeon: dungeon next | dungeon leave")
+
+// dungeonBlocked are the commands errInDungeon blocks. Resting, training
+// and the like report their own dungeon errors; the bank is in town.
+var dungeonBlocked = map[string]bool{"explore": true, "hunt": true, "deposit": true, "withdraw": true}
+
+// Dungeons is the global dungeon registry.
+var Dungeons = map[string]Dungeon{
+	"goblin_warren": {
+		ID:      "goblin_warren",
+		Name:    "Goblin Warren",
+		Enemies: []string{"goblin", "goblin", "bandit"},
+		Gold:    40,
+		XP:      30,
+		Item:    "healing_potion",
+	},
+	"bone_crypt": {
+		ID:      "bone_crypt",
+		Name:    "Bone Crypt",
+		Enemies: []string{"skeleton", "skeleton", "wolf", "bear"},
+		Gold:    90,
+		XP:      80,
+		Item:    "bone_shield",
+	},
+	"orc_stronghold": {
+		ID:      "orc_stronghold",
+		Name:    "Orc Stronghold",
+		Enemies: []string{"bandit", "wolf", "bear", "orc"},
+		Gold:    200,
+		XP:      150,
+		Item:    "orcish_blade",
+	},
+}
+
+// DungeonIDs returns every dungeon ID in sorted order.
+func DungeonIDs() []string {
+	ids := make([]string, 0, len(Dungeons))
+	for id := range Dungeons {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// EnterDungeon starts a run. Only one run can be active at a time.
+func EnterDungeon(state *State, id string) (Events, error) {
+	events := Events{}
+
+	if !state.Player.IsAlive() {
+		return events, ErrPlayerDown
+	}
+	if state.Dungeon != nil {
+		return events, errors.New("already inside " + Dungeons[state.Dungeon.ID].Name)
+	}
+	d, ok := Dungeons[NormalizeItemID(id)]
+	if !ok {
+		return events, errors.New("unknown dungeon")
+	}
+
+	state.Dungeon = &DungeonRun{ID: d.ID, EnteredHP: state.Player.HP}
+	events = emit(events, DungeonEntered{DungeonID: d.ID, Stages: len(d.Enemies)})
+	return events, nil
+}
+
+// DungeonNext fights the next enemy of the active run. Losing ends the run;
+// winning the last fight pays the clear reward.
+func DungeonNext(state *State, rng RNG) (Events, error) {
+	events := Events{}
+
+	if state.Dungeon == nil {
+		return events, errors.New("not in a dungeon")
+	}
+	if !state.Player.IsAlive() {
+		return events, ErrPlayerDown
+	}
+	if err := dungeonRunError(state.Dungeon); err != nil {
+		state.Dungeon = nil
+		return events, err
+	}
+	d := Dungeons[state.Dungeon.ID]
+
+	state.Meta.CommandCount++
+	enemy := Enemies[d.Enemies[state.Dungeon.Stage]]
+	result, combatEvents := ResolveCombat(state, enemy, stream(rng, StreamCombat))
+	events = append(events, combatEvents...)
+
+	if result.Outcome == "stalemate" {
+		// Neither side fell; the run stays on this stage.
+		return events, nil
+	}
+	if result.Outcome != "win" {
+		state.Dungeon = nil
+		return emit(events, DungeonFailed{DungeonID: d.ID}), nil
+	}
+
+	events = append(events, GrantXP(state, result.XP)...)
+	state.Player.Gold += result.Gold
+	events = emit(events, GoldGained{Amount: result.Gold})
+	events = append(events, GrantLoot(state, result.Loot, rng)...)
+
+	run := state.Dungeon
+	run.XP += result.XP
+	run.Gold += result.Gold
+	run.Loot = append(run.Loot, result.Loot...)
+	run.Stage++
+	if run.Stage < len(d.Enemies) {
+		return events, nil
+	}
+
+	// Cleared
+	state.Dungeon = nil
+	events = emit(events, DungeonCleared{DungeonID: d.ID})
+	events = append(events, GrantXP(state, d.XP)...)
+	state.Player.Gold += d.Gold
+	events = emit(events, GoldGained{Amount: d.Gold})
+	events = append(events, GrantLoot(state, []string{d.Item}, rng)...)
+	return events, nil
+}
+
+// LeaveDungeon abandons the active run.
+func LeaveDungeon(state *State) (Events, error) {
+	if state.Dungeon == nil {
+		return Events{}, errors.New("not in a dungeon")
+	}
+	id := state.Dungeon.ID
+	state.Dungeon = nil
+	return emit(nil, DungeonFailed{DungeonID: id}), nil
+}
@@ -0,0 +1,137 @@
+package engine
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDungeon_TwoEnemyRunToCompletion(t *testing.T) {
+	Dungeons["test_cellar"] = Dungeon{
+		ID: "test_cellar", Name: "Cellar",
+		Enemies: []string{"goblin", "skeleton"},
+		Gold:    25, XP: 10, Item: "torch",
+	}
+	defer delete(Dungeons, "test_cellar")
+
+	state := DefaultState()
+	state.Player.Level = 10
+	rng := &seqRNG{floats: []float64{1, 1, 1, 1}} // one-hit kills, no drops
+
+	if _, err := RunCommand(&state, "dungeon enter test_cellar", rng); err != nil {
+		t.Fatalf("enter returned error: %v", err)
+	}
+	if _, err := RunCommand(&state, "rest 1", rng); err == nil {
+		t.Fatalf("expected resting to be blocked inside a dungeon")
+	}
+
+	if _, err := RunCommand(&state, "dungeon next", rng); err != nil {
+		t.Fatalf("first fight returned error: %v", err)
+	}
+	if state.Dungeon == nil || state.Dungeon.Stage != 1 {
+		t.Fatalf("expected run at stage 1, got %+v", state.Dungeon)
+	}
+
+	// Progress survives a save round-trip.
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var resumed State
+	if err := json.Unmarshal(data, &resumed); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resumed.Dungeon == nil || resumed.Dungeon.Stage != 1 {
+		t.Fatalf("expected run restored at stage 1, got %+v", resumed.Dungeon)
+	}
+	gold := resumed.Player.Gold
+	events, err := RunCommand(&resumed, "dungeon next", rng)
+	if err != nil {
+		t.Fatalf("final fight returned error: %v", err)
+	}
+	if resumed.Dungeon != nil {
+		t.Fatalf("expected run cleared, got %+v", resumed.Dungeon)
+	}
+
+	cleared := false
+	for _, ev := range events {
+		if c, ok := ev.(DungeonCleared); ok && c.DungeonID == "test_cellar" {
+			cleared = true
+		}
+	}
+	if !cleared {
+		t.Fatalf("expected DungeonCleared, got %v", events)
+	}
+	if want := gold + Enemies["skeleton"].Gold + 25; resumed.Player.Gold != want {
+		t.Fatalf("expected gold %d after clear, got %d", want, resumed.Player.Gold)
+	}
+	if !HasItem(&resumed.Player, "torch", 2) {
+		t.Fatalf("expected guaranteed torch reward")
+	}
+}
+
+func TestDungeon_LosingEndsRun(t *testing.T) {
+	state := DefaultState()
+	state.Player.HP = 1
+	if _, err := EnterDungeon(&state, "orc_stronghold"); err != nil {
+		t.Fatalf("enter returned error: %v", err)
+	}
+
+	// weak hit, then the bandit hits back for at least 1.
+	events, err := DungeonNext(&state, &seqRNG{})
+	if err != nil {
+		t.Fatalf("DungeonNext returned error: %v", err)
+	}
+	if state.Dungeon != nil {
+		t.Fatalf("expected run to end on defeat")
+	}
+	if _, ok := events[len(events)-1].(DungeonFailed); !ok {
+		t.Fatalf("expected DungeonFailed last, got %v", events)
+	}
+}
+
+func TestDungeonNext_RejectsBrokenRun(t *testing.T) {
+	for _, run := range []DungeonRun{{ID: "no_such_dungeon"}, {ID: "orc_stronghold", Stage: 99}} {
+		state := DefaultState()
+		state.Dungeon = &run
+		if _, err := DungeonNext(&state, &seqRNG{}); err == nil {
+			t.Fatalf("expected an error for run %+v", run)
+		}
+		if state.Dungeon != nil {
+			t.Fatalf("expected run %+v abandoned", run)
+		}
+	}
+}
+
+func TestUseItem_SmokeBombEscapesDungeonRun(t *testing.T) {
+	state := DefaultState()
+	AddItem(&state.Player, "smoke_bomb", 2)
+
+	if _, err := UseItem(&state, "smoke_bomb", &seqRNG{}); err == nil || err.Error() != "no encounter to escape" {
+		t.Fatalf("expected escape error outside an encounter, got %v", err)
+	}
+	if !HasItem(&state.Player, "smoke_bomb", 2) {
+		t.Fatalf("expected smoke bomb kept when there is nothing to escape")
+	}
+
+	if _, err := EnterDungeon(&state, "goblin_warren"); err != nil {
+		t.Fatalf("enter returned error: %v", err)
+	}
+	gold, xp := state.Player.Gold, state.Player.XP
+
+	events, err := UseItem(&state, "smoke_bomb", &seqRNG{})
+	if err != nil {
+		t.Fatalf("UseItem returned error: %v", err)
+	}
+	if state.Dungeon != nil {
+		t.Fatalf("expected encounter ended, got %+v", state.Dungeon)
+	}
+	if !HasItem(&state.Player, "smoke_bomb", 1) || HasItem(&state.Player, "smoke_bomb", 2) {
+		t.Fatalf("expected one smoke bomb consumed")
+	}
+	if state.Player.Gold != gold || state.Player.XP != xp {
+		t.Fatalf("expected no rewards or penalty on escape")
+	}
+	if e, ok := events[len(events)-1].(EncounterEnded); !ok || e.DungeonID != "goblin_warren" {
+		t.Fatalf("expected EncounterEnded last, got %v", events)
+	}
+}
@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ================================
+// Durability
+// ================================
+
+// RepairGoldPerPoint is the gold `repair` charges per durability point.
+const RepairGoldPerPoint = 2
+
+// Durability returns how much durability an item has left. Items without
+// a MaxDurability never wear and report 0.
+func Durability(p *Player, itemID string) int {
+	return max(0, Items[itemID].MaxDurability-p.Wear[itemID])
+}
+
+// gearBonus scales an equipped item's stat by its remaining durability,
+// rounding up so only a broken item loses its bonus entirely.
+func gearBonus(p *Player, itemID string, stat int) int {
+	maxDur := Items[itemID].MaxDurability
+	if maxDur <= 0 || stat <= 0 {
+		return stat
+	}
+	return (stat*Durability(p, itemID) + maxDur - 1) / maxDur
+}
+
+// wearGear takes one durability point from every equipped item that wears,
+// in slot order.
+func wearGear(p *Player) Events {
+	var events Events
+	slots := make([]string, 0, len(p.Equipment))
+	for slot := range p.Equipment {
+		slots = append(slots, slot)
+	}
+	sort.Strings(slots)
+
+	for _, slot := range slots {
+		id := p.Equipment[slot]
+		if Items[id].MaxDurability <= 0 || Durability(p, id) == 0 {
+			continue
+		}
+		if p.Wear == nil {
+			p.Wear = map[string]int{}
+		}
+		p.Wear[id]++
+		events = emit(events, ItemWorn{
+			ItemID:        id,
+			Durability:    Durability(p, id),
+			MaxDurability: Items[id].MaxDurability,
+		})
+	}
+	return events
+}
+
+// dropWear forgets itemID's wear once no copy is carried or worn, so a
+// copy found later starts at full durability.
+func dropWear(p *Player, itemID string) {
+	if p.Inventory[itemID] > 0 {
+		return
+	}
+	for _, id := range p.Equipment {
+		if id == itemID {
+			return
+		}
+	}
+	delete(p.Wear, itemID)
+}
+
+// Repair restores an item, named by item ID or the slot it is worn in, to
+// full durability for RepairGoldPerPoint gold per point. Only possible in
+// town.
+func Repair(state *State, name string) (Events, error) {
+	events := Events{}
+	p := &state.Player
+	name = NormalizeItemID(name)
+
+	if state.Dungeon != nil {
+		return events, errors.New("you can only repair in town; leave the dungeon first")
+	}
+	id := name
+	if worn, ok := p.Equipment[name]; ok {
+		id = worn
+	}
+	if p.Wear[id] == 0 {
+		return events, errors.New("nothing to repair")
+	}
+
+	points := Items[id].MaxDurability - Durability(p, id)
+	cost := points * RepairGoldPerPoint
+	if p.Gold < cost {
+		return events, fmt.Errorf("repairing %s costs %d gold", id, cost)
+	}
+
+	p.Gold -= cost
+	delete(p.Wear, id)
+	events = emit(events,
+		GoldSpent{Amount: cost},
+		ItemRepaired{ItemID: id, Durability: Items[id].MaxDurability},
+	)
+	return events, nil
+}
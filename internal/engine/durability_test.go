@@ -0,0 +1,82 @@
+package engine
+
+import "testing"
+
+func TestCombat_WearsEquippedWeapon(t *testing.T) {
+	state := DefaultState()
+	state.Player.Inventory = map[string]int{"orcish_blade": 1}
+	if _, err := Equip(&state, "orcish_blade"); err != nil {
+		t.Fatalf("Equip returned error: %v", err)
+	}
+
+	_, events := ResolveCombat(&state, Enemies["goblin"], &seqRNG{ints: []int{5}})
+	want := Items["orcish_blade"].MaxDurability - 1
+	if got := Durability(&state.Player, "orcish_blade"); got != want {
+		t.Fatalf("expected durability %d after one fight, got %d", want, got)
+	}
+	worn, ok := events[len(events)-1].(ItemWorn)
+	if !ok || worn.ItemID != "orcish_blade" || worn.Durability != want {
+		t.Fatalf("expected ItemWorn for the blade, got %#v", events[len(events)-1])
+	}
+}
+
+func TestRepair_RestoresBrokenBonus(t *testing.T) {
+	state := DefaultState()
+	state.Player.Gold = 1000
+	state.Player.Inventory = map[string]int{"orcish_blade": 1}
+	base := CharacterSheet(&state).AttackMin
+	if _, err := Equip(&state, "orcish_blade"); err != nil {
+		t.Fatalf("Equip returned error: %v", err)
+	}
+
+	maxDur := Items["orcish_blade"].MaxDurability
+	state.Player.Wear = map[string]int{"orcish_blade": maxDur / 2}
+	if got := CharacterSheet(&state).AttackMin; got != base+2 {
+		t.Fatalf("expected half-worn blade to give +2 attack, got +%d", got-base)
+	}
+	state.Player.Wear["orcish_blade"] = maxDur
+	if got := CharacterSheet(&state).AttackMin; got != base {
+		t.Fatalf("expected broken blade to give no bonus, got +%d", got-base)
+	}
+
+	events, err := RunCommand(&state, "repair weapon", &seqRNG{})
+	if err != nil {
+		t.Fatalf("repair: %v", err)
+	}
+	if _, ok := events[1].(ItemRepaired); !ok {
+		t.Fatalf("expected ItemRepaired, got %#v", events)
+	}
+	if state.Player.Gold != 1000-maxDur*RepairGoldPerPoint {
+		t.Fatalf("expected %d gold spent, got %d left", maxDur*RepairGoldPerPoint, state.Player.Gold)
+	}
+	if got := CharacterSheet(&state).AttackMin; got != base+Items["orcish_blade"].Attack {
+		t.Fatalf("expected full bonus after repair, got +%d", got-base)
+	}
+	if _, err := Repair(&state, "orcish_blade"); err == nil {
+		t.Fatal("expected nothing to repair on a pristine blade")
+	}
+}
+
+func TestWear_ClearsWhenTheLastCopyLeaves(t *testing.T) {
+	state := DefaultState()
+	p := &state.Player
+	p.Inventory = map[string]int{"orcish_blade": 1}
+	p.Wear = map[string]int{"orcish_blade": 3}
+	if _, err := Equip(&state, "orcish_blade"); err != nil {
+		t.Fatalf("Equip: %v", err)
+	}
+	if p.Wear["orcish_blade"] != 3 {
+		t.Fatalf("equipping the last copy should keep its wear, got %v", p.Wear)
+	}
+
+	if _, err := Unequip(&state, "weapon"); err != nil {
+		t.Fatalf("Unequip: %v", err)
+	}
+	if _, err := Sell(&state, "orcish_blade", 1); err != nil {
+		t.Fatalf("Sell: %v", err)
+	}
+	AddItem(p, "orcish_blade", 1)
+	if got := Durability(p, "orcish_blade"); got != Items["orcish_blade"].MaxDurability {
+		t.Fatalf("a newly found blade should be pristine, got durability %d", got)
+	}
+}
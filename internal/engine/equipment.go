@@ -0,0 +1,226 @@
+package engine
+
+import (
+	"errors"
+	"sort"
+)
+
+// ================================
+// Equipment
+// ================================
+
+// Equipment slots.
+const (
+	SlotWeapon  = "weapon"
+	SlotArmor   = "armor"
+	SlotTrinket = "trinket"
+)
+
+// Equip moves an item from the inventory into its slot, returning whatever
+// was there to the inventory.
+func Equip(state *State, itemID string) (Events, error) {
+	events := Events{}
+	p := &state.Player
+	itemID = NormalizeItemID(itemID)
+
+	if !HasItem(p, itemID, 1) {
+		return events, ErrItemNotFound
+	}
+	item := Items[itemID]
+	if item.Slot == "" {
+		return events, errors.New("item can't be equipped")
+	}
+
+	if equippedAffix(p, item.Slot) == AffixCursed {
+		return events, ErrCursed
+	}
+
+	before := ActiveSets(p)
+	if old, ok := p.Equipment[item.Slot]; ok {
+		delete(p.Equipment, item.Slot)
+		AddItem(p, old, 1)
+		putAffix(p, old, equippedAffix(p, item.Slot))
+		events = emit(events, ItemUnequipped{ItemID: old, Slot: item.Slot})
+	}
+	affix := takeAffix(p, itemID)
+	if p.Equipment == nil {
+		p.Equipment = map[string]string{}
+	}
+	// Worn before it leaves the pack, so the last copy keeps its wear.
+	p.Equipment[item.Slot] = itemID
+	RemoveItem(p, itemID, 1)
+	setEquippedAffix(p, item.Slot, affix)
+	events = emit(events, ItemEquipped{ItemID: itemID, Slot: item.Slot})
+
+	return append(events, setChanges(before, ActiveSets(p))...), nil
+}
+
+// Unequip returns the item in a slot, named by slot or item ID, to the
+// inventory.
+func Unequip(state *State, name string) (Events, error) {
+	events := Events{}
+	p := &state.Player
+	name = NormalizeItemID(name)
+
+	slot := ""
+	for s, id := range p.Equipment {
+		if s == name || id == name {
+			slot = s
+		}
+	}
+	if slot == "" {
+		return events, errors.New("nothing equipped there")
+	}
+	if equippedAffix(p, slot) == AffixCursed {
+		return events, ErrCursed
+	}
+
+	before := ActiveSets(p)
+	id := p.Equipment[slot]
+	delete(p.Equipment, slot)
+	AddItem(p, id, 1)
+	putAffix(p, id, equippedAffix(p, slot))
+	setEquippedAffix(p, slot, "")
+	events = emit(events, ItemUnequipped{ItemID: id, Slot: slot})
+
+	return append(events, setChanges(before, ActiveSets(p))...), nil
+}
+
+// EquipTotal sums a stat over equipped items, shifted by their affixes and
+// scaled by durability, and active set bonuses.
+func EquipTotal(p *Player, stat string) int {
+	total := 0
+	for slot, id := range p.Equipment {
+		base := 0
+		switch stat {
+		case StatAttack:
+			base = Items[id].Attack
+		case StatDefense:
+			base = Items[id].Defense
+		}
+		total += gearBonus(p, id, base+AffixShift(equippedAffix(p, slot), base))
+	}
+	for _, id := range ActiveSets(p) {
+		if set := ItemSets[id]; set.Stat == stat {
+			total += set.Amount
+		}
+	}
+	return total
+}
+
+// ================================
+// Upgrades
+// ================================
+
+// CompareEquip scores candidate against current by attack plus defense;
+// positive means candidate is the upgrade.
+func CompareEquip(current, candidate Item) int {
+	return candidate.Attack + candidate.Defense - current.Attack - current.Defense
+}
+
+// FlagUpgrades emits UpgradeAvailable once per added item that beats what
+// is equipped in its slot. An empty slot is beaten by any stat at all.
+func FlagUpgrades(p *Player, events Events) Events {
+	var out Events
+	seen := map[string]bool{}
+	for _, ev := range events {
+		added, ok := ev.(ItemAdded)
+		if !ok {
+			continue
+		}
+		id := NormalizeItemID(added.ItemID)
+		candidate := Items[id]
+		if candidate.Slot == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		current := Items[p.Equipment[candidate.Slot]]
+		if CompareEquip(current, candidate) <= 0 {
+			continue
+		}
+		out = emit(out, UpgradeAvailable{
+			ItemID:  id,
+			Over:    current.ID,
+			Attack:  candidate.Attack - current.Attack,
+			Defense: candidate.Defense - current.Defense,
+		})
+	}
+	return out
+}
+
+// ================================
+// Item Sets
+// ================================
+
+// ItemSet grants a bonus while every member is equipped.
+type ItemSet struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+	Stat    string   `json:"stat"`
+	Amount  int      `json:"amount"`
+}
+
+// ItemSets is the global item set registry.
+var ItemSets = map[string]ItemSet{
+	"grave_guard": {
+		ID:      "grave_guard",
+		Name:    "Grave Guard",
+		Members: []string{"rusty_dagger", "bone_shield"},
+		Stat:    StatDefense,
+		Amount:  2,
+	},
+	"hunters_garb": {
+		ID:      "hunters_garb",
+		Name:    "Hunter's Garb",
+		Members: []string{"fur_cloak", "bear_charm"},
+		Stat:    StatAttack,
+		Amount:  2,
+	},
+}
+
+// ActiveSets returns the IDs of every fully equipped set, sorted.
+func ActiveSets(p *Player) []string {
+	worn := map[string]bool{}
+	for _, id := range p.Equipment {
+		worn[id] = true
+	}
+	var ids []string
+	for id, set := range ItemSets {
+		complete := len(set.Members) > 0
+		for _, m := range set.Members {
+			complete = complete && worn[m]
+		}
+		if complete {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// setChanges reports sets that ended or became active between two
+// ActiveSets results.
+func setChanges(before, after []string) Events {
+	var events Events
+	was := map[string]bool{}
+	for _, id := range before {
+		was[id] = true
+	}
+	is := map[string]bool{}
+	for _, id := range after {
+		is[id] = true
+	}
+	for _, id := range before {
+		if !is[id] {
+			events = emit(events, SetBonusEnded{SetID: id})
+		}
+	}
+	for _, id := range after {
+		if !was[id] {
+			events = emit(events, SetBonusActive{SetID: id})
+		}
+	}
+	return events
+}
@@ -0,0 +1,83 @@
+package engine
+
+import "testing"
+
+func TestEquip_SetBonusAppliesAndEnds(t *testing.T) {
+	state := DefaultState()
+	state.Player.Inventory = map[string]int{"fur_cloak": 1, "bear_charm": 1}
+	base := CharacterSheet(&state)
+
+	if _, err := Equip(&state, "fur_cloak"); err != nil {
+		t.Fatalf("Equip returned error: %v", err)
+	}
+	events, err := Equip(&state, "bear_charm")
+	if err != nil {
+		t.Fatalf("Equip returned error: %v", err)
+	}
+	if s, ok := events[len(events)-1].(SetBonusActive); !ok || s.SetID != "hunters_garb" {
+		t.Fatalf("expected SetBonusActive for hunters_garb, got %v", events)
+	}
+
+	// bear_charm +1 attack, fur_cloak +1 defense, set +2 attack.
+	sheet := CharacterSheet(&state)
+	if sheet.AttackMin != base.AttackMin+3 || sheet.Defense != base.Defense+1 {
+		t.Fatalf("expected +3 attack, +1 defense; got %+v from %+v", sheet, base)
+	}
+	if len(sheet.Sets) != 1 || sheet.Sets[0] != "hunters_garb" {
+		t.Fatalf("expected hunters_garb active, got %v", sheet.Sets)
+	}
+
+	events, err = Unequip(&state, "armor")
+	if err != nil {
+		t.Fatalf("Unequip returned error: %v", err)
+	}
+	if _, ok := events[len(events)-1].(SetBonusEnded); !ok {
+		t.Fatalf("expected SetBonusEnded, got %v", events)
+	}
+	sheet = CharacterSheet(&state)
+	if sheet.AttackMin != base.AttackMin+1 || len(sheet.Sets) != 0 {
+		t.Fatalf("expected only the charm's +1 attack, got %+v", sheet)
+	}
+	if GetItemCount(&state.Player, "fur_cloak") != 1 {
+		t.Fatalf("expected cloak back in inventory, got %v", state.Player.Inventory)
+	}
+}
+
+func TestEquip_SwapsAndRejectsNonGear(t *testing.T) {
+	state := DefaultState()
+	state.Player.Inventory = map[string]int{"rusty_dagger": 1, "orcish_blade": 1, "torch": 1}
+
+	if _, err := Equip(&state, "torch"); err == nil {
+		t.Fatal("expected error equipping a torch")
+	}
+	if _, err := Equip(&state, "rusty_dagger"); err != nil {
+		t.Fatalf("Equip returned error: %v", err)
+	}
+	if _, err := Equip(&state, "orcish_blade"); err != nil {
+		t.Fatalf("Equip returned error: %v", err)
+	}
+	if state.Player.Equipment[SlotWeapon] != "orcish_blade" || GetItemCount(&state.Player, "rusty_dagger") != 1 {
+		t.Fatalf("expected blade equipped and dagger returned, got %v / %v", state.Player.Equipment, state.Player.Inventory)
+	}
+}
+
+func TestFlagUpgrades_OnlyForStrongerGear(t *testing.T) {
+	state := DefaultState()
+	p := &state.Player
+	p.Equipment = map[string]string{SlotWeapon: "rusty_dagger"}
+	delete(p.Inventory, "rusty_dagger")
+
+	events := FlagUpgrades(p, AddItemWithEvent(p, "orcish_blade", 1, &seqRNG{}))
+	if len(events) != 1 {
+		t.Fatalf("expected one UpgradeAvailable, got %v", events)
+	}
+	up, ok := events[0].(UpgradeAvailable)
+	if !ok || up.ItemID != "orcish_blade" || up.Over != "rusty_dagger" || up.Attack != 2 {
+		t.Fatalf("unexpected upgrade %#v", events[0])
+	}
+
+	p.Equipment[SlotWeapon] = "orcish_greatblade"
+	if events := FlagUpgrades(p, AddItemWithEvent(p, "orcish_blade", 1, &seqRNG{})); len(events) != 0 {
+		t.Fatalf("expected no upgrade for a weaker blade, got %v", events)
+	}
+}
@@ -0,0 +1,42 @@
+package engine
+
+import "errors"
+
+// ================================
+// Action Errors
+// ================================
+
+// Sentinel errors returned by actions, so UIs can react with errors.Is
+// rather than matching messages. ErrPlayerDown and ErrUnknownCommand sit
+// with the code that returns them.
+var (
+	// ErrNotEnoughSP: Hunt or Rest asked for more SP than the player has.
+	ErrNotEnoughSP = errors.New("not enough SP")
+
+	// ErrInvalidAmount: a non-positive amount, e.g. `rest 0`.
+	ErrInvalidAmount = errors.New("invalid SP amount")
+
+	// ErrInvalidItem: an empty item ID.
+	ErrInvalidItem = errors.New("invalid item id")
+
+	// ErrItemNotFound: the item isn't in the inventory.
+	ErrItemNotFound = errors.New("item not in inventory")
+
+	// ErrUnknownItem: the item isn't in the catalog.
+	ErrUnknownItem = errors.New("unknown item")
+
+	// ErrUnknownEnemy: the enemy isn't in the catalog.
+	ErrUnknownEnemy = errors.New("unknown enemy")
+
+	// ErrNoUseEffect: the item can't be used.
+	ErrNoUseEffect = errors.New("item has no use effect")
+
+	// ErrOnCooldown: the item was used too recently.
+	ErrOnCooldown = errors.New("item is on cooldown")
+
+	// ErrCursed: the equipped item is cursed and can't be removed.
+	ErrCursed = errors.New("it's cursed and won't come off; use a remove curse scroll")
+
+	// ErrNoRestInDungeon: Rest or Camp inside a dungeon.
+	ErrNoRestInDungeon = errors.New("you can't rest inside a dungeon; use an item or leave")
+)
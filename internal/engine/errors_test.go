@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestActions_ReturnDocumentedErrors(t *testing.T) {
+	down := DefaultState()
+	down.Player.HP = 0
+
+	tired := DefaultState()
+	tired.Player.SP = 0
+
+	inDungeon := DefaultState()
+	inDungeon.Dungeon = &DungeonRun{ID: "crypt"}
+
+	tests := []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{"explore while down", func() error {
+			s := down
+			_, err := Explore(&s, &seqRNG{})
+			return err
+		}, ErrPlayerDown},
+		{"hunt while down", func() error {
+			s := down
+			_, err := Hunt(&s, 0, &seqRNG{})
+			return err
+		}, ErrPlayerDown},
+		{"hunt without SP", func() error {
+			s := tired
+			_, err := Hunt(&s, 0, &seqRNG{})
+			return err
+		}, ErrNotEnoughSP},
+		{"rest without SP", func() error {
+			s := tired
+			_, err := Rest(&s, 1)
+			return err
+		}, ErrNotEnoughSP},
+		{"rest zero", func() error {
+			s := DefaultState()
+			_, err := Rest(&s, 0)
+			return err
+		}, ErrInvalidAmount},
+		{"rest in dungeon", func() error {
+			s := inDungeon
+			_, err := Rest(&s, 1)
+			return err
+		}, ErrNoRestInDungeon},
+		{"use empty id", func() error {
+			s := DefaultState()
+			_, err := UseItem(&s, "", &seqRNG{})
+			return err
+		}, ErrInvalidItem},
+		{"use missing item", func() error {
+			s := DefaultState()
+			_, err := UseItem(&s, "healing_potion", &seqRNG{})
+			return err
+		}, ErrItemNotFound},
+		{"use item without effect", func() error {
+			s := DefaultState()
+			_, err := UseItem(&s, "torch", &seqRNG{})
+			return err
+		}, ErrNoUseEffect},
+	}
+
+	for _, tc := range tests {
+		if err := tc.run(); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+}
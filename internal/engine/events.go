@@ -1,5 +1,7 @@
 package engine
 
+import "sync"
+
 // ================================
 // Event System
 // ================================
@@ -20,6 +22,10 @@ type DamageDealt struct {
 	Target string // "player" or enemy ID
 	Amount int
 	HPLeft int
+
+	// Overkill is the damage beyond what a killing blow needed; 0 for a
+	// hit that leaves the target standing.
+	Overkill int
 }
 
 func (DamageDealt) EventType() string { return "damage_dealt" }
@@ -57,18 +63,50 @@ type LevelUp struct {
 
 func (LevelUp) EventType() string { return "level_up" }
 
+// LevelsGained stands for a run of consecutive LevelUp events. The engine
+// never emits it; CollapseLevelUps builds it so a UI can show one line.
+type LevelsGained struct {
+	Count    int
+	NewLevel int
+	NewMaxHP int
+}
+
+func (LevelsGained) EventType() string { return "levels_gained" }
+
+// AchievementUnlocked is emitted the first time an achievement's goal is met.
+type AchievementUnlocked struct {
+	ID   string
+	Name string
+}
+
+func (AchievementUnlocked) EventType() string { return "achievement_unlocked" }
+
 // ================================
 // Inventory & Loot Events
 // ================================
 
-// ItemAdded is emitted when an item enters inventory.
+// ItemAdded is emitted when an item enters inventory. Affix is set when
+// one of the copies rolled blessed or cursed.
 type ItemAdded struct {
 	ItemID string
 	Count  int
+	Affix  string
 }
 
 func (ItemAdded) EventType() string { return "item_added" }
 
+// UpgradeAvailable is emitted when an added item beats the gear in its slot.
+// Over is the equipped item ID, "" for an empty slot; Attack and Defense
+// are the stat differences.
+type UpgradeAvailable struct {
+	ItemID  string
+	Over    string
+	Attack  int
+	Defense int
+}
+
+func (UpgradeAvailable) EventType() string { return "upgrade_available" }
+
 // ItemRemoved is emitted when an item is consumed or lost.
 type ItemRemoved struct {
 	ItemID string
@@ -77,6 +115,24 @@ type ItemRemoved struct {
 
 func (ItemRemoved) EventType() string { return "item_removed" }
 
+// InventoryFull is emitted when items are left behind for lack of carry
+// capacity. Dropped is how many of ItemID did not fit.
+type InventoryFull struct {
+	ItemID  string
+	Dropped int
+}
+
+func (InventoryFull) EventType() string { return "inventory_full" }
+
+// ItemCrafted is emitted when a recipe produces its output.
+type ItemCrafted struct {
+	RecipeID string
+	ItemID   string
+	Count    int
+}
+
+func (ItemCrafted) EventType() string { return "item_crafted" }
+
 // LootFound is emitted after combat or exploration.
 type LootFound struct {
 	Items []string
@@ -95,6 +151,37 @@ type GoldGained struct {
 
 func (GoldGained) EventType() string { return "gold_gained" }
 
+// GoldSpent is emitted when gold is paid.
+type GoldSpent struct {
+	Amount int
+}
+
+func (GoldSpent) EventType() string { return "gold_spent" }
+
+// GoldBanked is emitted when gold is deposited. Banked is the new balance.
+type GoldBanked struct {
+	Amount int
+	Banked int
+}
+
+func (GoldBanked) EventType() string { return "gold_banked" }
+
+// GoldWithdrawn is emitted when banked gold is taken back out.
+type GoldWithdrawn struct {
+	Amount int
+	Banked int
+}
+
+func (GoldWithdrawn) EventType() string { return "gold_withdrawn" }
+
+// InterestAccrued is emitted when banked gold earns interest.
+type InterestAccrued struct {
+	Amount int
+	Banked int
+}
+
+func (InterestAccrued) EventType() string { return "interest_accrued" }
+
 // SPSpent is emitted when SP is consumed.
 type SPSpent struct {
 	Amount int
@@ -102,6 +189,128 @@ type SPSpent struct {
 
 func (SPSpent) EventType() string { return "sp_spent" }
 
+// ItemEquipped is emitted when an item is put on.
+type ItemEquipped struct {
+	ItemID string
+	Slot   string
+}
+
+func (ItemEquipped) EventType() string { return "item_equipped" }
+
+// ItemUnequipped is emitted when an item is taken off and returned to the
+// inventory.
+type ItemUnequipped struct {
+	ItemID string
+	Slot   string
+}
+
+func (ItemUnequipped) EventType() string { return "item_unequipped" }
+
+// PriceQuoted is emitted by `sell price`: what Qty of ItemID would fetch.
+type PriceQuoted struct {
+	ItemID string
+	Qty    int
+	Price  int
+}
+
+func (PriceQuoted) EventType() string { return "price_quoted" }
+
+// Haggled is emitted by `sell haggle`. Price is what the merchant paid
+// against the Quote, unless WalkedAway, in which case nothing was sold.
+type Haggled struct {
+	ItemID     string
+	Qty        int
+	Quote      int
+	Price      int
+	WalkedAway bool
+}
+
+func (Haggled) EventType() string { return "haggled" }
+
+// RepeatStopped is emitted when a repeated command, like `explore 5`,
+// stops after Done of its Times runs.
+type RepeatStopped struct {
+	Command string
+	Done    int
+	Times   int
+	Reason  string
+}
+
+func (RepeatStopped) EventType() string { return "repeat_stopped" }
+
+// Hint is a tutorial tip for a new player; see GiveHints.
+type Hint struct {
+	ID   string
+	Text string
+}
+
+func (Hint) EventType() string { return "hint" }
+
+// Stolen is emitted when an enemy's strike lifts Gold or one ItemID from
+// the player.
+type Stolen struct {
+	EnemyID string
+	Gold    int
+	ItemID  string
+}
+
+func (Stolen) EventType() string { return "stolen" }
+
+// StolenRecovered is emitted on a win, returning everything stolen during
+// the fight.
+type StolenRecovered struct {
+	Gold  int
+	Items []string
+}
+
+func (StolenRecovered) EventType() string { return "stolen_recovered" }
+
+// CurseLifted is emitted when a remove curse scroll frees the item in Slot.
+type CurseLifted struct {
+	ItemID string
+	Slot   string
+}
+
+func (CurseLifted) EventType() string { return "curse_lifted" }
+
+// SetBonusActive is emitted when an equip completes an item set.
+type SetBonusActive struct {
+	SetID string
+}
+
+func (SetBonusActive) EventType() string { return "set_bonus_active" }
+
+// SetBonusEnded is emitted when an unequip breaks an item set.
+type SetBonusEnded struct {
+	SetID string
+}
+
+func (SetBonusEnded) EventType() string { return "set_bonus_ended" }
+
+// BuffGained is emitted when a temporary buff is applied.
+type BuffGained struct {
+	Stat       string
+	Amount     int
+	Encounters int
+}
+
+func (BuffGained) EventType() string { return "buff_gained" }
+
+// BuffExpired is emitted when a buff runs out of encounters.
+type BuffExpired struct {
+	Stat   string
+	Amount int
+}
+
+func (BuffExpired) EventType() string { return "buff_expired" }
+
+// SPRegained is emitted when SP regenerates passively.
+type SPRegained struct {
+	Amount int
+}
+
+func (SPRegained) EventType() string { return "sp_regained" }
+
 // HPRestored is emitted when HP is restored.
 type HPRestored struct {
 	Amount int
@@ -113,23 +322,423 @@ func (HPRestored) EventType() string { return "hp_restored" }
 // World / Flow Events
 // ================================
 
-// EncounterStarted signals an enemy encounter.
+// EncounterStarted signals an enemy encounter. Name is set for a variant,
+// e.g. "Scarred Goblin", and empty for a plain enemy.
 type EncounterStarted struct {
 	EnemyID string
+	Name    string
 }
 
 func (EncounterStarted) EventType() string { return "encounter_started" }
 
+// ItemWorn is emitted when a fight costs equipped gear a durability point.
+// At 0 the item is broken.
+type ItemWorn struct {
+	ItemID        string
+	Durability    int
+	MaxDurability int
+}
+
+func (ItemWorn) EventType() string { return "item_worn" }
+
+// ItemRepaired is emitted when `repair` restores an item.
+type ItemRepaired struct {
+	ItemID     string
+	Durability int
+}
+
+func (ItemRepaired) EventType() string { return "item_repaired" }
+
+// StatTrained is emitted when `train` raises a stat. Times counts the
+// trainings of that stat so far.
+type StatTrained struct {
+	Stat   string
+	Amount int
+	Times  int
+}
+
+func (StatTrained) EventType() string { return "stat_trained" }
+
+// Target is a foe the player may attack, numbered from 1.
+type Target struct {
+	Index   int
+	EnemyID string
+	HP      int
+}
+
+// TurnStarted opens a round of a targeted battle, listing the foes still
+// standing.
+type TurnStarted struct {
+	Turn    int
+	Targets []Target
+}
+
+func (TurnStarted) EventType() string { return "turn_started" }
+
+// EncounterAvoided is emitted when explore backs away from an enemy too
+// strong for the player; see RetreatXPPerLevel.
+type EncounterAvoided struct {
+	EnemyID string
+}
+
+func (EncounterAvoided) EventType() string { return "encounter_avoided" }
+
+// EncounterEnded is emitted when the player leaves an encounter without
+// winning or losing it. DungeonID is empty when the encounter was a staged
+// battle.
+type EncounterEnded struct {
+	DungeonID string
+	Reason    string
+}
+
+func (EncounterEnded) EventType() string { return "encounter_ended" }
+
+// Prestiged is emitted when the player prestiges. XPPercent is the new
+// XP multiplier.
+type Prestiged struct {
+	Prestige  int
+	XPPercent int
+}
+
+func (Prestiged) EventType() string { return "prestiged" }
+
+// CombatStalemate is emitted when a fight hits MaxCombatTurns undecided.
+type CombatStalemate struct {
+	EnemyID string
+	Turns   int
+}
+
+func (CombatStalemate) EventType() string { return "combat_stalemate" }
+
+// CombatTotals stands for a fight's DamageDealt events. The engine never
+// emits it; SummarizeCombat builds it for the summary verbosity.
+type CombatTotals struct {
+	Dealt int
+	Taken int
+	Hits  int
+}
+
+func (CombatTotals) EventType() string { return "combat_totals" }
+
+// WorldChanged is emitted when the world modifier rotates.
+type WorldChanged struct {
+	From string
+	To   string
+}
+
+func (WorldChanged) EventType() string { return "world_changed" }
+
+// TimeChanged is emitted when night falls or day breaks.
+type TimeChanged struct {
+	From string
+	To   string
+}
+
+func (TimeChanged) EventType() string { return "time_changed" }
+
+// DungeonEntered is emitted when a dungeon run starts.
+type DungeonEntered struct {
+	DungeonID string
+	Stages    int
+}
+
+func (DungeonEntered) EventType() string { return "dungeon_entered" }
+
+// DungeonCleared is emitted when the last dungeon fight is won.
+type DungeonCleared struct {
+	DungeonID string
+}
+
+func (DungeonCleared) EventType() string { return "dungeon_cleared" }
+
+// DungeonFailed is emitted when a run ends in defeat or is abandoned.
+type DungeonFailed struct {
+	DungeonID string
+}
+
+func (DungeonFailed) EventType() string { return "dungeon_failed" }
+
+// ChoiceOffered is emitted when a treasure waits for the player to pick
+// what to take.
+type ChoiceOffered struct {
+	Gold    int
+	Item    string
+	Options []string
+}
+
+func (ChoiceOffered) EventType() string { return "choice_offered" }
+
+// MerchantOffered is emitted when a caravan waits to trade: Item for
+// Price, and Offer gold for one Wants ("" if it wants nothing).
+type MerchantOffered struct {
+	Item    string
+	Price   int
+	Wants   string
+	Offer   int
+	Options []string
+}
+
+func (MerchantOffered) EventType() string { return "merchant_offered" }
+
+// MerchantLeft is emitted when the player waves a caravan off.
+type MerchantLeft struct{}
+
+func (MerchantLeft) EventType() string { return "merchant_left" }
+
 // ExplorationResult is emitted for non-combat explore outcomes.
 type ExplorationResult struct {
-	Kind string // "nothing", "gold", "item", "treasure"
+	Kind string // "nothing", "gold", "item", "treasure", "merchant"
 }
 
 func (ExplorationResult) EventType() string { return "exploration_result" }
 
+// ================================
+// Observers
+// ================================
+
+// Observer is notified of each event as the engine emits it, in the same
+// order the events are returned to the caller.
+type Observer func(Event)
+
+type subscription struct {
+	id int
+	fn Observer
+}
+
+// observersMu guards the registry below, so subscribing, emitting and
+// muting are safe from any goroutine. Observers themselves run outside it
+// and may subscribe or unsubscribe.
+var observersMu sync.Mutex
+
+var (
+	observers []subscription
+	nextSubID int
+
+	// muted silences emit while positive; see mute.
+	muted int
+)
+
+// mute stops emit from notifying observers until the returned function is
+// called. What-if runs like Simulate and RunArena use it so their fights
+// never reach the action log or analytics.
+func mute() (unmute func()) {
+	observersMu.Lock()
+	muted++
+	observersMu.Unlock()
+	return func() {
+		observersMu.Lock()
+		muted--
+		observersMu.Unlock()
+	}
+}
+
+// Subscribe registers fn for every emitted event and returns a function
+// that removes it again. Observers run synchronously inside engine calls.
+func Subscribe(fn Observer) (unsubscribe func()) {
+	observersMu.Lock()
+	defer observersMu.Unlock()
+	nextSubID++
+	id := nextSubID
+	observers = append(observers, subscription{id: id, fn: fn})
+	return func() {
+		observersMu.Lock()
+		defer observersMu.Unlock()
+		for i, sub := range observers {
+			if sub.id == id {
+				observers = append(observers[:i:i], observers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// CommandObserver is notified after each command RunCommand recognizes,
+// with the state it left behind and the error, if any.
+type CommandObserver func(line string, state *State, err error)
+
+type commandSubscription struct {
+	id int
+	fn CommandObserver
+}
+
+var commandObservers []commandSubscription
+
+// SubscribeCommands registers fn to run after every recognized command and
+// returns a function that removes it again. Paired with Subscribe, it lets
+// an observer group events by the command that produced them.
+func SubscribeCommands(fn CommandObserver) (unsubscribe func()) {
+	observersMu.Lock()
+	defer observersMu.Unlock()
+	nextSubID++
+	id := nextSubID
+	commandObservers = append(commandObservers, commandSubscription{id: id, fn: fn})
+	return func() {
+		observersMu.Lock()
+		defer observersMu.Unlock()
+		for i, sub := range commandObservers {
+			if sub.id == id {
+				commandObservers = append(commandObservers[:i:i], commandObservers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func notifyCommand(line string, state *State, err error) {
+	observersMu.Lock()
+	subs := commandObservers
+	observersMu.Unlock()
+	for _, sub := range subs {
+		sub.fn(line, state, err)
+	}
+}
+
+// emit appends evs to events and notifies observers. Actions build their
+// events through it; events forwarded from a helper that already emitted
+// them are appended directly so observers see each event once.
+func emit(events Events, evs ...Event) Events {
+	observersMu.Lock()
+	subs := observers
+	if muted > 0 {
+		subs = nil
+	}
+	observersMu.Unlock()
+	for _, e := range evs {
+		for _, sub := range subs {
+			sub.fn(e)
+		}
+	}
+	return append(events, evs...)
+}
+
 // ================================
 // Utility
 // ================================
 
 // Events is a convenience alias.
 type Events []Event
+
+// CollapseLoot drops the per-item ItemAdded events already summarized by a
+// following LootFound, so a UI can render a single loot line per drop.
+// Affixed copies keep their ItemAdded, since the summary can't show it.
+func CollapseLoot(events Events) Events {
+	pending := map[string]int{}
+	out := make(Events, 0, len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		switch ev := events[i].(type) {
+		case LootFound:
+			for _, it := range ev.Items {
+				pending[NormalizeItemID(it)]++
+			}
+		case ItemAdded:
+			id := NormalizeItemID(ev.ItemID)
+			if pending[id] > 0 {
+				pending[id]--
+				if ev.Affix == "" {
+					continue
+				}
+			}
+		}
+		out = append(out, events[i])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// CollapseLevelUps replaces each run of two or more consecutive LevelUp
+// events with one LevelsGained carrying the final level and max HP. A lone
+// LevelUp is kept as is.
+func CollapseLevelUps(events Events) Events {
+	out := make(Events, 0, len(events))
+	for i := 0; i < len(events); {
+		first, ok := events[i].(LevelUp)
+		if !ok {
+			out = append(out, events[i])
+			i++
+			continue
+		}
+		last, n := first, 1
+		for i+n < len(events) {
+			next, ok := events[i+n].(LevelUp)
+			if !ok {
+				break
+			}
+			last = next
+			n++
+		}
+		if n == 1 {
+			out = append(out, first)
+		} else {
+			out = append(out, LevelsGained{Count: n, NewLevel: last.NewLevel, NewMaxHP: last.NewMaxHP})
+		}
+		i += n
+	}
+	return out
+}
+
+// CombatVerbosity selects how UIs show a fight.
+type CombatVerbosity string
+
+const (
+	CombatVerbose CombatVerbosity = "verbose" // every hit
+	CombatSummary CombatVerbosity = "summary" // start, outcome and totals
+)
+
+// SummarizeCombat drops the DamageDealt events of each fight, from its
+// EncounterStarted events to the PlayerDefeated, CombatStalemate or last
+// EnemyDefeated that ends it, and puts one CombatTotals before that ending.
+// Hits outside such a fight, like a targeted battle's rounds, are kept.
+func SummarizeCombat(events Events) Events {
+	out := make(Events, 0, len(events))
+	var (
+		held         Events
+		totals       CombatTotals
+		foes, felled int
+	)
+	for _, e := range events {
+		switch ev := e.(type) {
+		case EncounterStarted:
+			if len(held) == 0 && felled == 0 {
+				foes++
+			}
+			out = append(out, e)
+			continue
+		case DamageDealt:
+			if foes == 0 {
+				break
+			}
+			held = append(held, e)
+			totals.Hits++
+			if ev.Target == "player" {
+				totals.Taken += ev.Amount
+			} else {
+				totals.Dealt += ev.Amount
+			}
+			continue
+		case EnemyDefeated:
+			if foes == 0 {
+				break
+			}
+			felled++
+			if felled < foes {
+				out = append(out, e)
+				continue
+			}
+			out = append(out, totals, e)
+			held, totals, foes, felled = nil, CombatTotals{}, 0, 0
+			continue
+		case PlayerDefeated, CombatStalemate:
+			if foes == 0 {
+				break
+			}
+			out = append(out, totals, e)
+			held, totals, foes, felled = nil, CombatTotals{}, 0, 0
+			continue
+		}
+		out = append(out, e)
+	}
+	// A fight that never ended keeps its hits.
+	return append(out, held...)
+}
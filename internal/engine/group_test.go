@@ -0,0 +1,70 @@
+package engine
+
+import "testing"
+
+func TestResolveGroupCombat_ClearsWolfPack(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 10 // 11–12 damage: each wolf takes two hits
+
+	wolf := Enemies["wolf"]
+	rng := &seqRNG{
+		// turn 1: hit, both wolves bite; turn 2: kill (gold +3), wolf 2 bites;
+		// turn 3: hit, wolf 2 bites; turn 4: kill (gold +1).
+		ints: []int{0, 0, 0, 0, 3, 0, 0, 0, 0, 1},
+		// wolf 1 drops a pelt only; wolf 2 drops meat only.
+		floats: []float64{0.1, 0.9, 0.9, 0.1},
+	}
+	result, events := ResolveGroupCombat(&state, []EnemyTemplate{wolf, wolf}, rng)
+
+	if result.Outcome != "win" {
+		t.Fatalf("expected win, got %q", result.Outcome)
+	}
+	if result.XP != 2*wolf.XP {
+		t.Fatalf("expected %d XP, got %d", 2*wolf.XP, result.XP)
+	}
+	if want := 2*wolf.Gold + 4; result.Gold != want {
+		t.Fatalf("expected %d gold, got %d", want, result.Gold)
+	}
+	if len(result.Loot) != 2 || result.Loot[0] != "wolf_pelt" || result.Loot[1] != "meat" {
+		t.Fatalf("expected pelt and meat, got %v", result.Loot)
+	}
+	// Four bites of 3 damage each.
+	if state.Player.HP != DefaultMaxHP-12 {
+		t.Fatalf("expected %d HP, got %d", DefaultMaxHP-12, state.Player.HP)
+	}
+
+	started, defeated := 0, 0
+	for _, ev := range events {
+		switch ev.(type) {
+		case EncounterStarted:
+			started++
+		case EnemyDefeated:
+			defeated++
+		}
+	}
+	if started != 2 || defeated != 2 {
+		t.Fatalf("expected 2 starts and 2 defeats, got %d and %d", started, defeated)
+	}
+}
+
+func TestChooseEncounter_FormsPacks(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = PackMinLevel
+
+	// weights at level 3: goblin 20, skeleton 15, bandit 15, wolf 5 → roll 50 is a wolf.
+	pack := ChooseEncounter(&state, 0, &seqRNG{ints: []int{50, 1}, floats: []float64{0}})
+	if len(pack) != 3 || pack[0].ID != "wolf" {
+		t.Fatalf("expected a pack of 3 wolves, got %v", pack)
+	}
+
+	alone := ChooseEncounter(&state, 0, &seqRNG{ints: []int{50}, floats: []float64{0.99}})
+	if len(alone) != 1 {
+		t.Fatalf("expected a lone wolf, got %d enemies", len(alone))
+	}
+
+	// below level 3 weights are 25, 20, 15, 10, ... → roll 60 is a wolf.
+	state.Player.Level = PackMinLevel - 1
+	if low := ChooseEncounter(&state, 0, &seqRNG{ints: []int{60}}); len(low) != 1 || low[0].ID != "wolf" {
+		t.Fatalf("expected no packs below level %d, got %d enemies", PackMinLevel, len(low))
+	}
+}
@@ -0,0 +1,95 @@
+package engine
+
+// ================================
+// Tutorial Hints
+// ================================
+
+// Tutorial shows contextual hints for new players after commands; see
+// GiveHints.
+var Tutorial = true
+
+// HintCooldown is how many commands pass before a hint the player hasn't
+// acted on is shown again.
+var HintCooldown = 20
+
+// Hint IDs.
+const (
+	HintHeal    = "heal"
+	HintCamp    = "camp"
+	HintExplore = "explore"
+)
+
+// hintLearned marks a hint in Meta.Hints whose mechanic the player has
+// used; it is never shown again.
+const hintLearned = -1
+
+// hintRule is one contextual hint: shown while applies holds, retired once
+// the player runs command.
+type hintRule struct {
+	id      string
+	command string
+	text    string
+	applies func(state *State) bool
+}
+
+// hintRules are checked in order; at most one hint is shown per command.
+var hintRules = []hintRule{
+	{
+		id:      HintHeal,
+		command: "use",
+		text:    "You're badly hurt. `use healing_potion` restores HP.",
+		applies: func(state *State) bool {
+			p := &state.Player
+			return p.IsAlive() && p.HP*3 <= p.MaxHP && HasItem(p, "healing_potion", 1)
+		},
+	},
+	{
+		id:      HintCamp,
+		command: "camp",
+		text:    "Out of SP to hunt. `camp` restores some, and SP comes back as you explore.",
+		applies: func(state *State) bool {
+			return state.Player.SP < HuntBaseSP
+		},
+	},
+	{
+		id:      HintExplore,
+		command: "explore",
+		text:    "`explore` searches the area for loot, merchants and enemies.",
+		applies: func(state *State) bool {
+			return state.Meta.CommandTicks >= 3
+		},
+	},
+}
+
+// GiveHints runs after each successful command. Running a hint's command,
+// or an alias of it, retires that hint for good; otherwise the first hint that applies, and
+// hasn't been shown in the last HintCooldown commands, is emitted.
+func GiveHints(state *State, cmd string) Events {
+	if !Tutorial {
+		return nil
+	}
+	cmd = canonicalCommand(cmd)
+	for _, r := range hintRules {
+		if r.command == cmd {
+			setHint(state, r.id, hintLearned)
+		}
+	}
+	for _, r := range hintRules {
+		last, shown := state.Meta.Hints[r.id]
+		if last == hintLearned || (shown && state.Meta.CommandTicks-last < HintCooldown) {
+			continue
+		}
+		if r.applies(state) {
+			setHint(state, r.id, state.Meta.CommandTicks)
+			return emit(nil, Hint{ID: r.id, Text: r.text})
+		}
+	}
+	return nil
+}
+
+func setHint(state *State, id string, tick int) {
+	if state.Meta.Hints == nil {
+		state.Meta.Hints = map[string]int{}
+	}
+	state.Meta.Hints[id] = tick
+}
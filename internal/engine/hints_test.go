@@ -0,0 +1,71 @@
+package engine
+
+import "testing"
+
+func hintsIn(events Events) []Hint {
+	var hints []Hint
+	for _, e := range events {
+		if h, ok := e.(Hint); ok {
+			hints = append(hints, h)
+		}
+	}
+	return hints
+}
+
+func TestGiveHints_LowHPWithPotionOnceUntilCooldown(t *testing.T) {
+	state := DefaultState()
+	state.Player.HP = 20
+	state.Player.Inventory["healing_potion"] = 1
+
+	hints := hintsIn(GiveHints(&state, "status"))
+	if len(hints) != 1 || hints[0].ID != HintHeal {
+		t.Fatalf("expected the heal hint, got %+v", hints)
+	}
+
+	state.Meta.CommandTicks++
+	if hints := hintsIn(GiveHints(&state, "status")); len(hints) != 0 {
+		t.Fatalf("expected no repeat right after the hint, got %+v", hints)
+	}
+
+	state.Meta.CommandTicks += HintCooldown
+	if hints := hintsIn(GiveHints(&state, "status")); len(hints) != 1 || hints[0].ID != HintHeal {
+		t.Fatalf("expected the heal hint again after the cooldown, got %+v", hints)
+	}
+}
+
+func TestGiveHints_RetiredOnceUsed(t *testing.T) {
+	state := DefaultState()
+	state.Player.HP = 20
+	state.Player.Inventory["healing_potion"] = 2
+
+	if _, err := RunCommand(&state, "use healing_potion", &seqRNG{}); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+	state.Player.HP = 20
+	state.Meta.CommandTicks += HintCooldown
+	for _, h := range hintsIn(GiveHints(&state, "status")) {
+		if h.ID == HintHeal {
+			t.Fatal("expected the heal hint retired after using a potion")
+		}
+	}
+}
+
+func TestGiveHints_AliasRetiresHint(t *testing.T) {
+	state := DefaultState()
+	GiveHints(&state, "wait")
+	if state.Meta.Hints[HintCamp] != hintLearned {
+		t.Fatalf("expected `wait` to retire the camp hint, got %v", state.Meta.Hints)
+	}
+}
+
+func TestGiveHints_OffWithoutTutorial(t *testing.T) {
+	old := Tutorial
+	Tutorial = false
+	defer func() { Tutorial = old }()
+
+	state := DefaultState()
+	state.Player.SP = 0
+	if events := GiveHints(&state, "status"); len(events) != 0 {
+		t.Fatalf("expected no hints with the tutorial off, got %+v", events)
+	}
+}
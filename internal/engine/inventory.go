@@ -1,6 +1,9 @@
 package engine
 
-import "strings"
+import (
+	"sort"
+	"strings"
+)
 
 // ================================
 // Inventory Helpers (Pure)
@@ -40,9 +43,11 @@ func RemoveItem(p *Player, itemID string, qty int) {
 	have := p.Inventory[itemID]
 	if have <= qty {
 		delete(p.Inventory, itemID)
-		return
+	} else {
+		p.Inventory[itemID] = have - qty
 	}
-	p.Inventory[itemID] = have - qty
+	trimAffixes(p, itemID)
+	dropWear(p, itemID)
 }
 
 // HasItem returns true if the player has at least qty of itemID.
@@ -57,8 +62,9 @@ func HasItem(p *Player, itemID string, qty int) bool {
 }
 
 // NormalizeItemID converts user/save/catalog IDs to a canonical lower_snake_case key.
+// Non-printable runes, such as a pasted zero-width space, are dropped.
 func NormalizeItemID(itemID string) string {
-	parts := strings.Fields(strings.ToLower(strings.ReplaceAll(itemID, "-", " ")))
+	parts := strings.Fields(strings.ToLower(strings.ReplaceAll(SanitizeInput(itemID), "-", " ")))
 	if len(parts) == 0 {
 		return ""
 	}
@@ -86,22 +92,132 @@ func NormalizeInventory(inventory map[string]int) map[string]int {
 	return normalized
 }
 
+// ================================
+// Inventory Listing
+// ================================
+
+// InventoryEntry is one line of an inventory listing. Slot is set for an
+// equipped item, which is out of the inventory proper.
+type InventoryEntry struct {
+	ID    string
+	Name  string
+	Count int
+	Rare  bool
+	Slot  string
+}
+
+// InventoryList lists carried stacks by ID, then equipped items by slot.
+// A non-empty filter keeps only entries whose ID or name contains it,
+// case-insensitively.
+func InventoryList(p *Player, filter string) []InventoryEntry {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	keep := func(e InventoryEntry) bool {
+		return filter == "" ||
+			strings.Contains(e.ID, NormalizeItemID(filter)) ||
+			strings.Contains(strings.ToLower(e.Name), filter)
+	}
+	entry := func(id string) InventoryEntry {
+		e := InventoryEntry{ID: id, Name: id}
+		if it, ok := ItemByID(id); ok {
+			e.Name, e.Rare = it.Name, it.Rare
+		}
+		return e
+	}
+
+	ids := make([]string, 0, len(p.Inventory))
+	for id := range p.Inventory {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var out []InventoryEntry
+	for _, id := range ids {
+		e := entry(id)
+		e.Count = p.Inventory[id]
+		if keep(e) {
+			out = append(out, e)
+		}
+	}
+	for _, slot := range []string{SlotWeapon, SlotArmor, SlotTrinket} {
+		id, ok := p.Equipment[slot]
+		if !ok {
+			continue
+		}
+		e := entry(id)
+		e.Count, e.Slot = 1, slot
+		if keep(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// ================================
+// Carry Weight
+// ================================
+
+// ItemWeight returns the weight of one item; unknown or unweighted items
+// weigh 1.
+func ItemWeight(itemID string) int {
+	if w := Items[NormalizeItemID(itemID)].Weight; w > 0 {
+		return w
+	}
+	return 1
+}
+
+// CarryWeight returns the total weight of the player's inventory.
+func CarryWeight(p *Player) int {
+	total := 0
+	for id, qty := range p.Inventory {
+		total += ItemWeight(id) * qty
+	}
+	return total
+}
+
+// CarryRoom returns how many more of itemID the player can carry, or qty
+// when there is no limit.
+func CarryRoom(p *Player, itemID string, qty int) int {
+	if p.MaxCarryWeight <= 0 {
+		return qty
+	}
+	free := p.MaxCarryWeight - CarryWeight(p)
+	return min(qty, max(0, free/ItemWeight(itemID)))
+}
+
+// AddItemChecked adds as many of qty as fit under MaxCarryWeight and
+// returns the number added.
+func AddItemChecked(p *Player, itemID string, qty int) int {
+	if qty <= 0 {
+		return 0
+	}
+	n := CarryRoom(p, itemID, qty)
+	AddItem(p, itemID, n)
+	return n
+}
+
 // ================================
 // Inventory + Events (Optional Helpers)
 // ================================
 
-// AddItemWithEvent adds items and emits ItemAdded.
-func AddItemWithEvent(p *Player, itemID string, qty int) Events {
+// AddItemWithEvent adds items and emits ItemAdded, or InventoryFull for
+// whatever exceeds the carry limit. rng rolls the added copies' affix.
+func AddItemWithEvent(p *Player, itemID string, qty int, rng RNG) Events {
 	if qty <= 0 {
 		return nil
 	}
-	AddItem(p, itemID, qty)
-	return Events{
-		ItemAdded{
+	var events Events
+	added := AddItemChecked(p, itemID, qty)
+	if added > 0 {
+		events = emit(events, ItemAdded{
 			ItemID: itemID,
-			Count:  qty,
-		},
+			Count:  added,
+			Affix:  rollAffix(p, itemID, rng),
+		})
+	}
+	if added < qty {
+		events = emit(events, InventoryFull{ItemID: itemID, Dropped: qty - added})
 	}
+	return events
 }
 
 // RemoveItemWithEvent removes items and emits ItemRemoved.
@@ -110,10 +226,32 @@ func RemoveItemWithEvent(p *Player, itemID string, qty int) Events {
 		return nil
 	}
 	RemoveItem(p, itemID, qty)
-	return Events{
-		ItemRemoved{
-			ItemID: itemID,
-			Count:  qty,
-		},
+	return emit(nil, ItemRemoved{
+		ItemID: itemID,
+		Count:  qty,
+	})
+}
+
+// GrantLoot adds each dropped item to the inventory, emitting one ItemAdded
+// per item followed by a single LootFound summarizing the whole drop. Items
+// that exceed the carry limit are left behind with an InventoryFull. rng
+// rolls each item's affix.
+func GrantLoot(state *State, items []string, rng RNG) Events {
+	if len(items) == 0 {
+		return nil
+	}
+	events := Events{}
+	kept := make([]string, 0, len(items))
+	for _, it := range items {
+		if AddItemChecked(state.PlayerPtr(), it, 1) == 0 {
+			events = emit(events, InventoryFull{ItemID: it, Dropped: 1})
+			continue
+		}
+		kept = append(kept, it)
+		events = emit(events, ItemAdded{ItemID: it, Count: 1, Affix: rollAffix(state.PlayerPtr(), it, rng)})
+	}
+	if len(kept) > 0 {
+		events = emit(events, LootFound{Items: kept})
 	}
+	return events
 }
@@ -1,6 +1,10 @@
 package engine
 
-import "testing"
+import (
+	"errors"
+	"strings"
+	"testing"
+)
 
 type fixedRNG struct {
 	ints []int
@@ -108,6 +112,46 @@ func TestUseItem_MeatRestoresFixedHPAndSP(t *testing.T) {
 	}
 }
 
+func TestUseItem_SPClampedToMaxSP(t *testing.T) {
+	state := DefaultState()
+	state.Player.SP = state.Player.MaxSP - 1
+	AddItem(&state.Player, "meat", 2)
+
+	for i := 0; i < 2; i++ {
+		if _, err := UseItem(&state, "meat", &fixedRNG{ints: []int{0, 0}}); err != nil {
+			t.Fatalf("UseItem returned error: %v", err)
+		}
+		if state.Player.SP != state.Player.MaxSP {
+			t.Fatalf("expected SP clamped to %d, got %d", state.Player.MaxSP, state.Player.SP)
+		}
+	}
+}
+
+func TestUseItem_DispatchesOnEffectKind(t *testing.T) {
+	Items["test_tonic"] = Item{ID: "test_tonic", Name: "Test Tonic", Effects: []Effect{
+		{Kind: EffectRestoreSP, Min: 4, Max: 4},
+	}}
+	defer delete(Items, "test_tonic")
+
+	state := DefaultState()
+	state.Player.HP = 50
+	state.Player.SP = 2
+	AddItem(&state.Player, "test_tonic", 1)
+
+	events, err := UseItem(&state, "test_tonic", &fixedRNG{ints: []int{0}})
+	if err != nil {
+		t.Fatalf("UseItem returned error: %v", err)
+	}
+	if state.Player.SP != 6 || state.Player.HP != 50 {
+		t.Fatalf("expected SP-only effect (SP 6, HP 50), got SP %d HP %d", state.Player.SP, state.Player.HP)
+	}
+	for _, ev := range events {
+		if _, ok := ev.(HPRestored); ok {
+			t.Fatalf("unexpected HPRestored for an SP-only item")
+		}
+	}
+}
+
 func TestUseItem_NoEffectItemReturnsError(t *testing.T) {
 	state := DefaultState()
 	AddItem(&state.Player, "torch", 1)
@@ -117,3 +161,116 @@ func TestUseItem_NoEffectItemReturnsError(t *testing.T) {
 		t.Fatalf("expected no-use-effect error for torch")
 	}
 }
+
+func TestAddItemWithEvent_RejectsOverflowPastCapacity(t *testing.T) {
+	p := Player{MaxCarryWeight: 5, Inventory: map[string]int{"torch": 1}} // weight 1
+
+	// bone_shield weighs 3: one fits (4/5), the second would make 7.
+	events := AddItemWithEvent(&p, "bone_shield", 2, &seqRNG{})
+	if got := GetItemCount(&p, "bone_shield"); got != 1 {
+		t.Fatalf("expected 1 bone_shield, got %d", got)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected ItemAdded and InventoryFull, got %v", events)
+	}
+	if full, ok := events[1].(InventoryFull); !ok || full.Dropped != 1 {
+		t.Fatalf("expected 1 dropped, got %v", events[1])
+	}
+
+	events = GrantLoot(&State{Player: p}, []string{"bone_shield"}, &seqRNG{})
+	if len(events) != 1 {
+		t.Fatalf("expected only InventoryFull, got %v", events)
+	}
+	if _, ok := events[0].(InventoryFull); !ok {
+		t.Fatalf("expected InventoryFull, got %v", events[0])
+	}
+}
+
+func TestAddItemChecked_NoLimitAcceptsAll(t *testing.T) {
+	p := Player{}
+	if n := AddItemChecked(&p, "orcish_blade", 50); n != 50 {
+		t.Fatalf("expected all 50 added without a limit, got %d", n)
+	}
+	if w := CarryWeight(&p); w != 200 {
+		t.Fatalf("expected weight 200, got %d", w)
+	}
+}
+
+func TestCatalogAccessors_SortedAndComplete(t *testing.T) {
+	items := AllItems()
+	if len(items) != len(Items) {
+		t.Fatalf("AllItems returned %d items, want %d", len(items), len(Items))
+	}
+	for i := 1; i < len(items); i++ {
+		if items[i-1].ID >= items[i].ID {
+			t.Fatalf("AllItems not sorted at %d: %q >= %q", i, items[i-1].ID, items[i].ID)
+		}
+	}
+	enemies := AllEnemies()
+	if len(enemies) != len(Enemies) {
+		t.Fatalf("AllEnemies returned %d enemies, want %d", len(enemies), len(Enemies))
+	}
+	for i := 1; i < len(enemies); i++ {
+		if enemies[i-1].ID >= enemies[i].ID {
+			t.Fatalf("AllEnemies not sorted at %d: %q >= %q", i, enemies[i-1].ID, enemies[i].ID)
+		}
+	}
+	if it, ok := ItemByID("healing_potion"); !ok || it.ID != "healing_potion" {
+		t.Fatalf("ItemByID(healing_potion) = %+v, %v", it, ok)
+	}
+	if _, ok := ItemByID("no_such_item"); ok {
+		t.Fatal("ItemByID should miss unknown items")
+	}
+	if e, ok := EnemyByID("wolf"); !ok || e.ID != "wolf" {
+		t.Fatalf("EnemyByID(wolf) = %+v, %v", e, ok)
+	}
+	if _, ok := EnemyByID("no_such_enemy"); ok {
+		t.Fatal("EnemyByID should miss unknown enemies")
+	}
+}
+
+func TestUseItem_RespectsCooldown(t *testing.T) {
+	Items["test_tonic"] = Item{
+		ID:               "test_tonic",
+		Name:             "Test Tonic",
+		Effects:          []Effect{{Kind: EffectRestoreSP, Min: 1, Max: 1}},
+		CooldownCommands: 2,
+	}
+	defer delete(Items, "test_tonic")
+	oldRegen := SPRegenInterval
+	SPRegenInterval = 0
+	defer func() { SPRegenInterval = oldRegen }()
+
+	state := DefaultState()
+	state.Player.SP = 0
+	AddItem(&state.Player, "test_tonic", 3)
+	// High float draws keep camp from being ambushed.
+	rng := &seqRNG{floats: []float64{0.99, 0.99}}
+
+	if _, err := RunCommand(&state, "use test_tonic", rng); err != nil {
+		t.Fatalf("first use failed: %v", err)
+	}
+	_, err := RunCommand(&state, "use test_tonic", rng)
+	if !errors.Is(err, ErrOnCooldown) || !strings.Contains(err.Error(), "2 commands remaining") {
+		t.Fatalf("expected cooldown error with 2 commands remaining, got %v", err)
+	}
+	if got := state.Player.Inventory["test_tonic"]; got != 2 {
+		t.Fatalf("rejected use consumed the item: %d left", got)
+	}
+
+	if _, err := RunCommand(&state, "wait", rng); err != nil {
+		t.Fatalf("wait failed: %v", err)
+	}
+	if got := CooldownRemaining(&state, "test_tonic"); got != 1 {
+		t.Fatalf("expected 1 command remaining, got %d", got)
+	}
+	if _, err := RunCommand(&state, "wait", rng); err != nil {
+		t.Fatalf("wait failed: %v", err)
+	}
+	if _, err := RunCommand(&state, "use test_tonic", rng); err != nil {
+		t.Fatalf("use after cooldown failed: %v", err)
+	}
+	if len(Cooldowns(&state)) != 1 {
+		t.Fatalf("expected the tonic cooling down again, got %v", Cooldowns(&state))
+	}
+}
@@ -0,0 +1,62 @@
+package engine
+
+import "sort"
+
+// ================================
+// Leaderboard
+// ================================
+
+// SlotSource enumerates saved games. It mirrors ports.SlotStore so the
+// engine stays free of port imports, the same way RNG does.
+type SlotSource interface {
+	Slots() ([]string, error)
+	LoadSlot(name string) (*State, error)
+}
+
+// LeaderboardEntry summarizes one save slot.
+type LeaderboardEntry struct {
+	Slot     string
+	Name     string
+	Class    string
+	Level    int
+	Gold     int
+	Commands int
+}
+
+// Leaderboard loads every slot and ranks the players by level, then gold,
+// then command count. Slots that fail to load are skipped and reported in
+// warnings; only a failure to list the slots aborts.
+func Leaderboard(store SlotSource) (entries []LeaderboardEntry, warnings []string, err error) {
+	names, err := store.Slots()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, name := range names {
+		s, err := store.LoadSlot(name)
+		if err != nil {
+			warnings = append(warnings, "skipped slot "+name+": "+err.Error())
+			continue
+		}
+		entries = append(entries, LeaderboardEntry{
+			Slot:     name,
+			Name:     s.Player.Name,
+			Class:    s.Player.Class,
+			Level:    s.Player.Level,
+			Gold:     s.Player.Gold,
+			Commands: s.Meta.CommandCount,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Level != b.Level {
+			return a.Level > b.Level
+		}
+		if a.Gold != b.Gold {
+			return a.Gold > b.Gold
+		}
+		return a.Commands > b.Commands
+	})
+	return entries, warnings, nil
+}
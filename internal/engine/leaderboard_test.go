@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"errors"
+	"sort"
+	"testing"
+)
+
+type memSlots map[string]*State
+
+func (m memSlots) Slots() ([]string, error) {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (m memSlots) LoadSlot(name string) (*State, error) {
+	if m[name] == nil {
+		return nil, errors.New("corrupt save")
+	}
+	return m[name], nil
+}
+
+func slotState(level, gold, commands int) *State {
+	s := DefaultState()
+	s.Player.Level = level
+	s.Player.Gold = gold
+	s.Meta.CommandCount = commands
+	return &s
+}
+
+func TestLeaderboard_RanksByLevelGoldCommands(t *testing.T) {
+	slots := memSlots{
+		"alpha":   slotState(3, 100, 10),
+		"bravo":   slotState(5, 10, 10),
+		"charlie": slotState(3, 100, 40),
+		"delta":   slotState(3, 250, 5),
+		"broken":  nil,
+	}
+
+	entries, warnings, err := Leaderboard(slots)
+	if err != nil {
+		t.Fatalf("Leaderboard returned error: %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning for the broken slot, got %v", warnings)
+	}
+
+	want := []string{"bravo", "delta", "charlie", "alpha"}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(entries))
+	}
+	for i, slot := range want {
+		if entries[i].Slot != slot {
+			t.Fatalf("rank %d: expected %s, got %s", i+1, slot, entries[i].Slot)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package engine
+
+// ================================
+// Loot Log
+// ================================
+
+// LootLogMax bounds State.LootLog; the oldest entries drop off first.
+var LootLogMax = 50
+
+// Loot sources other than an enemy ID.
+const (
+	LootExplore  = "explore"
+	LootTreasure = "treasure"
+	LootDungeon  = "dungeon"
+	LootCrafted  = "crafted"
+	LootBought   = "bought"
+)
+
+// LootRecord is one item that entered the inventory. Source is the enemy
+// ID that dropped it, or one of the Loot* sources; Command is
+// Meta.CommandCount at the time.
+type LootRecord struct {
+	ItemID  string `json:"item_id"`
+	Count   int    `json:"count"`
+	Affix   string `json:"affix,omitempty"`
+	Source  string `json:"source"`
+	Command int    `json:"command"`
+}
+
+// RecordLoot appends a LootRecord per ItemAdded in a command's events.
+// Drops are the items a LootFound summarizes; anything else was crafted
+// or bought.
+func RecordLoot(state *State, events Events) {
+	dropped := map[string]int{}
+	var defeated []string
+	dropSource, other := LootTreasure, LootBought
+	for _, ev := range events {
+		switch e := ev.(type) {
+		case LootFound:
+			for _, it := range e.Items {
+				dropped[NormalizeItemID(it)]++
+			}
+		case EnemyDefeated:
+			defeated = append(defeated, e.EnemyID)
+		case DungeonCleared:
+			dropSource = LootDungeon
+		case ExplorationResult:
+			if e.Kind == "item" {
+				dropSource = LootExplore
+			}
+		case ItemCrafted:
+			other = LootCrafted
+		}
+	}
+
+	for _, ev := range events {
+		added, ok := ev.(ItemAdded)
+		if !ok {
+			continue
+		}
+		id := NormalizeItemID(added.ItemID)
+		source := other
+		if dropped[id] > 0 {
+			dropped[id]--
+			source = dropperOf(id, defeated, dropSource)
+		}
+		state.LootLog = append(state.LootLog, LootRecord{
+			ItemID:  id,
+			Count:   added.Count,
+			Affix:   added.Affix,
+			Source:  source,
+			Command: state.Meta.CommandCount,
+		})
+	}
+	if over := len(state.LootLog) - LootLogMax; over > 0 {
+		state.LootLog = append([]LootRecord(nil), state.LootLog[over:]...)
+	}
+}
+
+// dropperOf is the first defeated enemy whose loot table holds itemID, or
+// fallback when none does.
+func dropperOf(itemID string, defeated []string, fallback string) string {
+	for _, enemyID := range defeated {
+		for _, l := range Enemies[enemyID].Loot {
+			if l.ItemID == itemID {
+				return enemyID
+			}
+		}
+	}
+	return fallback
+}
+
+// RecentLoot returns up to n of the newest loot records, newest first.
+func RecentLoot(state *State, n int) []LootRecord {
+	log := state.LootLog
+	n = min(n, len(log))
+	out := make([]LootRecord, 0, max(n, 0))
+	for i := len(log) - 1; i >= len(log)-n; i-- {
+		out = append(out, log[i])
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+package engine
+
+import "testing"
+
+func TestRecordLoot_GoblinDropNamesGoblin(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 20
+	state.Player.Inventory = map[string]int{}
+
+	// Float64 draws of 0 pass every drop chance.
+	if _, err := RunCommand(&state, "hunt goblin", &seqRNG{}); err != nil {
+		t.Fatalf("hunt goblin: %v", err)
+	}
+	if len(state.LootLog) == 0 {
+		t.Fatal("expected the goblin's drops in the loot log")
+	}
+	for _, r := range state.LootLog {
+		if r.Source != "goblin" || r.Command != state.Meta.CommandCount {
+			t.Fatalf("expected a goblin drop at command %d, got %+v", state.Meta.CommandCount, r)
+		}
+	}
+
+	state.Player.Inventory["wolf_pelt"] = 2
+	if _, err := RunCommand(&state, "craft fur_cloak", &seqRNG{}); err != nil {
+		t.Fatalf("craft: %v", err)
+	}
+	if got := RecentLoot(&state, 1); len(got) != 1 || got[0].ItemID != "fur_cloak" || got[0].Source != LootCrafted {
+		t.Fatalf("expected a crafted fur cloak newest, got %+v", got)
+	}
+}
+
+func TestRecordLoot_KeepsNewestUpToMax(t *testing.T) {
+	old := LootLogMax
+	LootLogMax = 3
+	defer func() { LootLogMax = old }()
+
+	state := DefaultState()
+	for i := range 5 {
+		state.Meta.CommandCount = i
+		RecordLoot(&state, Events{ItemAdded{ItemID: "torch", Count: 1}})
+	}
+	if len(state.LootLog) != 3 || state.LootLog[0].Command != 2 || state.LootLog[2].Source != LootBought {
+		t.Fatalf("unexpected log: %+v", state.LootLog)
+	}
+}
@@ -1,6 +1,11 @@
 package engine
 
-import "testing"
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
 
 type seqRNG struct {
 	ints   []int
@@ -94,6 +99,87 @@ func TestGrantXP_MultipleLevelUps(t *testing.T) {
 	}
 }
 
+func TestCollapseLevelUps_GroupsConsecutiveRuns(t *testing.T) {
+	events := Events{
+		XPGained{Amount: 600},
+		LevelUp{NewLevel: 2, NewMaxHP: 110},
+		LevelUp{NewLevel: 3, NewMaxHP: 120},
+		LevelUp{NewLevel: 4, NewMaxHP: 130},
+		GoldGained{Amount: 5},
+		LevelUp{NewLevel: 5, NewMaxHP: 140},
+	}
+
+	got := CollapseLevelUps(events)
+	want := Events{
+		XPGained{Amount: 600},
+		LevelsGained{Count: 3, NewLevel: 4, NewMaxHP: 130},
+		GoldGained{Amount: 5},
+		LevelUp{NewLevel: 5, NewMaxHP: 140},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d events, got %#v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("event %d: expected %#v, got %#v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestSummarizeCombat_CollapsesFightToStartOutcomeAndTotals(t *testing.T) {
+	events := Events{
+		SPSpent{Amount: 1},
+		EncounterStarted{EnemyID: "goblin"},
+		DamageDealt{Source: "player", Target: "goblin", Amount: 6, HPLeft: 14},
+		DamageDealt{Source: "goblin", Target: "player", Amount: 3, HPLeft: 97},
+		DamageDealt{Source: "player", Target: "goblin", Amount: 7, HPLeft: 7},
+		DamageDealt{Source: "goblin", Target: "player", Amount: 4, HPLeft: 93},
+		DamageDealt{Source: "player", Target: "goblin", Amount: 9, HPLeft: 0, Overkill: 2},
+		EnemyDefeated{EnemyID: "goblin", XP: 10, Gold: 5},
+		XPGained{Amount: 10},
+	}
+
+	got := SummarizeCombat(events)
+	want := Events{
+		SPSpent{Amount: 1},
+		EncounterStarted{EnemyID: "goblin"},
+		CombatTotals{Dealt: 22, Taken: 7, Hits: 5},
+		EnemyDefeated{EnemyID: "goblin", XP: 10, Gold: 5},
+		XPGained{Amount: 10},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected summary:\n got %#v\nwant %#v", got, want)
+	}
+}
+
+func TestSummarizeCombat_GroupEndsAtLastDefeatOrLoss(t *testing.T) {
+	events := Events{
+		EncounterStarted{EnemyID: "wolf"},
+		EncounterStarted{EnemyID: "wolf"},
+		DamageDealt{Source: "player", Target: "wolf", Amount: 12, HPLeft: 0},
+		EnemyDefeated{EnemyID: "wolf"},
+		DamageDealt{Source: "wolf", Target: "player", Amount: 5, HPLeft: 0},
+		PlayerDefeated{},
+	}
+	got := SummarizeCombat(events)
+	want := Events{
+		EncounterStarted{EnemyID: "wolf"},
+		EncounterStarted{EnemyID: "wolf"},
+		EnemyDefeated{EnemyID: "wolf"},
+		CombatTotals{Dealt: 12, Taken: 5, Hits: 2},
+		PlayerDefeated{},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected summary:\n got %#v\nwant %#v", got, want)
+	}
+
+	// A targeted battle's round has no EncounterStarted; its hits stay.
+	round := Events{DamageDealt{Source: "player", Target: "wolf", Amount: 4, HPLeft: 8}}
+	if got := SummarizeCombat(round); !reflect.DeepEqual(got, round) {
+		t.Fatalf("expected battle hits kept, got %#v", got)
+	}
+}
+
 func TestResolveCombat_WinDeterministicWithLoot(t *testing.T) {
 	state := DefaultState()
 	state.Player.Level = 10
@@ -119,6 +205,84 @@ func TestResolveCombat_WinDeterministicWithLoot(t *testing.T) {
 	}
 }
 
+func TestResolveCombat_FasterEnemyStrikesFirst(t *testing.T) {
+	defer func(prev bool) { Initiative = prev }(Initiative)
+	Initiative = true
+
+	state := DefaultState()
+	state.Player.Level = 10
+	state.Player.HP = 100
+
+	_, events := ResolveCombat(&state, Enemies["wolf"], &seqRNG{})
+	hit, ok := events[1].(DamageDealt)
+	if !ok || hit.Source != "wolf" || hit.Target != "player" {
+		t.Fatalf("expected the wolf to strike first, got %#v", events[1])
+	}
+
+	// Ties go to the player.
+	state.Player.Speed = Enemies["wolf"].Speed
+	_, events = ResolveCombat(&state, Enemies["wolf"], &seqRNG{})
+	if hit, ok := events[1].(DamageDealt); !ok || hit.Source != "player" {
+		t.Fatalf("expected the player to strike first on a speed tie, got %#v", events[1])
+	}
+}
+
+func TestResolveCombat_OneShotReportsOverkill(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 10
+	lo, _ := AttackRange(&state.Player)
+
+	_, events := ResolveCombat(&state, Enemies["goblin"], &seqRNG{})
+	hit, ok := events[1].(DamageDealt)
+	if !ok || hit.Source != "player" || hit.HPLeft != 0 {
+		t.Fatalf("expected a killing blow, got %#v", events[1])
+	}
+	if want := lo - Enemies["goblin"].HP; hit.Overkill != want {
+		t.Fatalf("expected overkill %d, got %d", want, hit.Overkill)
+	}
+
+	RecordOverkill(&state, events)
+	if state.Meta.MaxOverkill != hit.Overkill {
+		t.Fatalf("expected MaxOverkill %d, got %d", hit.Overkill, state.Meta.MaxOverkill)
+	}
+}
+
+func TestResolveCombat_LuckTurnsMissIntoDrop(t *testing.T) {
+	// goblin drops: rusty_dagger 0.20, healing_potion 0.10; a roll of 0.25
+	// misses both at base chance.
+	for _, tc := range []struct {
+		luck int
+		want int
+	}{
+		{luck: 0, want: 0},
+		{luck: 5, want: 1},
+	} {
+		state := DefaultState()
+		state.Player.Level = 10
+		state.Player.Luck = tc.luck
+
+		rng := &seqRNG{ints: []int{0}, floats: []float64{0.25, 0.25}}
+		result, _ := ResolveCombat(&state, Enemies["goblin"], rng)
+		if len(result.Loot) != tc.want {
+			t.Fatalf("luck %d: expected %d drops, got %v", tc.luck, tc.want, result.Loot)
+		}
+	}
+}
+
+func TestExplore_LuckWidensTreasureBand(t *testing.T) {
+	state := DefaultState()
+	state.Player.Luck = 1
+
+	// roll 4 is a gold find at base luck, but treasure with +2%.
+	events, err := Explore(&state, &seqRNG{ints: []int{3, 0, 0}})
+	if err != nil {
+		t.Fatalf("Explore returned error: %v", err)
+	}
+	if r, ok := events[0].(ExplorationResult); !ok || r.Kind != "treasure" {
+		t.Fatalf("expected treasure with luck, got %v", events)
+	}
+}
+
 func TestHunt_UsesExtraSPAndAppliesMultiplier(t *testing.T) {
 	state := DefaultState()
 	state.Player.Level = 10
@@ -146,6 +310,119 @@ func TestHunt_UsesExtraSPAndAppliesMultiplier(t *testing.T) {
 	}
 }
 
+func TestHunt_AntiFarmShrinksOverLeveledRewards(t *testing.T) {
+	old := AntiFarm
+	AntiFarm = true
+	defer func() { AntiFarm = old }()
+
+	if got := rewardScale(3, 1); got != 1 {
+		t.Fatalf("expected full rewards for a close fight, got %v", got)
+	}
+	if got := rewardScale(50, 1); got != AntiFarmTunables.Floor {
+		t.Fatalf("expected a huge gap to hit the floor, got %v", got)
+	}
+
+	state := DefaultState()
+	state.Player.Level = 30
+	state.Player.XP = 0
+	state.Player.Gold = 0
+
+	// Floats of 1 miss every drop; goblins pay 5 XP and 3 gold.
+	if _, err := HuntTarget(&state, "goblin", 0, &seqRNG{floats: []float64{1, 1}}); err != nil {
+		t.Fatalf("HuntTarget returned error: %v", err)
+	}
+	if state.Player.XP >= 5 || state.Player.Gold >= 3 {
+		t.Fatalf("expected reduced rewards, got XP %d gold %d", state.Player.XP, state.Player.Gold)
+	}
+}
+
+func TestHunt_AlternateTuningRaisesReward(t *testing.T) {
+	saved := HuntTunables
+	defer func() { HuntTunables = saved }()
+	HuntTunables.RewardPerSP = 1.0
+
+	state := DefaultState()
+	state.Player.Level = 10
+	state.Player.SP = 10
+	state.Player.Gold = 0
+
+	rng := &seqRNG{ints: []int{0, 0}, floats: []float64{1, 1}}
+	if _, err := Hunt(&state, 2, rng); err != nil {
+		t.Fatalf("Hunt returned error: %v", err)
+	}
+	if state.Player.XP != 15 {
+		t.Fatalf("expected XP 15 (5 * 3), got %d", state.Player.XP)
+	}
+	if state.Player.Gold != 9 {
+		t.Fatalf("expected Gold 9 (3 * 3), got %d", state.Player.Gold)
+	}
+}
+
+func TestChooseEnemy_StakeBiasFollowsTuning(t *testing.T) {
+	saved := HuntTunables
+	defer func() { HuntTunables = saved }()
+
+	// With no weight shift a stake changes nothing: roll 0 stays a goblin.
+	HuntTunables.WeightPerSP = 0
+	state := DefaultState()
+	if id := ChooseEnemy(&state, 3, &seqRNG{ints: []int{0}}); id != "goblin" {
+		t.Fatalf("expected goblin, got %s", id)
+	}
+
+	// A big enough shift empties the goblin band entirely.
+	HuntTunables.WeightPerSP = 25
+	if id := ChooseEnemy(&state, 1, &seqRNG{ints: []int{0}}); id == "goblin" {
+		t.Fatal("expected the stake to push past goblins")
+	}
+}
+
+func TestHunt_TargetedSkipsWeighting(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 10
+	state.Player.SP = 10
+
+	// Roll 0 would pick a goblin; naming the wolf skips the weighted pick.
+	events, err := RunCommand(&state, "hunt wolf 1", &seqRNG{floats: []float64{1, 1}})
+	if err != nil {
+		t.Fatalf("hunt wolf: %v", err)
+	}
+	if enc, ok := events[1].(EncounterStarted); !ok || enc.EnemyID != "wolf" {
+		t.Fatalf("expected a wolf encounter, got %#v", events[1])
+	}
+	if state.Player.SP != 10-HuntBaseSP-1 {
+		t.Fatalf("expected the stake paid, SP %d", state.Player.SP)
+	}
+}
+
+func TestHunt_NumericArgStaysRandom(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 10
+	state.Player.SP = 10
+
+	events, err := RunCommand(&state, "hunt 2", &seqRNG{floats: []float64{1, 1}})
+	if err != nil {
+		t.Fatalf("hunt 2: %v", err)
+	}
+	if enc, ok := events[1].(EncounterStarted); !ok || enc.EnemyID != "goblin" {
+		t.Fatalf("expected the weighted pick (goblin), got %#v", events[1])
+	}
+	if state.Player.SP != 10-HuntBaseSP-2 {
+		t.Fatalf("expected SP %d, got %d", 10-HuntBaseSP-2, state.Player.SP)
+	}
+}
+
+func TestHunt_UnknownTargetErrors(t *testing.T) {
+	state := DefaultState()
+	state.Player.SP = 10
+
+	if _, err := RunCommand(&state, "hunt dragon", &seqRNG{}); !errors.Is(err, ErrUnknownEnemy) {
+		t.Fatalf("expected ErrUnknownEnemy, got %v", err)
+	}
+	if state.Player.SP != 10 {
+		t.Fatalf("an invalid target must cost nothing, SP %d", state.Player.SP)
+	}
+}
+
 func TestHunt_ClampsExtraSPToMax(t *testing.T) {
 	state := DefaultState()
 	state.Player.Level = 10
@@ -187,6 +464,37 @@ func TestExplore_TreasurePath(t *testing.T) {
 	}
 }
 
+func TestExplore_EncounterRateShiftsBand(t *testing.T) {
+	old := EncounterRate
+	defer func() { EncounterRate = old }()
+
+	explore := func() Events {
+		state := DefaultState()
+		// A roll of 60: past the default encounter band (31-50).
+		events, err := Explore(&state, &seqRNG{ints: []int{59}, floats: []float64{0.99}})
+		if err != nil {
+			t.Fatalf("Explore returned error: %v", err)
+		}
+		return events
+	}
+	fought := func(events Events) bool {
+		for _, ev := range events {
+			if _, ok := ev.(EncounterStarted); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	if events := explore(); fought(events) {
+		t.Fatalf("roll 60 should find nothing at the default rate, got %#v", events)
+	}
+	EncounterRate = 40
+	if events := explore(); !fought(events) {
+		t.Fatalf("roll 60 should meet an enemy at a 40%% rate, got %#v", events)
+	}
+}
+
 func TestExplore_PlayerDownReturnsError(t *testing.T) {
 	state := DefaultState()
 	state.Player.HP = 0
@@ -196,3 +504,312 @@ func TestExplore_PlayerDownReturnsError(t *testing.T) {
 		t.Fatalf("expected error when player HP is 0")
 	}
 }
+
+func TestHunt_EmitsLootSummaryWithAllDrops(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 10
+	state.Player.SP = 10
+
+	rng := &seqRNG{ints: []int{0, 0}, floats: []float64{0.05, 0.05}}
+	events, err := Hunt(&state, 0, rng)
+	if err != nil {
+		t.Fatalf("Hunt returned error: %v", err)
+	}
+
+	var summary *LootFound
+	for _, ev := range events {
+		if lf, ok := ev.(LootFound); ok {
+			summary = &lf
+		}
+	}
+	if summary == nil {
+		t.Fatalf("expected LootFound summary event")
+	}
+	want := map[string]bool{"rusty_dagger": false, "healing_potion": false}
+	for _, it := range summary.Items {
+		want[it] = true
+	}
+	for it, seen := range want {
+		if !seen {
+			t.Fatalf("expected %s in loot summary, got %v", it, summary.Items)
+		}
+	}
+
+	collapsed := CollapseLoot(events)
+	for _, ev := range collapsed {
+		if _, ok := ev.(ItemAdded); ok {
+			t.Fatalf("expected per-item ItemAdded events collapsed into LootFound")
+		}
+	}
+	if len(collapsed) != len(events)-len(summary.Items) {
+		t.Fatalf("expected only summarized ItemAdded events removed, got %d of %d", len(collapsed), len(events))
+	}
+}
+
+func TestRevive_RestoresPartialHPForGold(t *testing.T) {
+	state := DefaultState()
+	state.Player.HP = 0
+	state.Player.Level = 2
+	state.Meta.Location = "Dark Forest"
+
+	events, err := RunCommand(&state, "revive", &seqRNG{})
+	if err != nil {
+		t.Fatalf("revive returned error: %v", err)
+	}
+
+	cost := ReviveCost(2)
+	if state.Player.Gold != 50-cost {
+		t.Fatalf("expected %d gold after revive, got %d", 50-cost, state.Player.Gold)
+	}
+	if state.Player.HP != DefaultMaxHP/2 {
+		t.Fatalf("expected HP %d, got %d", DefaultMaxHP/2, state.Player.HP)
+	}
+	if state.Meta.Location != "Starting Village" {
+		t.Fatalf("expected return to town, got %q", state.Meta.Location)
+	}
+	if _, ok := events[0].(GoldSpent); !ok {
+		t.Fatalf("expected GoldSpent first, got %v", events)
+	}
+	if _, ok := events[1].(HPRestored); !ok {
+		t.Fatalf("expected HPRestored, got %v", events)
+	}
+}
+
+func TestRevive_NotEnoughGold(t *testing.T) {
+	state := DefaultState()
+	state.Player.HP = 0
+	state.Player.Gold = ReviveCost(1) - 1
+
+	if _, err := Revive(&state); err == nil {
+		t.Fatalf("expected error when gold is short")
+	}
+	if state.Player.HP != 0 || state.Player.Gold != ReviveCost(1)-1 {
+		t.Fatalf("expected state untouched on failed revive")
+	}
+}
+
+func TestExplore_PlayerDownPointsToRevive(t *testing.T) {
+	state := DefaultState()
+	state.Player.HP = 0
+
+	if _, err := Explore(&state, &seqRNG{}); !errors.Is(err, ErrPlayerDown) || !strings.Contains(err.Error(), "revive") {
+		t.Fatalf("expected ErrPlayerDown mentioning revive, got %v", err)
+	}
+}
+
+func TestCamp_SafeRestRestoresHPAndSP(t *testing.T) {
+	state := DefaultState()
+	state.Player.HP = 50
+	state.Player.SP = 3
+
+	events, err := Camp(&state, &seqRNG{floats: []float64{CampAmbushChance}})
+	if err != nil {
+		t.Fatalf("Camp returned error: %v", err)
+	}
+	if state.Player.HP != 50+CampHP || state.Player.SP != 3+CampSP {
+		t.Fatalf("expected HP %d SP %d, got HP %d SP %d", 50+CampHP, 3+CampSP, state.Player.HP, state.Player.SP)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected HPRestored and SPRegained, got %v", events)
+	}
+	if _, ok := events[0].(HPRestored); !ok {
+		t.Fatalf("expected HPRestored first, got %v", events)
+	}
+	if _, ok := events[1].(SPRegained); !ok {
+		t.Fatalf("expected SPRegained second, got %v", events)
+	}
+}
+
+func TestCamp_AmbushStartsCombat(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 10
+	state.Player.HP = 50
+	state.Player.SP = 3
+
+	// ambush roll, then weighted pick 0 -> goblin, one-hit kill, no loot
+	events, err := Camp(&state, &seqRNG{ints: []int{0, 0}, floats: []float64{0, 1, 1}})
+	if err != nil {
+		t.Fatalf("Camp returned error: %v", err)
+	}
+	if enc, ok := events[0].(EncounterStarted); !ok || enc.EnemyID != "goblin" {
+		t.Fatalf("expected goblin ambush, got %v", events)
+	}
+	if state.Player.SP != 3 {
+		t.Fatalf("expected no SP recovery when ambushed, got %d", state.Player.SP)
+	}
+	for _, ev := range events {
+		if _, ok := ev.(HPRestored); ok {
+			t.Fatalf("expected no rest when ambushed, got %v", events)
+		}
+	}
+}
+
+func TestResolveCombat_GoldRolledWithinRange(t *testing.T) {
+	enemy := EnemyTemplate{ID: "test_mark", Name: "Mark", HP: 1, Gold: 10, GoldMax: 20}
+
+	seen := map[int]bool{}
+	for roll := 0; roll <= enemy.GoldMax-enemy.Gold; roll++ {
+		state := DefaultState()
+		result, events := ResolveCombat(&state, enemy, &seqRNG{ints: []int{0, roll}})
+		if result.Gold < enemy.Gold || result.Gold > enemy.GoldMax {
+			t.Fatalf("gold %d outside [%d, %d]", result.Gold, enemy.Gold, enemy.GoldMax)
+		}
+		for _, ev := range events {
+			if d, ok := ev.(EnemyDefeated); ok && d.Gold != result.Gold {
+				t.Fatalf("EnemyDefeated reports %d gold, result has %d", d.Gold, result.Gold)
+			}
+		}
+		seen[result.Gold] = true
+	}
+	if !seen[enemy.Gold] || !seen[enemy.GoldMax] {
+		t.Fatalf("expected both range ends reachable, saw %v", seen)
+	}
+}
+
+func TestResolveCombat_StalemateAfterTurnLimit(t *testing.T) {
+	// Neither side can deal damage: the player's level makes every swing 0
+	// on a zero roll, and the enemy hits for nothing.
+	enemy := EnemyTemplate{ID: "test_wall", Name: "Wall", HP: 10, XP: 50, Gold: 10}
+	state := DefaultState()
+	state.Player.Level = -1
+	hp := state.Player.HP
+
+	result, events := ResolveCombat(&state, enemy, &seqRNG{})
+	if result.Outcome != "stalemate" {
+		t.Fatalf("expected stalemate, got %q", result.Outcome)
+	}
+	if result.XP != 0 || result.Gold != 0 || len(result.Loot) != 0 {
+		t.Fatalf("expected no rewards, got %+v", result)
+	}
+	if state.Player.HP != hp {
+		t.Fatalf("expected HP untouched at %d, got %d", hp, state.Player.HP)
+	}
+	s, ok := events[len(events)-1].(CombatStalemate)
+	if !ok || s.Turns != MaxCombatTurns {
+		t.Fatalf("expected CombatStalemate after %d turns, got %v", MaxCombatTurns, events[len(events)-1])
+	}
+}
+
+func TestExplore_RetreatsFromMuchStrongerEnemy(t *testing.T) {
+	state := DefaultState()
+	hp := state.Player.HP
+
+	// roll 31 is an encounter; 76 of the level 1 weights is an orc.
+	events, err := Explore(&state, &seqRNG{ints: []int{30, 76}})
+	if err != nil {
+		t.Fatalf("Explore returned error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected only the avoidance event, got %#v", events)
+	}
+	if avoided, ok := events[0].(EncounterAvoided); !ok || avoided.EnemyID != "orc" {
+		t.Fatalf("expected EncounterAvoided for the orc, got %#v", events[0])
+	}
+	if state.Player.HP != hp || state.Meta.CommandCount != 1 {
+		t.Fatalf("expected no fight but a spent command, got hp=%d commands=%d", state.Player.HP, state.Meta.CommandCount)
+	}
+
+	old := RetreatXPPerLevel
+	RetreatXPPerLevel = 0
+	defer func() { RetreatXPPerLevel = old }()
+
+	state = DefaultState()
+	events, _ = Explore(&state, &seqRNG{ints: []int{30, 76}})
+	if _, ok := events[0].(EncounterStarted); !ok {
+		t.Fatalf("expected the orc fought with retreating disabled, got %#v", events[0])
+	}
+}
+
+func TestPityAdjustedChance_RisesWithMissesAndCaps(t *testing.T) {
+	if got := pityAdjustedChance(0.1, 0); got != 0.1 {
+		t.Fatalf("expected no pity without misses, got %v", got)
+	}
+	if got := pityAdjustedChance(0.1, 4); got <= 0.1 {
+		t.Fatalf("expected 4 misses to raise the chance, got %v", got)
+	}
+	if got := pityAdjustedChance(0.9, 100); got != 1 {
+		t.Fatalf("expected the chance to cap at 1, got %v", got)
+	}
+}
+
+func TestDefeatEnemy_PityGuaranteesDropAfterMisses(t *testing.T) {
+	old := LootPity
+	LootPity = true
+	defer func() { LootPity = old }()
+
+	state := DefaultState()
+	state.Player.Luck = 0
+	enemy := EnemyTemplate{ID: "rat", XP: 1, Loot: []LootEntry{{ItemID: "rat_tail", Chance: 0.1}}}
+
+	// 0.99 misses every natural chance below 1, so only pity can land it.
+	misses := int((1 - 0.1) / PityStep)
+	for i := range misses + 2 {
+		var won CombatResult
+		defeatEnemy(&state, enemy, &won, &seqRNG{floats: []float64{0.99}})
+		if len(won.Loot) > 0 {
+			if i < misses {
+				t.Fatalf("expected no drop before %d misses, got one after %d", misses, i)
+			}
+			if n := state.Meta.DropMisses["rat_tail"]; n != 0 {
+				t.Fatalf("expected the pity count to reset on a drop, got %d", n)
+			}
+			return
+		}
+		if n := state.Meta.DropMisses["rat_tail"]; n != i+1 {
+			t.Fatalf("expected %d recorded misses, got %d", i+1, n)
+		}
+	}
+	t.Fatalf("expected a guaranteed drop within %d rolls", misses+2)
+}
+
+func TestResolveCombat_BanditStealsGoldOnLoss(t *testing.T) {
+	state := DefaultState()
+	state.Player.HP = 1
+	state.Player.Gold = 20
+
+	// Float64 draws of 0 land every steal.
+	result, events := ResolveCombat(&state, Enemies["bandit"], &seqRNG{})
+	if result.Outcome != "lose" {
+		t.Fatalf("expected a loss at 1 HP, got %q", result.Outcome)
+	}
+	if state.Player.Gold != 15 {
+		t.Fatalf("expected the bandit to take 5 gold, have %d", state.Player.Gold)
+	}
+	var stolen []Stolen
+	for _, e := range events {
+		if s, ok := e.(Stolen); ok {
+			stolen = append(stolen, s)
+		}
+	}
+	if len(stolen) != 1 || stolen[0] != (Stolen{EnemyID: "bandit", Gold: 5}) {
+		t.Fatalf("expected one 5 gold theft, got %+v", stolen)
+	}
+}
+
+func TestResolveCombat_ThiefTakesItemAndWinReturnsIt(t *testing.T) {
+	state := DefaultState()
+	state.Player.Gold = 0
+	state.Player.Inventory = map[string]int{"torch": 1}
+	pMin, _ := AttackRange(&state.Player)
+	thief := EnemyTemplate{ID: "cutpurse", HP: pMin + 1, StealChance: 1, StealAmount: 5}
+
+	result, events := ResolveCombat(&state, thief, &seqRNG{})
+	if result.Outcome != "win" {
+		t.Fatalf("expected a win, got %q", result.Outcome)
+	}
+	if state.Player.Inventory["torch"] != 1 {
+		t.Fatalf("expected the stolen torch back after the win, have %v", state.Player.Inventory)
+	}
+	var took, recovered bool
+	for _, e := range events {
+		switch ev := e.(type) {
+		case Stolen:
+			took = ev.ItemID == "torch"
+		case StolenRecovered:
+			recovered = len(ev.Items) == 1 && ev.Items[0] == "torch"
+		}
+	}
+	if !took || !recovered {
+		t.Fatalf("expected the torch stolen then recovered, got %+v", events)
+	}
+}
@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"errors"
+	"sort"
+)
+
+// ================================
+// Merchant Caravan
+// ================================
+
+// MerchantTuning shapes the caravan explore can meet.
+type MerchantTuning struct {
+	// Chance is the percent of explores, carved out of the "nothing"
+	// band, that meet a caravan.
+	Chance int `json:"chance"`
+	// Discount is how far below BuyPrice the caravan sells its rare item,
+	// in percent; Premium is how far above catalog price it pays for the
+	// item it wants.
+	Discount int `json:"discount"`
+	Premium  int `json:"premium"`
+}
+
+var MerchantTunables = MerchantTuning{Chance: 3, Discount: 25, Premium: 50}
+
+// Merchant options.
+const (
+	TradeBuy   = "buy"
+	TradeSell  = "sell"
+	TradeLeave = "leave"
+)
+
+// errMerchantPending blocks every command but `trade` while a caravan
+// waits.
+var errMerchantPending = errors.New("a merchant is waiting: trade buy | sell | leave")
+
+// offerMerchant leaves a caravan pending with one rare item for sale and,
+// if the player carries anything priced, one item it wants. It draws one
+// rng.Intn for the item for sale and one for the wanted item.
+func offerMerchant(state *State, rng RNG) Events {
+	var rare []string
+	for id, it := range Items {
+		if it.Rare && it.Price > 0 {
+			rare = append(rare, id)
+		}
+	}
+	sort.Strings(rare)
+	item := rare[rng.Intn(len(rare))]
+
+	c := &PendingChoice{
+		Kind:  "merchant",
+		Item:  item,
+		Price: BuyPrice(item) * (100 - MerchantTunables.Discount) / 100,
+	}
+
+	var priced []string
+	for id := range state.Player.Inventory {
+		if Items[id].Price > 0 {
+			priced = append(priced, id)
+		}
+	}
+	if len(priced) > 0 {
+		sort.Strings(priced)
+		c.Wants = priced[rng.Intn(len(priced))]
+		c.Offer = Items[c.Wants].Price * (100 + MerchantTunables.Premium) / 100
+	}
+
+	state.Pending = c
+	return emit(nil, MerchantOffered{
+		Item:    c.Item,
+		Price:   c.Price,
+		Wants:   c.Wants,
+		Offer:   c.Offer,
+		Options: c.Options(&state.Player),
+	})
+}
+
+// Trade answers a waiting caravan. Any answer, once it succeeds, sends the
+// caravan on its way.
+func Trade(state *State, option string, rng RNG) (Events, error) {
+	c := state.Pending
+	if c == nil || c.Kind != "merchant" {
+		return Events{}, errors.New("no merchant here")
+	}
+	if !c.allows(&state.Player, option) {
+		return Events{}, errors.New("usage: trade " + joinOptions(c.Options(&state.Player)))
+	}
+
+	var (
+		events Events
+		err    error
+	)
+	switch option {
+	case TradeBuy:
+		events, err = Buy(state, c.Item, c.Price, rng)
+	case TradeSell:
+		events, err = SellAt(state, c.Wants, c.Offer)
+	default:
+		events = emit(nil, MerchantLeft{})
+	}
+	if err != nil {
+		return events, err
+	}
+	state.Pending = nil
+	return events, nil
+}
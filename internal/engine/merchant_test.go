@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExplore_MerchantSellsItsRareItem(t *testing.T) {
+	state := DefaultState()
+	state.Player.Gold = 100
+
+	// Roll 51 is the first merchant roll past encounters; then the rare
+	// item (orcish_blade) and the wanted item (rusty_dagger).
+	events, err := RunCommand(&state, "explore", &seqRNG{ints: []int{50, 1, 0}})
+	if err != nil {
+		t.Fatalf("explore: %v", err)
+	}
+	c := state.Pending
+	if c == nil || c.Kind != "merchant" || c.Item != "orcish_blade" || c.Wants != "rusty_dagger" {
+		t.Fatalf("expected a merchant selling orcish_blade, got %+v", c)
+	}
+	wantPrice := BuyPrice("orcish_blade") * (100 - MerchantTunables.Discount) / 100
+	if c.Price != wantPrice || c.Price >= BuyPrice("orcish_blade") {
+		t.Fatalf("expected discounted price %d, got %d", wantPrice, c.Price)
+	}
+	if c.Offer <= Items["rusty_dagger"].Price {
+		t.Fatalf("expected a premium offer, got %d", c.Offer)
+	}
+	offered := false
+	for _, ev := range events {
+		if o, ok := ev.(MerchantOffered); ok {
+			offered = len(o.Options) == 3
+		}
+	}
+	if !offered {
+		t.Fatalf("expected MerchantOffered with buy, sell and leave, got %#v", events)
+	}
+
+	if _, err := RunCommand(&state, "explore", &seqRNG{}); !errors.Is(err, errMerchantPending) {
+		t.Fatalf("expected explore blocked by the merchant, got %v", err)
+	}
+	if _, err := RunCommand(&state, "take gold", &seqRNG{}); !errors.Is(err, errMerchantPending) {
+		t.Fatalf("expected take blocked by the merchant, got %v", err)
+	}
+
+	if _, err := RunCommand(&state, "trade buy", &seqRNG{}); err != nil {
+		t.Fatalf("trade buy: %v", err)
+	}
+	if state.Pending != nil {
+		t.Fatalf("expected the caravan gone after a trade")
+	}
+	if state.Player.Gold != 100-wantPrice || !HasItem(&state.Player, "orcish_blade", 1) {
+		t.Fatalf("expected orcish_blade for %d gold, got gold %d, inventory %v", wantPrice, state.Player.Gold, state.Player.Inventory)
+	}
+}
+
+func TestTrade_BuyNeedsGold(t *testing.T) {
+	state := DefaultState()
+	state.Player.Gold = 0
+	state.Pending = &PendingChoice{Kind: "merchant", Item: "orcish_blade", Price: 60}
+
+	if _, err := Trade(&state, TradeBuy, &seqRNG{}); err == nil {
+		t.Fatalf("expected buy refused without gold")
+	}
+	if state.Pending == nil {
+		t.Fatalf("a refused trade should keep the caravan waiting")
+	}
+	if _, err := Trade(&state, TradeLeave, &seqRNG{}); err != nil || state.Pending != nil {
+		t.Fatalf("expected leave to send the caravan off, err %v", err)
+	}
+}
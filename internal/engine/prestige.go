@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ================================
+// Prestige
+// ================================
+
+const (
+	// PrestigeMinLevel is the level a player must reach to prestige.
+	PrestigeMinLevel = 10
+
+	// PrestigeXPBonusPercent is the extra XP each prestige grants, applied
+	// cumulatively in GrantXP.
+	PrestigeXPBonusPercent = 5
+)
+
+// PrestigeOptions controls what survives a prestige besides the counter.
+type PrestigeOptions struct {
+	KeepInventory bool
+	KeepGold      bool
+}
+
+// PrestigeKeep is the policy Prestige applies. By default the player keeps
+// their gear but starts over with the default purse.
+var PrestigeKeep = PrestigeOptions{KeepInventory: true}
+
+// PrestigeXPPercent returns the XP multiplier, in percent, for the given
+// prestige count.
+func PrestigeXPPercent(prestige int) int {
+	return 100 + PrestigeXPBonusPercent*max(prestige, 0)
+}
+
+// Prestige resets level and XP for a permanent XP bonus. Training is kept;
+// inventory and gold are kept or reset according to PrestigeKeep.
+func Prestige(state *State) (Events, error) {
+	events := Events{}
+
+	if !state.Player.IsAlive() {
+		return events, ErrPlayerDown
+	}
+	if state.Dungeon != nil {
+		return events, errors.New("leave the dungeon before you prestige")
+	}
+	if state.Player.Level < PrestigeMinLevel {
+		return events, fmt.Errorf("prestige requires level %d", PrestigeMinLevel)
+	}
+
+	def := DefaultState()
+	p := &state.Player
+	p.Level = def.Player.Level
+	p.XP = def.Player.XP
+	p.MaxHP = def.Player.MaxHP + TrainedBonus(p, StatHP)
+	p.HP = p.MaxHP
+	p.MaxSP = def.Player.MaxSP + TrainedBonus(p, StatSP)
+	p.SP = p.MaxSP
+	p.Buffs = nil
+	if !PrestigeKeep.KeepInventory {
+		p.Inventory = def.Player.Inventory
+		p.Equipment = nil
+		p.Wear = nil
+		p.Affixes = nil
+		p.EquippedAffixes = nil
+	}
+	if !PrestigeKeep.KeepGold {
+		p.Gold = def.Player.Gold
+		p.BankedGold = 0
+	}
+	state.Meta.Location = def.Meta.Location
+	state.Meta.Prestige++
+
+	events = emit(events, Prestiged{
+		Prestige:  state.Meta.Prestige,
+		XPPercent: PrestigeXPPercent(state.Meta.Prestige),
+	})
+	return events, nil
+}
@@ -0,0 +1,78 @@
+package engine
+
+import "testing"
+
+func TestPrestige_ResetsLevelAndBoostsXP(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = PrestigeMinLevel
+	state.Player.XP = 250
+	state.Player.MaxHP = 190
+	state.Player.Gold = 900
+	state.Player.Inventory["orcish_blade"] = 1
+
+	events, err := Prestige(&state)
+	if err != nil {
+		t.Fatalf("Prestige returned error: %v", err)
+	}
+	if state.Player.Level != 1 || state.Player.XP != 0 {
+		t.Fatalf("expected level 1 with 0 XP, got level %d XP %d", state.Player.Level, state.Player.XP)
+	}
+	if state.Player.MaxHP != DefaultMaxHP || state.Player.HP != DefaultMaxHP {
+		t.Fatalf("expected HP reset to %d, got %d/%d", DefaultMaxHP, state.Player.HP, state.Player.MaxHP)
+	}
+	if state.Meta.Prestige != 1 {
+		t.Fatalf("expected prestige 1, got %d", state.Meta.Prestige)
+	}
+	if p, ok := events[0].(Prestiged); !ok || p.XPPercent != 105 {
+		t.Fatalf("expected Prestiged at 105%%, got %v", events)
+	}
+
+	// Default policy keeps gear and resets gold.
+	if state.Player.Inventory["orcish_blade"] != 1 {
+		t.Fatalf("expected inventory kept, got %v", state.Player.Inventory)
+	}
+	if state.Player.Gold != DefaultState().Player.Gold {
+		t.Fatalf("expected gold reset, got %d", state.Player.Gold)
+	}
+
+	events = GrantXP(&state, 40)
+	if g, ok := events[0].(XPGained); !ok || g.Amount != 42 {
+		t.Fatalf("expected 42 XP after bonus, got %v", events)
+	}
+	if state.Player.XP != 42 {
+		t.Fatalf("expected 42 XP, got %d", state.Player.XP)
+	}
+}
+
+func TestPrestige_RequiresMinimumLevel(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = PrestigeMinLevel - 1
+
+	if _, err := Prestige(&state); err == nil {
+		t.Fatal("expected error below the prestige level")
+	}
+	if state.Meta.Prestige != 0 || state.Player.Level != PrestigeMinLevel-1 {
+		t.Fatalf("state changed on failed prestige: %+v", state.Meta)
+	}
+}
+
+func TestPrestige_HonorsKeepPolicy(t *testing.T) {
+	saved := PrestigeKeep
+	defer func() { PrestigeKeep = saved }()
+	PrestigeKeep = PrestigeOptions{KeepGold: true}
+
+	state := DefaultState()
+	state.Player.Level = PrestigeMinLevel
+	state.Player.Gold = 900
+	state.Player.Inventory["orcish_blade"] = 1
+
+	if _, err := Prestige(&state); err != nil {
+		t.Fatalf("Prestige returned error: %v", err)
+	}
+	if state.Player.Gold != 900 {
+		t.Fatalf("expected gold kept, got %d", state.Player.Gold)
+	}
+	if state.Player.Inventory["orcish_blade"] != 0 {
+		t.Fatalf("expected inventory reset, got %v", state.Player.Inventory)
+	}
+}
@@ -0,0 +1,45 @@
+package engine
+
+// ================================
+// Event Priority
+// ================================
+
+// Event priorities, lowest first. UIs may alert the player on PriorityHigh.
+const (
+	PriorityLow = iota
+	PriorityNormal
+	PriorityHigh
+)
+
+// Priority classifies how much an event deserves the player's attention.
+// Level-ups, rare drops and defeat are high; blow-by-blow combat is low.
+func Priority(e Event) int {
+	switch ev := e.(type) {
+	case LevelUp, LevelsGained, PlayerDefeated:
+		return PriorityHigh
+	case ItemAdded:
+		if Items[NormalizeItemID(ev.ItemID)].Rare || ev.Affix != "" {
+			return PriorityHigh
+		}
+		return PriorityNormal
+	case ItemWorn:
+		if ev.Durability == 0 {
+			return PriorityNormal
+		}
+		return PriorityLow
+	case DamageDealt, XPGained, SPSpent, SPRegained, Hint:
+		return PriorityLow
+	default:
+		return PriorityNormal
+	}
+}
+
+// HighestPriority returns the highest priority among events, or
+// PriorityLow for none.
+func HighestPriority(events Events) int {
+	p := PriorityLow
+	for _, ev := range events {
+		p = max(p, Priority(ev))
+	}
+	return p
+}
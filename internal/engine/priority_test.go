@@ -0,0 +1,32 @@
+package engine
+
+import "testing"
+
+func TestPriority_ClassifiesEvents(t *testing.T) {
+	for _, tc := range []struct {
+		event Event
+		want  int
+	}{
+		{LevelUp{NewLevel: 2}, PriorityHigh},
+		{PlayerDefeated{}, PriorityHigh},
+		{ItemAdded{ItemID: "orcish_blade", Count: 1}, PriorityHigh},
+		{ItemAdded{ItemID: "torch", Count: 1}, PriorityNormal},
+		{GoldGained{Amount: 5}, PriorityNormal},
+		{DamageDealt{Amount: 3}, PriorityLow},
+		{XPGained{Amount: 10}, PriorityLow},
+	} {
+		if got := Priority(tc.event); got != tc.want {
+			t.Fatalf("%T: expected priority %d, got %d", tc.event, tc.want, got)
+		}
+	}
+}
+
+func TestHighestPriority(t *testing.T) {
+	if got := HighestPriority(nil); got != PriorityLow {
+		t.Fatalf("expected low for no events, got %d", got)
+	}
+	events := Events{DamageDealt{}, GoldGained{}, LevelUp{}}
+	if got := HighestPriority(events); got != PriorityHigh {
+		t.Fatalf("expected high, got %d", got)
+	}
+}
@@ -0,0 +1,41 @@
+package engine
+
+// ================================
+// RNG Streams
+// ================================
+
+// Named sub-streams of an RNGSet.
+const (
+	StreamSelection = "selection" // which enemies an encounter brings
+	StreamCombat    = "combat"    // hits, damage and drops
+)
+
+// RNGSet is an RNG that keeps named sub-streams alongside a master stream,
+// so tuning one concern doesn't shift the draws another sees: with
+// selection and combat split, a change to enemy weights leaves a seeded
+// fight's rolls untouched. Draws made on the set itself, and on any
+// stream it lacks, come from Master.
+type RNGSet struct {
+	Master  RNG
+	Streams map[string]RNG
+}
+
+func (s *RNGSet) Intn(n int) int   { return s.Master.Intn(n) }
+func (s *RNGSet) Float64() float64 { return s.Master.Float64() }
+
+// Stream returns the named sub-stream, or Master when there is none.
+func (s *RNGSet) Stream(name string) RNG {
+	if r, ok := s.Streams[name]; ok {
+		return r
+	}
+	return s.Master
+}
+
+// stream picks name's sub-stream when rng has them, as an RNGSet does.
+// Any other RNG is a single stream and is returned as is.
+func stream(rng RNG, name string) RNG {
+	if set, ok := rng.(interface{ Stream(string) RNG }); ok {
+		return set.Stream(name)
+	}
+	return rng
+}
@@ -0,0 +1,202 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ================================
+// Selling
+// ================================
+
+// SellJunk sells every stack of junk items at catalog price.
+func SellJunk(state *State) (Events, error) {
+	return sellMatching(state, func(it Item) bool { return it.Junk })
+}
+
+// SellAll sells every stack of items that have a price.
+func SellAll(state *State) (Events, error) {
+	return sellMatching(state, func(Item) bool { return true })
+}
+
+// sellMatching sells each priced stack match accepts, emitting one
+// ItemRemoved per stack followed by a single GoldGained for the total.
+func sellMatching(state *State, match func(Item) bool) (Events, error) {
+	events := Events{}
+
+	ids := make([]string, 0, len(state.Player.Inventory))
+	for id := range state.Player.Inventory {
+		if it, ok := Items[id]; ok && it.Price > 0 && match(it) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return events, errors.New("nothing to sell")
+	}
+	sort.Strings(ids)
+
+	total := 0
+	for _, id := range ids {
+		qty := state.Player.Inventory[id]
+		total += Items[id].Price * qty
+		events = append(events, RemoveItemWithEvent(&state.Player, id, qty)...)
+	}
+	state.Player.Gold += total
+	events = emit(events, GoldGained{Amount: total})
+
+	return events, nil
+}
+
+// ================================
+// Quotes & Haggling
+// ================================
+
+// Haggling enables `sell haggle`. Off by default, so selling never draws
+// from the RNG unless a game opts in.
+var Haggling = false
+
+// HaggleTuning shapes a haggle: the price moves by up to Swing percent
+// either way, and WalkChance is the chance the merchant walks away. After
+// a walkaway the merchant won't haggle over that item for Cooldown
+// commands, so a walkaway can't simply be retried.
+type HaggleTuning struct {
+	Swing      int     `json:"swing"`
+	WalkChance float64 `json:"walk_chance"`
+	Cooldown   int     `json:"cooldown"`
+}
+
+var HaggleTunables = HaggleTuning{Swing: 10, WalkChance: 0.15, Cooldown: 10}
+
+// QuotePrice is what qty of itemID sells for at catalog price; 0 if it
+// can't be sold.
+func QuotePrice(itemID string, qty int) int {
+	return Items[NormalizeItemID(itemID)].Price * max(qty, 0)
+}
+
+// Quote emits the price qty of itemID would sell for, changing nothing.
+func Quote(state *State, itemID string, qty int) (Events, error) {
+	itemID = NormalizeItemID(itemID)
+	if err := checkSellable(&state.Player, itemID, qty); err != nil {
+		return nil, err
+	}
+	return emit(nil, PriceQuoted{ItemID: itemID, Qty: qty, Price: QuotePrice(itemID, qty)}), nil
+}
+
+// Sell sells qty of itemID at its quoted price.
+func Sell(state *State, itemID string, qty int) (Events, error) {
+	itemID = NormalizeItemID(itemID)
+	if err := checkSellable(&state.Player, itemID, qty); err != nil {
+		return nil, err
+	}
+	return sellStack(state, itemID, qty, QuotePrice(itemID, qty)), nil
+}
+
+// Haggle sells qty of itemID after one haggle, drawing one rng.Float64:
+// below WalkChance the merchant walks away and nothing is sold; above it
+// the quote moves by -Swing..+Swing percent, higher rolls paying more.
+func Haggle(state *State, itemID string, qty int, rng RNG) (Events, error) {
+	if !Haggling {
+		return nil, errors.New("haggling is off")
+	}
+	itemID = NormalizeItemID(itemID)
+	if err := checkSellable(&state.Player, itemID, qty); err != nil {
+		return nil, err
+	}
+	ticks := state.Meta.CommandTicks
+	if until := state.Meta.HaggleBlocked[itemID]; ticks < until {
+		return nil, fmt.Errorf("the merchant won't haggle over %s for %d more commands", Items[itemID].Name, until-ticks)
+	}
+	delete(state.Meta.HaggleBlocked, itemID)
+
+	quote := QuotePrice(itemID, qty)
+	t := HaggleTunables
+	roll := rng.Float64()
+	if roll < t.WalkChance {
+		if t.Cooldown > 0 {
+			if state.Meta.HaggleBlocked == nil {
+				state.Meta.HaggleBlocked = map[string]int{}
+			}
+			// This command ticks once it succeeds, so the block covers
+			// the Cooldown commands after it.
+			state.Meta.HaggleBlocked[itemID] = ticks + 1 + t.Cooldown
+		}
+		return emit(nil, Haggled{ItemID: itemID, Qty: qty, Quote: quote, WalkedAway: true}), nil
+	}
+	span := (roll - t.WalkChance) / (1 - t.WalkChance)
+	pct := min(int(span*float64(2*t.Swing+1)), 2*t.Swing) - t.Swing
+	price := max(1, quote*(100+pct)/100)
+
+	events := emit(nil, Haggled{ItemID: itemID, Qty: qty, Quote: quote, Price: price})
+	return append(events, sellStack(state, itemID, qty, price)...), nil
+}
+
+// checkSellable reports why qty of itemID can't be sold, if it can't.
+func checkSellable(p *Player, itemID string, qty int) error {
+	it, ok := Items[itemID]
+	switch {
+	case !ok:
+		return fmt.Errorf("%w: %s", ErrUnknownItem, itemID)
+	case qty <= 0:
+		return errors.New("invalid quantity")
+	case !HasItem(p, itemID, qty):
+		return ErrItemNotFound
+	case it.Price <= 0:
+		return fmt.Errorf("%s can't be sold", it.Name)
+	}
+	return nil
+}
+
+// sellStack removes qty of itemID and pays price for the lot.
+func sellStack(state *State, itemID string, qty, price int) Events {
+	events := RemoveItemWithEvent(&state.Player, itemID, qty)
+	state.Player.Gold += price
+	return emit(events, GoldGained{Amount: price})
+}
+
+// ================================
+// Buying
+// ================================
+
+// BuyMarkup is what buying costs as a percent of an item's sell price.
+var BuyMarkup = 200
+
+// BuyPrice is what one itemID costs at BuyMarkup.
+func BuyPrice(itemID string) int {
+	return Items[NormalizeItemID(itemID)].Price * BuyMarkup / 100
+}
+
+// Buy pays price for one itemID, if the player can afford and carry it.
+func Buy(state *State, itemID string, price int, rng RNG) (Events, error) {
+	events := Events{}
+	p := &state.Player
+	itemID = NormalizeItemID(itemID)
+
+	if _, ok := Items[itemID]; !ok {
+		return events, fmt.Errorf("%w: %s", ErrUnknownItem, itemID)
+	}
+	if p.Gold < price {
+		return events, fmt.Errorf("%s costs %d gold", Items[itemID].Name, price)
+	}
+	if CarryRoom(p, itemID, 1) == 0 {
+		return events, errors.New("you can't carry any more")
+	}
+
+	p.Gold -= price
+	events = emit(events, GoldSpent{Amount: price})
+	return append(events, AddItemWithEvent(p, itemID, 1, rng)...), nil
+}
+
+// SellAt sells one itemID for price rather than its catalog price.
+func SellAt(state *State, itemID string, price int) (Events, error) {
+	events := Events{}
+	p := &state.Player
+	itemID = NormalizeItemID(itemID)
+
+	if !HasItem(p, itemID, 1) {
+		return events, ErrItemNotFound
+	}
+	events = append(events, RemoveItemWithEvent(p, itemID, 1)...)
+	p.Gold += price
+	return emit(events, GoldGained{Amount: price}), nil
+}
@@ -0,0 +1,146 @@
+package engine
+
+import "testing"
+
+func TestSellJunk_SellsOnlyJunk(t *testing.T) {
+	state := DefaultState()
+	state.Player.Gold = 0
+	state.Player.Inventory = map[string]int{
+		"rusty_dagger":   2,
+		"coin_pouch":     1,
+		"healing_potion": 3,
+		"orcish_blade":   1,
+	}
+
+	events, err := SellJunk(&state)
+	if err != nil {
+		t.Fatalf("SellJunk returned error: %v", err)
+	}
+
+	want := 2*Items["rusty_dagger"].Price + Items["coin_pouch"].Price
+	if state.Player.Gold != want {
+		t.Fatalf("expected %d gold, got %d", want, state.Player.Gold)
+	}
+	if HasItem(&state.Player, "rusty_dagger", 1) || HasItem(&state.Player, "coin_pouch", 1) {
+		t.Fatalf("expected junk sold, got %v", state.Player.Inventory)
+	}
+	if GetItemCount(&state.Player, "healing_potion") != 3 || GetItemCount(&state.Player, "orcish_blade") != 1 {
+		t.Fatalf("expected non-junk untouched, got %v", state.Player.Inventory)
+	}
+
+	// Two ItemRemoved, then one GoldGained.
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %v", events)
+	}
+	if g, ok := events[2].(GoldGained); !ok || g.Amount != want {
+		t.Fatalf("expected a single GoldGained of %d, got %v", want, events[2])
+	}
+}
+
+func TestSellJunk_NothingToSell(t *testing.T) {
+	state := DefaultState()
+	state.Player.Inventory = map[string]int{"healing_potion": 1}
+	if _, err := SellJunk(&state); err == nil {
+		t.Fatal("expected error with no junk")
+	}
+}
+
+func TestSellAll_SellsEveryPricedStack(t *testing.T) {
+	state := DefaultState()
+	state.Player.Gold = 0
+	state.Player.Inventory = map[string]int{"healing_potion": 2, "torch": 1}
+
+	if _, err := SellAll(&state); err != nil {
+		t.Fatalf("SellAll returned error: %v", err)
+	}
+	if len(state.Player.Inventory) != 0 {
+		t.Fatalf("expected empty inventory, got %v", state.Player.Inventory)
+	}
+	if want := 2*Items["healing_potion"].Price + Items["torch"].Price; state.Player.Gold != want {
+		t.Fatalf("expected %d gold, got %d", want, state.Player.Gold)
+	}
+}
+
+func TestHaggle_SuccessPaysMoreAndWalkawayPaysNothing(t *testing.T) {
+	old := Haggling
+	Haggling = true
+	defer func() { Haggling = old }()
+
+	quote := QuotePrice("orcish_blade", 2)
+	if quote != 2*Items["orcish_blade"].Price {
+		t.Fatalf("QuotePrice = %d", quote)
+	}
+
+	state := DefaultState()
+	state.Player.Gold = 0
+	state.Player.Inventory = map[string]int{"orcish_blade": 2}
+	if _, err := Haggle(&state, "orcish_blade", 2, &seqRNG{floats: []float64{0.99}}); err != nil {
+		t.Fatalf("Haggle: %v", err)
+	}
+	if state.Player.Gold <= quote || HasItem(&state.Player, "orcish_blade", 1) {
+		t.Fatalf("a good haggle should sell above %d, got %d gold and %v", quote, state.Player.Gold, state.Player.Inventory)
+	}
+
+	state = DefaultState()
+	state.Player.Gold = 0
+	state.Player.Inventory = map[string]int{"orcish_blade": 2}
+	events, err := Haggle(&state, "orcish_blade", 2, &seqRNG{floats: []float64{0}})
+	if err != nil {
+		t.Fatalf("Haggle: %v", err)
+	}
+	if h, ok := events[0].(Haggled); !ok || !h.WalkedAway {
+		t.Fatalf("expected the merchant to walk away, got %#v", events)
+	}
+	if state.Player.Gold != 0 || !HasItem(&state.Player, "orcish_blade", 2) {
+		t.Fatalf("a walkaway should sell nothing, got %d gold and %v", state.Player.Gold, state.Player.Inventory)
+	}
+}
+
+func TestHaggle_WalkawayBlocksThatItem(t *testing.T) {
+	oldHaggling, oldTuning := Haggling, HaggleTunables
+	Haggling = true
+	HaggleTunables.Cooldown = 2
+	defer func() { Haggling, HaggleTunables = oldHaggling, oldTuning }()
+
+	state := DefaultState()
+	state.Player.Inventory = map[string]int{"orcish_blade": 1, "rusty_dagger": 1}
+	if _, err := RunCommand(&state, "sell haggle orcish_blade", &seqRNG{floats: []float64{0}}); err != nil {
+		t.Fatalf("haggle: %v", err)
+	}
+	if _, err := RunCommand(&state, "sell haggle orcish_blade", &seqRNG{floats: []float64{0.99}}); err == nil {
+		t.Fatal("expected the merchant to refuse right after walking away")
+	}
+	if _, err := RunCommand(&state, "sell haggle rusty_dagger", &seqRNG{floats: []float64{0.99}}); err != nil {
+		t.Fatalf("other items should still haggle: %v", err)
+	}
+	if _, err := RunCommand(&state, "rest 1", &seqRNG{}); err != nil {
+		t.Fatalf("rest: %v", err)
+	}
+	if _, err := RunCommand(&state, "sell haggle orcish_blade", &seqRNG{floats: []float64{0.99}}); err != nil {
+		t.Fatalf("expected haggling again after the cooldown: %v", err)
+	}
+}
+
+func TestSellPrice_QuotesWithoutSelling(t *testing.T) {
+	state := DefaultState()
+	state.Player.Gold = 0
+	state.Player.Inventory = map[string]int{"rusty_dagger": 3}
+
+	events, err := RunCommand(&state, "sell price rusty_dagger 2", &seqRNG{})
+	if err != nil {
+		t.Fatalf("sell price: %v", err)
+	}
+	if q, ok := events[0].(PriceQuoted); !ok || q.Price != QuotePrice("rusty_dagger", 2) {
+		t.Fatalf("expected a quote, got %#v", events)
+	}
+	if state.Player.Gold != 0 || !HasItem(&state.Player, "rusty_dagger", 3) || state.Meta.CommandTicks != 0 {
+		t.Fatal("a quote should change nothing, not even pass a command tick")
+	}
+
+	if _, err := RunCommand(&state, "sell rusty_dagger 2", &seqRNG{}); err != nil {
+		t.Fatalf("sell: %v", err)
+	}
+	if state.Player.Gold != QuotePrice("rusty_dagger", 2) || GetItemCount(&state.Player, "rusty_dagger") != 1 {
+		t.Fatalf("expected two daggers sold at the quote, got %d gold and %v", state.Player.Gold, state.Player.Inventory)
+	}
+}
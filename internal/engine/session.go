@@ -0,0 +1,45 @@
+package engine
+
+import "time"
+
+// ================================
+// Session Summary
+// ================================
+
+// Summary is what changed over one play session.
+type Summary struct {
+	Commands int
+	Gold     int // net; negative when the session spent more than it earned
+	XP       int
+	Kills    int
+	Items    int // items gained, counting each inventory increase
+	Duration time.Duration
+}
+
+// SessionSummary compares the state at launch with the current one.
+func SessionSummary(start, current *State, started time.Time) Summary {
+	s := Summary{
+		Commands: current.Meta.CommandCount - start.Meta.CommandCount,
+		Gold:     current.Player.Gold - start.Player.Gold,
+		XP:       max(0, totalXP(&current.Player)-totalXP(&start.Player)),
+		Duration: time.Since(started),
+	}
+	for id, e := range current.Bestiary {
+		s.Kills += e.Killed - start.Bestiary[id].Killed
+	}
+	for id, qty := range current.Player.Inventory {
+		if gained := qty - start.Player.Inventory[id]; gained > 0 {
+			s.Items += gained
+		}
+	}
+	return s
+}
+
+// totalXP is all the XP behind the player's current level and progress.
+func totalXP(p *Player) int {
+	total := p.XP
+	for lvl := 1; lvl < p.Level; lvl++ {
+		total += XPToNext(lvl)
+	}
+	return total
+}
@@ -0,0 +1,31 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionSummary_Deltas(t *testing.T) {
+	start := DefaultState()
+	start.Player.XP = 80
+	start.Meta.CommandCount = 10
+	start.Bestiary = map[string]BestiaryEntry{"goblin": {Seen: 2, Killed: 2}}
+
+	current := start.Clone()
+	current.Meta.CommandCount = 25
+	current.Player.Gold = 120
+	current.Player.Level = 2
+	current.Player.XP = 30 // 20 to finish level 1, then 30 more
+	current.Player.Inventory["torch"] = 0
+	current.Player.Inventory["healing_potion"] = 2
+	current.Bestiary["goblin"] = BestiaryEntry{Seen: 5, Killed: 4}
+	current.Bestiary["wolf"] = BestiaryEntry{Seen: 1, Killed: 1}
+
+	s := SessionSummary(&start, current, time.Now().Add(-90*time.Minute))
+	if s.Commands != 15 || s.Gold != 70 || s.XP != 50 || s.Kills != 3 || s.Items != 2 {
+		t.Fatalf("unexpected summary %+v", s)
+	}
+	if s.Duration < 90*time.Minute {
+		t.Fatalf("expected at least 90m, got %v", s.Duration)
+	}
+}
@@ -0,0 +1,85 @@
+package engine
+
+// ================================
+// Character Sheet
+// ================================
+
+// Sheet is the player's derived stats: the numbers combat and progression
+// actually use, computed in one place.
+type Sheet struct {
+	Level    int
+	XP       int
+	XPToNext int
+
+	// AttackMin–AttackMax is the damage range of one player strike,
+	// including attack buffs. Defense is subtracted from each enemy hit.
+	AttackMin int
+	AttackMax int
+	Defense   int
+
+	Luck      int
+	XPPercent int // prestige XP multiplier
+
+	// Sets lists the IDs of fully equipped item sets.
+	Sets []string
+
+	// Fights and Wins come from the bestiary; WinRate is Wins/Fights, or 0
+	// before the first fight.
+	Fights  int
+	Wins    int
+	WinRate float64
+
+	// MaxOverkill is the player's biggest wasted killing blow.
+	MaxOverkill int
+}
+
+// AttackRange returns the damage range of one player strike: it scales
+// with level, plus attack from training, buffs, equipment and set bonuses.
+func AttackRange(p *Player) (int, int) {
+	bonus := TrainedBonus(p, StatAttack) + BuffTotal(p, StatAttack) + EquipTotal(p, StatAttack)
+	return 1 + p.Level + bonus, 2 + p.Level + bonus
+}
+
+// Defense returns how much damage is taken off each enemy hit: buffs,
+// equipment and set bonuses.
+func Defense(p *Player) int {
+	return BuffTotal(p, StatDefense) + EquipTotal(p, StatDefense)
+}
+
+// CharacterSheet computes the player's derived stats.
+func CharacterSheet(state *State) Sheet {
+	p := &state.Player
+	lo, hi := AttackRange(p)
+
+	s := Sheet{
+		Level:     p.Level,
+		XP:        p.XP,
+		XPToNext:  XPToNext(p.Level),
+		AttackMin: lo,
+		AttackMax: hi,
+		Defense:   Defense(p),
+		Luck:      p.Luck,
+		XPPercent: PrestigeXPPercent(state.Meta.Prestige),
+		Sets:      ActiveSets(p),
+
+		MaxOverkill: state.Meta.MaxOverkill,
+	}
+	for _, e := range state.Bestiary {
+		s.Fights += e.Seen
+		s.Wins += e.Killed
+	}
+	if s.Fights > 0 {
+		s.WinRate = float64(s.Wins) / float64(s.Fights)
+	}
+	return s
+}
+
+// RecordOverkill keeps Meta.MaxOverkill up to date from a command's player
+// hits.
+func RecordOverkill(state *State, events Events) {
+	for _, ev := range events {
+		if hit, ok := ev.(DamageDealt); ok && hit.Source == "player" {
+			state.Meta.MaxOverkill = max(state.Meta.MaxOverkill, hit.Overkill)
+		}
+	}
+}
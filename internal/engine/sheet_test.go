@@ -0,0 +1,42 @@
+package engine
+
+import "testing"
+
+func TestCharacterSheet_AttackMatchesCombat(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 4
+	AddBuff(&state.Player, Buff{Stat: StatAttack, Amount: 3, Remaining: 2})
+
+	sheet := CharacterSheet(&state)
+	if sheet.AttackMin != 8 || sheet.AttackMax != 9 {
+		t.Fatalf("expected attack 8-9, got %d-%d", sheet.AttackMin, sheet.AttackMax)
+	}
+
+	// A lowest and a highest roll against a sturdy dummy land exactly on
+	// the sheet's range.
+	for roll, want := range []int{sheet.AttackMin, sheet.AttackMax} {
+		s := cloneState(state)
+		dummy := EnemyTemplate{ID: "test_dummy", HP: 100}
+		_, events := ResolveCombat(&s, dummy, &seqRNG{ints: []int{roll}})
+		hit, ok := events[1].(DamageDealt)
+		if !ok || hit.Amount != want {
+			t.Fatalf("roll %d: expected a %d hit, got %v", roll, want, events[1])
+		}
+	}
+}
+
+func TestCharacterSheet_WinRateFromBestiary(t *testing.T) {
+	state := DefaultState()
+	if s := CharacterSheet(&state); s.WinRate != 0 || s.Fights != 0 {
+		t.Fatalf("expected no fights yet, got %+v", s)
+	}
+
+	state.Bestiary = map[string]BestiaryEntry{
+		"goblin": {Seen: 3, Killed: 3},
+		"orc":    {Seen: 1, Killed: 0},
+	}
+	s := CharacterSheet(&state)
+	if s.Fights != 4 || s.Wins != 3 || s.WinRate != 0.75 {
+		t.Fatalf("expected 3 of 4 won, got %+v", s)
+	}
+}
@@ -0,0 +1,75 @@
+package engine
+
+import "fmt"
+
+// ================================
+// Combat Simulation
+// ================================
+
+// SimMaxFights caps one Simulate run so a typo can't hang the game.
+const SimMaxFights = 10000
+
+// SimResult summarizes a Simulate run. Averages are per fight; only wins
+// pay XP and gold.
+type SimResult struct {
+	EnemyID    string
+	Fights     int
+	Wins       int
+	Losses     int
+	Stalemates int
+
+	AvgDamageTaken float64
+	AvgXP          float64
+	AvgGold        float64
+}
+
+// WinRate is the fraction of fights won.
+func (r SimResult) WinRate() float64 {
+	if r.Fights == 0 {
+		return 0
+	}
+	return float64(r.Wins) / float64(r.Fights)
+}
+
+// Simulate fights enemyID n times, each against a fresh clone of state, and
+// tallies the outcomes. state itself is never touched, and observers never
+// hear of the simulated fights.
+func Simulate(state *State, enemyID string, n int, rng RNG) (SimResult, error) {
+	enemy, ok := EnemyByID(enemyID)
+	if !ok {
+		return SimResult{}, fmt.Errorf("%w: %s", ErrUnknownEnemy, enemyID)
+	}
+	if n <= 0 || n > SimMaxFights {
+		return SimResult{}, fmt.Errorf("fights must be 1-%d", SimMaxFights)
+	}
+	if !state.Player.IsAlive() {
+		return SimResult{}, ErrPlayerDown
+	}
+
+	defer mute()()
+
+	res := SimResult{EnemyID: enemy.ID, Fights: n}
+	taken, xp, gold := 0, 0, 0
+	for range n {
+		result, events := ResolveCombat(state.Clone(), enemy, rng)
+		for _, ev := range events {
+			if d, ok := ev.(DamageDealt); ok && d.Target == "player" {
+				taken += d.Amount
+			}
+		}
+		switch result.Outcome {
+		case "win":
+			res.Wins++
+			xp += result.XP
+			gold += result.Gold
+		case "lose":
+			res.Losses++
+		default:
+			res.Stalemates++
+		}
+	}
+	res.AvgDamageTaken = float64(taken) / float64(n)
+	res.AvgXP = float64(xp) / float64(n)
+	res.AvgGold = float64(gold) / float64(n)
+	return res, nil
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSimulate_StrongPlayerAlwaysBeatsGoblin(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 20
+	state.Player.MaxHP = 500
+	state.Player.HP = 500
+	before := state.Clone()
+
+	res, err := Simulate(&state, "goblin", 50, rand.New(rand.NewSource(7)))
+	if err != nil {
+		t.Fatalf("Simulate: %v", err)
+	}
+	if res.Fights != 50 || res.WinRate() != 1 {
+		t.Fatalf("expected 50 wins out of 50, got %+v", res)
+	}
+	if res.AvgXP <= 0 || res.AvgGold <= 0 {
+		t.Fatalf("expected rewards averaged over wins, got %+v", res)
+	}
+	if !reflect.DeepEqual(&state, before) {
+		t.Fatal("Simulate mutated the real state")
+	}
+}
+
+func TestSimulate_RejectsBadInput(t *testing.T) {
+	state := DefaultState()
+	rng := rand.New(rand.NewSource(1))
+	if _, err := Simulate(&state, "dragon", 10, rng); err == nil {
+		t.Fatal("expected an unknown enemy to be refused")
+	}
+	if _, err := Simulate(&state, "goblin", 0, rng); err == nil {
+		t.Fatal("expected zero fights to be refused")
+	}
+}
+
+func TestSimulate_LeavesEventCountsUntouched(t *testing.T) {
+	stop := TrackEventCounts()
+	defer stop()
+
+	state := DefaultState()
+	state.Player.Gold = 0
+	if _, err := Simulate(&state, "goblin", 20, &seqRNG{}); err != nil {
+		t.Fatalf("Simulate: %v", err)
+	}
+	// A failing command still flushes whatever observers have gathered.
+	if _, err := RunCommand(&state, "train hp", &seqRNG{}); err == nil {
+		t.Fatal("expected train to fail without the gold")
+	}
+	if len(state.Meta.EventCounts) != 0 {
+		t.Fatalf("expected no counts from a simulation, got %v", state.Meta.EventCounts)
+	}
+}
@@ -9,6 +9,22 @@ package engine
 type State struct {
 	Player Player `json:"player"`
 	Meta   Meta   `json:"meta"`
+
+	// Bestiary records every enemy encountered, keyed by enemy ID.
+	Bestiary map[string]BestiaryEntry `json:"bestiary,omitempty"`
+
+	// LootLog holds the most recent items gained, oldest first, up to
+	// LootLogMax.
+	LootLog []LootRecord `json:"loot_log,omitempty"`
+
+	// Dungeon is the active dungeon run, if any.
+	Dungeon *DungeonRun `json:"dungeon,omitempty"`
+
+	// Battle is a targeted group fight in progress, if any.
+	Battle *Battle `json:"battle,omitempty"`
+
+	// Pending is a choice waiting on the player, if any.
+	Pending *PendingChoice `json:"pending,omitempty"`
 }
 
 // ================================
@@ -22,9 +38,37 @@ type Player struct {
 	HP        int            `json:"hp"`
 	MaxHP     int            `json:"max_hp"`
 	SP        int            `json:"sp"`
+	MaxSP     int            `json:"max_sp"`
 	Level     int            `json:"level"`
 	XP        int            `json:"xp"`
+	Luck      int            `json:"luck,omitempty"`
+	Speed     int            `json:"speed,omitempty"`
 	Inventory map[string]int `json:"inventory"` // item_id -> count
+	Buffs     []Buff         `json:"buffs,omitempty"`
+
+	// BankedGold is gold deposited at the bank: it earns interest and is
+	// out of reach of revive fees.
+	BankedGold int `json:"banked_gold,omitempty"`
+
+	// MaxCarryWeight caps total item weight; 0 disables the limit.
+	MaxCarryWeight int `json:"max_carry_weight,omitempty"`
+
+	// Equipment maps a slot to the item worn there. Equipped items are
+	// out of the inventory.
+	Equipment map[string]string `json:"equipment,omitempty"`
+
+	// Wear counts durability lost per item ID. Copies in a stack share one
+	// entry; `repair` clears it, and so does the last copy leaving.
+	Wear map[string]int `json:"wear,omitempty"`
+
+	// Trained counts how many times `train` has raised each stat.
+	Trained map[string]int `json:"trained,omitempty"`
+
+	// Affixes lists the affix of each affixed copy of an item in the
+	// inventory; copies beyond the list are plain. EquippedAffixes maps a
+	// slot to the affix of the item worn there.
+	Affixes         map[string][]string `json:"affixes,omitempty"`
+	EquippedAffixes map[string]string   `json:"equipped_affixes,omitempty"`
 }
 
 // ================================
@@ -35,6 +79,82 @@ type Meta struct {
 	Location        string `json:"location"`
 	QuestsCompleted int    `json:"quests_completed"`
 	CommandCount    int    `json:"command_count"`
+
+	// SPRegenCounter counts non-combat commands toward the next SP regen.
+	SPRegenCounter int `json:"sp_regen_counter,omitempty"`
+
+	// World is the active world modifier ID ("" means neutral) and
+	// WorldTicks counts commands toward the next rotation.
+	World      string `json:"world,omitempty"`
+	WorldTicks int    `json:"world_ticks,omitempty"`
+
+	// TimeOfDay is the day/night phase ("" means neutral) and TimeTicks
+	// counts commands toward the next change.
+	TimeOfDay string `json:"time_of_day,omitempty"`
+	TimeTicks int    `json:"time_ticks,omitempty"`
+
+	// InterestTicks counts commands with gold banked toward the next
+	// interest payment.
+	InterestTicks int `json:"interest_ticks,omitempty"`
+
+	// CommandTicks counts every successful command, and LastUsed holds
+	// the tick each cooldown item was last used at.
+	CommandTicks int            `json:"command_ticks,omitempty"`
+	LastUsed     map[string]int `json:"last_used,omitempty"`
+
+	// TargetedCombat makes multi-enemy encounters wait for an `attack <n>`
+	// each round instead of resolving in one go. Single enemies and dungeon
+	// fights always resolve immediately.
+	TargetedCombat bool `json:"targeted_combat,omitempty"`
+
+	// MaxOverkill is the most damage ever wasted past a killing blow.
+	MaxOverkill int `json:"max_overkill,omitempty"`
+
+	// Prestige counts how many times the player has prestiged.
+	Prestige int `json:"prestige,omitempty"`
+
+	// Achievements is the set of unlocked achievement IDs.
+	Achievements map[string]bool `json:"achievements,omitempty"`
+
+	// RNG stream position, so a seeded game replays identically after reload.
+	RNGSeed  int64 `json:"rng_seed,omitempty"`
+	RNGDraws int64 `json:"rng_draws,omitempty"`
+
+	// RNGStreams holds the draws made on each named sub-stream when the
+	// RNG is split; see RNGSet.
+	RNGStreams map[string]int64 `json:"rng_streams,omitempty"`
+
+	// Hints holds the command tick each tutorial hint was last shown at,
+	// or -1 once the player has used what it teaches.
+	Hints map[string]int `json:"hints,omitempty"`
+
+	// DropMisses counts consecutive missed drop rolls per item while
+	// LootPity is on.
+	DropMisses map[string]int `json:"drop_misses,omitempty"`
+
+	// HaggleBlocked holds, per item, the command tick until which the
+	// merchant refuses to haggle after walking away.
+	HaggleBlocked map[string]int `json:"haggle_blocked,omitempty"`
+
+	// EventCounts tallies every event the save has seen by EventType; see
+	// TrackEventCounts.
+	EventCounts map[string]int `json:"event_counts,omitempty"`
+
+	// SchemaVersion is the save layout this state was written with.
+	SchemaVersion int `json:"schema_version,omitempty"`
+}
+
+// ================================
+// Bestiary
+// ================================
+
+// BestiaryEntry is the player's record of one enemy type. FirstSeen and
+// LastSeen are command counts.
+type BestiaryEntry struct {
+	Seen      int `json:"seen"`
+	Killed    int `json:"killed"`
+	FirstSeen int `json:"first_seen"`
+	LastSeen  int `json:"last_seen"`
 }
 
 // ================================
@@ -43,13 +163,41 @@ type Meta struct {
 
 const (
 	DefaultMaxHP = 100
+	DefaultMaxSP = 10
 
 	HuntBaseSP      = 1
 	HuntExtraSPMax  = 5
 	RestHPPerSP     = 25
 	RestockInterval = 20
+
+	// LuckChancePerPoint is added to drop and find chances per point of luck.
+	LuckChancePerPoint = 0.02
+
+	// Pack-forming enemies come in groups with PackChance from PackMinLevel.
+	PackMinLevel = 3
+	PackChance   = 0.3
+)
+
+// Camp and revive tuning; config files may override them (see Tunables).
+var (
+	CampHP           = 15
+	CampSP           = 2
+	CampAmbushChance = 0.25
+
+	ReviveBaseCost     = 20
+	ReviveCostPerLevel = 10
+	RevivePercentHP    = 50
 )
 
+// SchemaVersion is the save layout this build reads and writes. Saves
+// from before versioning have no schema_version and count as 1; the
+// adapters migrate older saves up to this on load.
+const SchemaVersion = 2
+
+// SPRegenInterval is how many non-combat commands it takes to regenerate
+// 1 SP. It is a variable so it can be tuned; zero disables regen.
+var SPRegenInterval = 3
+
 // DefaultState returns a fully initialized game state.
 // Engine code assumes this is the canonical zero-state.
 func DefaultState() State {
@@ -60,7 +208,8 @@ func DefaultState() State {
 			Gold:  50,
 			HP:    DefaultMaxHP,
 			MaxHP: DefaultMaxHP,
-			SP:    10,
+			SP:    DefaultMaxSP,
+			MaxSP: DefaultMaxSP,
 			Level: 1,
 			XP:    0,
 			Inventory: map[string]int{
@@ -72,6 +221,7 @@ func DefaultState() State {
 			Location:        "Starting Village",
 			QuestsCompleted: 0,
 			CommandCount:    0,
+			SchemaVersion:   SchemaVersion,
 		},
 	}
 }
@@ -95,9 +245,135 @@ func (p *Player) ClampHP() {
 	}
 }
 
+// ClampSP ensures SP does not exceed MaxSP or fall below zero.
+func (p *Player) ClampSP() {
+	if p.SP < 0 {
+		p.SP = 0
+	}
+	if p.SP > p.MaxSP {
+		p.SP = p.MaxSP
+	}
+}
+
 // EnsureInventory guarantees the inventory map exists.
 func (p *Player) EnsureInventory() {
 	if p.Inventory == nil {
 		p.Inventory = make(map[string]int)
 	}
 }
+
+// Clone returns a deep copy of s: every map, slice and pointer is copied,
+// so mutating the clone never touches s.
+func (s *State) Clone() *State {
+	out := cloneState(*s)
+	return &out
+}
+
+// cloneState returns a deep copy of s so the copy never shares a map with
+// the original.
+func cloneState(s State) State {
+	out := s
+	out.Player.Inventory = make(map[string]int, len(s.Player.Inventory))
+	for id, qty := range s.Player.Inventory {
+		out.Player.Inventory[id] = qty
+	}
+	out.Player.Buffs = append([]Buff(nil), s.Player.Buffs...)
+	if s.Player.Equipment != nil {
+		out.Player.Equipment = make(map[string]string, len(s.Player.Equipment))
+		for slot, id := range s.Player.Equipment {
+			out.Player.Equipment[slot] = id
+		}
+	}
+	if s.Player.Affixes != nil {
+		out.Player.Affixes = make(map[string][]string, len(s.Player.Affixes))
+		for id, affixes := range s.Player.Affixes {
+			out.Player.Affixes[id] = append([]string(nil), affixes...)
+		}
+	}
+	if s.Player.EquippedAffixes != nil {
+		out.Player.EquippedAffixes = make(map[string]string, len(s.Player.EquippedAffixes))
+		for slot, affix := range s.Player.EquippedAffixes {
+			out.Player.EquippedAffixes[slot] = affix
+		}
+	}
+	if s.Player.Wear != nil {
+		out.Player.Wear = make(map[string]int, len(s.Player.Wear))
+		for id, n := range s.Player.Wear {
+			out.Player.Wear[id] = n
+		}
+	}
+	if s.Player.Trained != nil {
+		out.Player.Trained = make(map[string]int, len(s.Player.Trained))
+		for stat, n := range s.Player.Trained {
+			out.Player.Trained[stat] = n
+		}
+	}
+	if s.Dungeon != nil {
+		run := *s.Dungeon
+		run.Loot = append([]string(nil), s.Dungeon.Loot...)
+		out.Dungeon = &run
+	}
+	if s.Battle != nil {
+		b := *s.Battle
+		b.Foes = append([]Foe(nil), s.Battle.Foes...)
+		b.Loot = append([]string(nil), s.Battle.Loot...)
+		b.StolenItems = append([]string(nil), s.Battle.StolenItems...)
+		out.Battle = &b
+	}
+	if s.Pending != nil {
+		choice := *s.Pending
+		out.Pending = &choice
+	}
+	if s.LootLog != nil {
+		out.LootLog = append([]LootRecord(nil), s.LootLog...)
+	}
+	if s.Bestiary != nil {
+		out.Bestiary = make(map[string]BestiaryEntry, len(s.Bestiary))
+		for id, e := range s.Bestiary {
+			out.Bestiary[id] = e
+		}
+	}
+	if s.Meta.Hints != nil {
+		out.Meta.Hints = make(map[string]int, len(s.Meta.Hints))
+		for id, tick := range s.Meta.Hints {
+			out.Meta.Hints[id] = tick
+		}
+	}
+	if s.Meta.DropMisses != nil {
+		out.Meta.DropMisses = make(map[string]int, len(s.Meta.DropMisses))
+		for id, n := range s.Meta.DropMisses {
+			out.Meta.DropMisses[id] = n
+		}
+	}
+	if s.Meta.HaggleBlocked != nil {
+		out.Meta.HaggleBlocked = make(map[string]int, len(s.Meta.HaggleBlocked))
+		for id, tick := range s.Meta.HaggleBlocked {
+			out.Meta.HaggleBlocked[id] = tick
+		}
+	}
+	if s.Meta.EventCounts != nil {
+		out.Meta.EventCounts = make(map[string]int, len(s.Meta.EventCounts))
+		for t, n := range s.Meta.EventCounts {
+			out.Meta.EventCounts[t] = n
+		}
+	}
+	if s.Meta.RNGStreams != nil {
+		out.Meta.RNGStreams = make(map[string]int64, len(s.Meta.RNGStreams))
+		for name, draws := range s.Meta.RNGStreams {
+			out.Meta.RNGStreams[name] = draws
+		}
+	}
+	if s.Meta.LastUsed != nil {
+		out.Meta.LastUsed = make(map[string]int, len(s.Meta.LastUsed))
+		for id, tick := range s.Meta.LastUsed {
+			out.Meta.LastUsed[id] = tick
+		}
+	}
+	if s.Meta.Achievements != nil {
+		out.Meta.Achievements = make(map[string]bool, len(s.Meta.Achievements))
+		for id, ok := range s.Meta.Achievements {
+			out.Meta.Achievements[id] = ok
+		}
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+package engine
+
+import "testing"
+
+func TestClone_DoesNotAliasOriginal(t *testing.T) {
+	state := DefaultState()
+	state.Player.Buffs = []Buff{{Stat: "attack", Amount: 2, Remaining: 2}}
+	state.Player.Equipment = map[string]string{"weapon": "rusty_dagger"}
+	state.Meta.Achievements = map[string]bool{"first_blood": true}
+
+	clone := state.Clone()
+	clone.Player.Gold += 100
+	clone.Player.HP = 1
+	clone.Player.Inventory["torch"] = 9
+	clone.Player.Inventory["healing_potion"] = 1
+	clone.Player.Buffs[0].Remaining = 0
+	clone.Player.Equipment["weapon"] = "orcish_blade"
+	clone.Meta.Achievements["rich"] = true
+
+	if state.Player.Gold != 50 || state.Player.HP != DefaultMaxHP {
+		t.Fatalf("clone stats leaked: gold %d, hp %d", state.Player.Gold, state.Player.HP)
+	}
+	if state.Player.Inventory["torch"] != 1 {
+		t.Fatalf("clone inventory leaked: torch x%d", state.Player.Inventory["torch"])
+	}
+	if _, ok := state.Player.Inventory["healing_potion"]; ok {
+		t.Fatalf("clone inventory added a key to the original")
+	}
+	if state.Player.Buffs[0].Remaining != 2 {
+		t.Fatalf("clone buffs leaked: %+v", state.Player.Buffs)
+	}
+	if state.Player.Equipment["weapon"] != "rusty_dagger" {
+		t.Fatalf("clone equipment leaked: %v", state.Player.Equipment)
+	}
+	if state.Meta.Achievements["rich"] {
+		t.Fatalf("clone achievements leaked: %v", state.Meta.Achievements)
+	}
+}
@@ -0,0 +1,47 @@
+package engine
+
+import "sort"
+
+// ================================
+// Theft
+// ================================
+
+// stealFrom rolls enemy's StealChance after one of its strikes. A thief
+// takes up to StealAmount gold, or one random item from the pack if there
+// is no gold to take. Enemies with no StealChance draw nothing from rng.
+// A stolen item loses its affix the way a sold one does: plain copies go
+// first, and recoverStolen hands back a plain copy.
+func stealFrom(player *Player, enemy EnemyTemplate, rng RNG) (Stolen, bool) {
+	if enemy.StealChance <= 0 || rng.Float64() >= enemy.StealChance {
+		return Stolen{}, false
+	}
+	if gold := min(enemy.StealAmount, player.Gold); gold > 0 {
+		player.Gold -= gold
+		return Stolen{EnemyID: enemy.ID, Gold: gold}, true
+	}
+
+	ids := make([]string, 0, len(player.Inventory))
+	for id := range player.Inventory {
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return Stolen{}, false
+	}
+	sort.Strings(ids)
+	id := ids[rng.Intn(len(ids))]
+	RemoveItem(player, id, 1)
+	return Stolen{EnemyID: enemy.ID, ItemID: id}, true
+}
+
+// recoverStolen hands back whatever was stolen during a fight the player
+// went on to win.
+func recoverStolen(player *Player, gold int, items []string) Events {
+	if gold == 0 && len(items) == 0 {
+		return nil
+	}
+	player.Gold += gold
+	for _, id := range items {
+		AddItem(player, id, 1)
+	}
+	return emit(nil, StolenRecovered{Gold: gold, Items: items})
+}
@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ================================
+// Training
+// ================================
+
+// Trainable stats besides StatAttack.
+const (
+	StatHP = "hp"
+	StatSP = "sp"
+)
+
+const (
+	// TrainBaseCost is the gold the first training of a stat costs; each
+	// further training of that stat costs another TrainBaseCost.
+	TrainBaseCost = 50
+
+	// Per-training gains.
+	TrainHPAmount     = 5
+	TrainSPAmount     = 1
+	TrainAttackAmount = 1
+)
+
+// trainGain maps each trainable stat to its per-training gain.
+var trainGain = map[string]int{
+	StatHP:     TrainHPAmount,
+	StatSP:     TrainSPAmount,
+	StatAttack: TrainAttackAmount,
+}
+
+// TrainCost returns the gold the next training of stat costs.
+func TrainCost(p *Player, stat string) int {
+	return TrainBaseCost * (1 + p.Trained[stat])
+}
+
+// TrainedBonus returns the permanent bonus training has added to stat.
+func TrainedBonus(p *Player, stat string) int {
+	return p.Trained[stat] * trainGain[stat]
+}
+
+// Train spends gold on a small permanent boost to hp (MaxHP), sp (MaxSP)
+// or attack. Only possible in town.
+func Train(state *State, stat string) (Events, error) {
+	events := Events{}
+	p := &state.Player
+
+	gain, ok := trainGain[stat]
+	if !ok {
+		return events, fmt.Errorf("can't train %q: choose hp, sp or attack", stat)
+	}
+	if !p.IsAlive() {
+		return events, ErrPlayerDown
+	}
+	if state.Dungeon != nil {
+		return events, errors.New("you can only train in town; leave the dungeon first")
+	}
+	cost := TrainCost(p, stat)
+	if p.Gold < cost {
+		return events, fmt.Errorf("training %s costs %d gold", stat, cost)
+	}
+
+	p.Gold -= cost
+	if p.Trained == nil {
+		p.Trained = map[string]int{}
+	}
+	p.Trained[stat]++
+	switch stat {
+	case StatHP:
+		p.MaxHP += gain
+		p.HP += gain
+	case StatSP:
+		p.MaxSP += gain
+		p.SP += gain
+	}
+
+	events = emit(events,
+		GoldSpent{Amount: cost},
+		StatTrained{Stat: stat, Amount: gain, Times: p.Trained[stat]},
+	)
+	return events, nil
+}
@@ -0,0 +1,50 @@
+package engine
+
+import "testing"
+
+func TestTrain_RaisesMaxHPWithRisingCost(t *testing.T) {
+	state := DefaultState()
+	state.Player.Gold = 1000
+	maxHP := state.Player.MaxHP
+
+	events, err := Train(&state, StatHP)
+	if err != nil {
+		t.Fatalf("Train returned error: %v", err)
+	}
+	if state.Player.MaxHP != maxHP+TrainHPAmount {
+		t.Fatalf("expected MaxHP %d, got %d", maxHP+TrainHPAmount, state.Player.MaxHP)
+	}
+	if spent, ok := events[0].(GoldSpent); !ok || spent.Amount != TrainBaseCost {
+		t.Fatalf("expected GoldSpent %d first, got %#v", TrainBaseCost, events[0])
+	}
+	if trained, ok := events[1].(StatTrained); !ok || trained.Stat != StatHP || trained.Times != 1 {
+		t.Fatalf("expected StatTrained for hp, got %#v", events[1])
+	}
+	if got := TrainCost(&state.Player, StatHP); got != 2*TrainBaseCost {
+		t.Fatalf("expected the second training to cost %d, got %d", 2*TrainBaseCost, got)
+	}
+
+	lo, _ := AttackRange(&state.Player)
+	if _, err := RunCommand(&state, "train attack", &seqRNG{}); err != nil {
+		t.Fatalf("train attack: %v", err)
+	}
+	if got, _ := AttackRange(&state.Player); got != lo+TrainAttackAmount {
+		t.Fatalf("expected attack +%d, got %d from %d", TrainAttackAmount, got, lo)
+	}
+}
+
+func TestTrain_RejectsShortGoldAndUnknownStats(t *testing.T) {
+	state := DefaultState()
+	state.Player.Gold = TrainBaseCost - 1
+	maxHP := state.Player.MaxHP
+
+	if _, err := Train(&state, StatHP); err == nil {
+		t.Fatal("expected an error with too little gold")
+	}
+	if state.Player.Gold != TrainBaseCost-1 || state.Player.MaxHP != maxHP {
+		t.Fatalf("expected nothing spent or gained, got gold=%d maxHP=%d", state.Player.Gold, state.Player.MaxHP)
+	}
+	if _, err := Train(&state, "luck"); err == nil {
+		t.Fatal("expected an error for an untrainable stat")
+	}
+}
@@ -0,0 +1,168 @@
+package engine
+
+import "fmt"
+
+// ================================
+// Tunables
+// ================================
+
+// Tunables gathers the package-level tunables a config file may override.
+// Each field mirrors the variable of the same name; CurrentTunables reads
+// them and ApplyTunables writes them back.
+type Tunables struct {
+	XPPercent           int     `json:"xp_percent"`
+	EncounterRate       int     `json:"encounter_rate"`
+	RetreatXPPerLevel   int     `json:"retreat_xp_per_level"`
+	MaxCombatTurns      int     `json:"max_combat_turns"`
+	PityStep            float64 `json:"pity_step"`
+	SPRegenInterval     int     `json:"sp_regen_interval"`
+	InterestInterval    int     `json:"interest_interval"`
+	InterestPercent     int     `json:"interest_percent"`
+	DayNightInterval    int     `json:"day_night_interval"`
+	WorldRotateInterval int     `json:"world_rotate_interval"`
+	BuyMarkup           int     `json:"buy_markup"`
+	LootLogMax          int     `json:"loot_log_max"`
+	TreasureChoiceGold  int     `json:"treasure_choice_gold"`
+	CampHP              int     `json:"camp_hp"`
+	CampSP              int     `json:"camp_sp"`
+	CampAmbushChance    float64 `json:"camp_ambush_chance"`
+	ReviveBaseCost      int     `json:"revive_base_cost"`
+	ReviveCostPerLevel  int     `json:"revive_cost_per_level"`
+	RevivePercentHP     int     `json:"revive_percent_hp"`
+
+	// Grouped tunings mirror the *Tunables variables, e.g. Hunt is
+	// HuntTunables.
+	Hunt     HuntTuning     `json:"hunt"`
+	AntiFarm AntiFarmTuning `json:"anti_farm"`
+	Merchant MerchantTuning `json:"merchant"`
+	Haggle   HaggleTuning   `json:"haggle"`
+}
+
+// defaultTunables are the built-in tunables, captured before any config
+// file is applied.
+var defaultTunables = CurrentTunables()
+
+// DefaultTunables returns the built-in tunables.
+func DefaultTunables() Tunables {
+	return defaultTunables
+}
+
+// CurrentTunables returns the tunables as they are set now.
+func CurrentTunables() Tunables {
+	return Tunables{
+		XPPercent:           XPPercent,
+		EncounterRate:       EncounterRate,
+		RetreatXPPerLevel:   RetreatXPPerLevel,
+		MaxCombatTurns:      MaxCombatTurns,
+		PityStep:            PityStep,
+		SPRegenInterval:     SPRegenInterval,
+		InterestInterval:    InterestInterval,
+		InterestPercent:     InterestPercent,
+		DayNightInterval:    DayNightInterval,
+		WorldRotateInterval: WorldRotateInterval,
+		BuyMarkup:           BuyMarkup,
+		LootLogMax:          LootLogMax,
+		TreasureChoiceGold:  TreasureChoiceGold,
+		CampHP:              CampHP,
+		CampSP:              CampSP,
+		CampAmbushChance:    CampAmbushChance,
+		ReviveBaseCost:      ReviveBaseCost,
+		ReviveCostPerLevel:  ReviveCostPerLevel,
+		RevivePercentHP:     RevivePercentHP,
+		Hunt:                HuntTunables,
+		AntiFarm:            AntiFarmTunables,
+		Merchant:            MerchantTunables,
+		Haggle:              HaggleTunables,
+	}
+}
+
+// ApplyTunables sets every tunable from t. Validate t first.
+func ApplyTunables(t Tunables) {
+	XPPercent = t.XPPercent
+	EncounterRate = t.EncounterRate
+	RetreatXPPerLevel = t.RetreatXPPerLevel
+	MaxCombatTurns = t.MaxCombatTurns
+	PityStep = t.PityStep
+	SPRegenInterval = t.SPRegenInterval
+	InterestInterval = t.InterestInterval
+	InterestPercent = t.InterestPercent
+	DayNightInterval = t.DayNightInterval
+	WorldRotateInterval = t.WorldRotateInterval
+	BuyMarkup = t.BuyMarkup
+	LootLogMax = t.LootLogMax
+	TreasureChoiceGold = t.TreasureChoiceGold
+	CampHP = t.CampHP
+	CampSP = t.CampSP
+	CampAmbushChance = t.CampAmbushChance
+	ReviveBaseCost = t.ReviveBaseCost
+	ReviveCostPerLevel = t.ReviveCostPerLevel
+	RevivePercentHP = t.RevivePercentHP
+	HuntTunables = t.Hunt
+	AntiFarmTunables = t.AntiFarm
+	MerchantTunables = t.Merchant
+	HaggleTunables = t.Haggle
+}
+
+// ValidateTunables reports every value in t the engine can't run with:
+// percentages outside their range, negative intervals and a combat turn
+// limit or loot log too small to be useful. Intervals may be 0, which
+// disables what they pace, as does a treasure_choice_gold of 0.
+func ValidateTunables(t Tunables) []error {
+	var errs []error
+	chance := func(name string, v float64) {
+		if v < 0 || v > 1 {
+			errs = append(errs, fmt.Errorf("%s %v is outside 0-1", name, v))
+		}
+	}
+	inRange := func(name string, v, lo, hi int) {
+		if v < lo || v > hi {
+			errs = append(errs, fmt.Errorf("%s %d is outside %d-%d", name, v, lo, hi))
+		}
+	}
+	notNegative := func(name string, v int) {
+		if v < 0 {
+			errs = append(errs, fmt.Errorf("%s %d is negative", name, v))
+		}
+	}
+
+	inRange("xp_percent", t.XPPercent, 1, 1000)
+	inRange("encounter_rate", t.EncounterRate, 0, 100)
+	notNegative("retreat_xp_per_level", t.RetreatXPPerLevel)
+	if t.MaxCombatTurns < 1 {
+		errs = append(errs, fmt.Errorf("max_combat_turns %d is below 1", t.MaxCombatTurns))
+	}
+	chance("pity_step", t.PityStep)
+	notNegative("sp_regen_interval", t.SPRegenInterval)
+	notNegative("interest_interval", t.InterestInterval)
+	inRange("interest_percent", t.InterestPercent, 0, 100)
+	notNegative("day_night_interval", t.DayNightInterval)
+	notNegative("world_rotate_interval", t.WorldRotateInterval)
+	if t.BuyMarkup < 100 {
+		errs = append(errs, fmt.Errorf("buy_markup %d is below 100, so buying would pay more than selling", t.BuyMarkup))
+	}
+	if t.LootLogMax < 1 {
+		errs = append(errs, fmt.Errorf("loot_log_max %d is below 1", t.LootLogMax))
+	}
+	notNegative("treasure_choice_gold", t.TreasureChoiceGold)
+	notNegative("camp_hp", t.CampHP)
+	notNegative("camp_sp", t.CampSP)
+	chance("camp_ambush_chance", t.CampAmbushChance)
+	notNegative("revive_base_cost", t.ReviveBaseCost)
+	notNegative("revive_cost_per_level", t.ReviveCostPerLevel)
+	inRange("revive_percent_hp", t.RevivePercentHP, 1, 100)
+
+	if t.Hunt.RewardPerSP < 0 {
+		errs = append(errs, fmt.Errorf("hunt.reward_per_sp %v is negative", t.Hunt.RewardPerSP))
+	}
+	notNegative("hunt.weight_per_sp", t.Hunt.WeightPerSP)
+	notNegative("anti_farm.free_levels", t.AntiFarm.FreeLevels)
+	chance("anti_farm.step", t.AntiFarm.Step)
+	chance("anti_farm.floor", t.AntiFarm.Floor)
+	inRange("merchant.chance", t.Merchant.Chance, 0, 100)
+	inRange("merchant.discount", t.Merchant.Discount, 0, 100)
+	notNegative("merchant.premium", t.Merchant.Premium)
+	inRange("haggle.swing", t.Haggle.Swing, 0, 100)
+	chance("haggle.walk_chance", t.Haggle.WalkChance)
+	notNegative("haggle.cooldown", t.Haggle.Cooldown)
+	return errs
+}
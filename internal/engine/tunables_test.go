@@ -0,0 +1,28 @@
+package engine
+
+import "testing"
+
+func TestValidateTunables_DefaultsAreValid(t *testing.T) {
+	if errs := ValidateTunables(CurrentTunables()); len(errs) > 0 {
+		t.Fatalf("expected the built-in tunables to validate, got %v", errs)
+	}
+}
+
+func TestApplyTunables_RoundTrips(t *testing.T) {
+	defer ApplyTunables(CurrentTunables())
+
+	want := CurrentTunables()
+	want.XPPercent, want.EncounterRate = 150, 35
+	want.TreasureChoiceGold = 40
+	want.CampHP, want.CampAmbushChance, want.RevivePercentHP = 30, 0.1, 75
+	want.Hunt.RewardPerSP, want.AntiFarm.Floor = 0.5, 0.5
+	want.Merchant.Chance, want.Haggle.Cooldown = 10, 3
+	ApplyTunables(want)
+	if got := CurrentTunables(); got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	if CampHP != 30 || HuntTunables.RewardPerSP != 0.5 || MerchantTunables.Chance != 10 || HaggleTunables.Cooldown != 3 {
+		t.Fatalf("expected the variables set, got camp %d hunt %+v merchant %+v haggle %+v",
+			CampHP, HuntTunables, MerchantTunables, HaggleTunables)
+	}
+}
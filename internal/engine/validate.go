@@ -0,0 +1,208 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ================================
+// State Validation
+// ================================
+
+// ValidateState reports every broken invariant in s: level ≥ 1, MaxHP ≥ 1,
+// 0 ≤ HP ≤ MaxHP, 0 ≤ SP ≤ MaxSP, non-negative gold and XP, and positive
+// inventory counts, and an active dungeon run that exists and is on one of
+// its stages. It does not modify s.
+func ValidateState(s *State) []error {
+	var errs []error
+	p := &s.Player
+
+	if p.Level < 1 {
+		errs = append(errs, fmt.Errorf("level %d is below 1", p.Level))
+	}
+	if p.MaxHP < 1 {
+		errs = append(errs, fmt.Errorf("max HP %d is below 1", p.MaxHP))
+	}
+	if p.HP < 0 {
+		errs = append(errs, fmt.Errorf("HP %d is negative", p.HP))
+	}
+	if p.HP > p.MaxHP {
+		errs = append(errs, fmt.Errorf("HP %d exceeds max HP %d", p.HP, p.MaxHP))
+	}
+	if p.MaxSP < 0 {
+		errs = append(errs, fmt.Errorf("max SP %d is negative", p.MaxSP))
+	}
+	if p.SP < 0 {
+		errs = append(errs, fmt.Errorf("SP %d is negative", p.SP))
+	}
+	if p.SP > p.MaxSP {
+		errs = append(errs, fmt.Errorf("SP %d exceeds max SP %d", p.SP, p.MaxSP))
+	}
+	if p.Gold < 0 {
+		errs = append(errs, fmt.Errorf("gold %d is negative", p.Gold))
+	}
+	if p.XP < 0 {
+		errs = append(errs, fmt.Errorf("XP %d is negative", p.XP))
+	}
+
+	ids := make([]string, 0, len(p.Inventory))
+	for id := range p.Inventory {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if n := p.Inventory[id]; n <= 0 {
+			errs = append(errs, fmt.Errorf("inventory has %d of %s", n, id))
+		}
+	}
+	if err := dungeonRunError(s.Dungeon); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// dungeonRunError reports a run that names an unknown dungeon or a stage
+// outside it. A nil run is valid.
+func dungeonRunError(run *DungeonRun) error {
+	if run == nil {
+		return nil
+	}
+	d, ok := Dungeons[run.ID]
+	if !ok {
+		return fmt.Errorf("dungeon run is in unknown dungeon %q", run.ID)
+	}
+	if run.Stage < 0 || run.Stage >= len(d.Enemies) {
+		return fmt.Errorf("dungeon run is on stage %d of %d in %s", run.Stage, len(d.Enemies), run.ID)
+	}
+	return nil
+}
+
+// RepairState fixes the invariant violations that have an obvious repair
+// and returns what it fixed. A MaxHP or MaxSP below zero has no safe repair
+// and is left for ValidateState to report. A broken dungeon run is
+// abandoned.
+func RepairState(s *State) []error {
+	fixed := ValidateState(s)
+	if len(fixed) == 0 {
+		return nil
+	}
+
+	p := &s.Player
+	if p.Level < 1 {
+		p.Level = 1
+	}
+	if p.MaxHP >= 1 {
+		p.ClampHP()
+	}
+	if p.MaxSP >= 0 {
+		p.ClampSP()
+	}
+	p.Gold = max(p.Gold, 0)
+	p.XP = max(p.XP, 0)
+	for id, n := range p.Inventory {
+		if n <= 0 {
+			delete(p.Inventory, id)
+		}
+	}
+	if dungeonRunError(s.Dungeon) != nil {
+		s.Dungeon = nil
+	}
+
+	// Report only what was actually repaired.
+	left := map[string]bool{}
+	for _, err := range ValidateState(s) {
+		left[err.Error()] = true
+	}
+	out := fixed[:0]
+	for _, err := range fixed {
+		if !left[err.Error()] {
+			out = append(out, err)
+		}
+	}
+	return out
+}
+
+// ================================
+// Catalog Validation
+// ================================
+
+// ValidateCatalogReferences reports every catalog ID that points nowhere:
+// items in the default inventory, explore's item tables, enemy loot,
+// recipes, dungeons and item sets must exist in Items, and enemies in the
+// selection pool and dungeons must exist in Enemies. Registry keys must
+// match their entries' IDs.
+func ValidateCatalogReferences() []error {
+	var errs []error
+	item := func(where, id string) {
+		if _, ok := Items[id]; !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown item %q", where, id))
+		}
+	}
+	enemy := func(where, id string) {
+		if _, ok := Enemies[id]; !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown enemy %q", where, id))
+		}
+	}
+
+	for _, id := range sortedIDs(Items) {
+		if Items[id].ID != id {
+			errs = append(errs, fmt.Errorf("item %q has ID %q", id, Items[id].ID))
+		}
+	}
+	def := DefaultState()
+	for _, id := range sortedIDs(def.Player.Inventory) {
+		item("default inventory", id)
+	}
+	for _, id := range treasureItems {
+		item("explore treasure", id)
+	}
+	for _, id := range foundItems {
+		item("explore find", id)
+	}
+
+	for _, id := range enemyPool {
+		enemy("enemy pool", id)
+	}
+	for _, id := range sortedIDs(Enemies) {
+		e := Enemies[id]
+		if e.ID != id {
+			errs = append(errs, fmt.Errorf("enemy %q has ID %q", id, e.ID))
+		}
+		for _, drop := range e.Loot {
+			item("loot of "+id, drop.ItemID)
+		}
+	}
+
+	for _, id := range sortedIDs(Recipes) {
+		r := Recipes[id]
+		for _, in := range sortedIDs(r.Inputs) {
+			item("recipe "+id, in)
+		}
+		item("recipe "+id, r.Output)
+	}
+	for _, id := range sortedIDs(Dungeons) {
+		d := Dungeons[id]
+		for _, e := range d.Enemies {
+			enemy("dungeon "+id, e)
+		}
+		if d.Item != "" {
+			item("dungeon "+id, d.Item)
+		}
+	}
+	for _, id := range sortedIDs(ItemSets) {
+		for _, m := range ItemSets[id].Members {
+			item("set "+id, m)
+		}
+	}
+	return errs
+}
+
+// sortedIDs returns m's keys in order, so reports are stable.
+func sortedIDs[V any](m map[string]V) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateState_DefaultIsValid(t *testing.T) {
+	state := DefaultState()
+	if errs := ValidateState(&state); len(errs) != 0 {
+		t.Fatalf("expected default state valid, got %v", errs)
+	}
+}
+
+func TestRepairState_FixesWhatItCan(t *testing.T) {
+	state := DefaultState()
+	state.Player.Level = 0
+	state.Player.HP = -4
+	state.Player.SP = 99
+	state.Player.Inventory["torch"] = -1
+	state.Dungeon = &DungeonRun{ID: "orc_stronghold", Stage: 99}
+
+	fixed := RepairState(&state)
+	if len(fixed) != 5 {
+		t.Fatalf("expected 5 repairs, got %v", fixed)
+	}
+	if state.Dungeon != nil {
+		t.Fatal("expected the broken dungeon run abandoned")
+	}
+	if errs := ValidateState(&state); len(errs) != 0 {
+		t.Fatalf("expected a valid state after repair, got %v", errs)
+	}
+	if state.Player.HP != 0 || state.Player.SP != state.Player.MaxSP {
+		t.Fatalf("expected HP 0 and SP capped, got %d and %d", state.Player.HP, state.Player.SP)
+	}
+
+	state.Player.MaxHP = 0
+	if fixed := RepairState(&state); len(fixed) != 0 {
+		t.Fatalf("expected nothing repaired for max HP 0, got %v", fixed)
+	}
+	if errs := ValidateState(&state); len(errs) == 0 {
+		t.Fatal("expected max HP 0 to stay invalid")
+	}
+}
+
+func TestValidateCatalogReferences_AllResolve(t *testing.T) {
+	for _, err := range ValidateCatalogReferences() {
+		t.Error(err)
+	}
+}
+
+func TestValidateCatalogReferences_ReportsMissingItem(t *testing.T) {
+	old := foundItems
+	defer func() { foundItems = old }()
+	foundItems = []string{"torch", "healing_potoin"}
+
+	errs := ValidateCatalogReferences()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `explore find: unknown item "healing_potoin"`) {
+		t.Fatalf("expected one missing-item error, got %v", errs)
+	}
+}
@@ -0,0 +1,61 @@
+package engine
+
+import "strings"
+
+// ================================
+// Enemy Variants
+// ================================
+
+// EnemyVariants gives every chosen encounter a variant: an adjective and a
+// small stat shift, e.g. a scarred goblin. Off by default, so encounters
+// draw nothing extra from the RNG and keep their plain names.
+var EnemyVariants = false
+
+// Variant is an adjective prefix with the stat shift it brings. Shifts stay
+// within ±VariantMaxDelta.
+type Variant struct {
+	Adjective string
+	HP        int
+	Attack    int
+}
+
+// VariantMaxDelta bounds every Variant stat shift.
+const VariantMaxDelta = 3
+
+// Variants is the variant table RollVariant draws from.
+var Variants = []Variant{
+	{Adjective: "scarred", HP: 2, Attack: 1},
+	{Adjective: "starving", HP: -2, Attack: 0},
+	{Adjective: "hulking", HP: 3, Attack: 0},
+	{Adjective: "frenzied", HP: -1, Attack: 1},
+	{Adjective: "sickly", HP: -3, Attack: -1},
+	{Adjective: "grizzled", HP: 1, Attack: 1},
+}
+
+// RollVariant draws one Intn to pick a variant and applies it to enemy.
+// HP never drops below 1 nor attack below 0.
+func RollVariant(enemy EnemyTemplate, rng RNG) EnemyTemplate {
+	if len(Variants) == 0 {
+		return enemy
+	}
+	return ApplyVariant(enemy, Variants[rng.Intn(len(Variants))])
+}
+
+// ApplyVariant returns enemy shifted by v and renamed "Scarred Goblin".
+func ApplyVariant(enemy EnemyTemplate, v Variant) EnemyTemplate {
+	enemy.Variant = v.Adjective
+	enemy.Name = strings.ToUpper(v.Adjective[:1]) + v.Adjective[1:] + " " + enemy.Name
+	enemy.HP = max(1, enemy.HP+v.HP)
+	enemy.AttackMin = max(0, enemy.AttackMin+v.Attack)
+	enemy.AttackMax = max(enemy.AttackMin, enemy.AttackMax+v.Attack)
+	return enemy
+}
+
+// encounterName is the name an EncounterStarted carries: a variant's full
+// name, or "" for a plain enemy.
+func encounterName(enemy EnemyTemplate) string {
+	if enemy.Variant == "" {
+		return ""
+	}
+	return enemy.Name
+}
@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRollVariant_DistinctNamesAndBoundedDeltas(t *testing.T) {
+	base := Enemies["goblin"]
+	rng := rand.New(rand.NewSource(11))
+
+	names := map[string]bool{}
+	for range 50 {
+		v := RollVariant(base, rng)
+		if v.Variant == "" || v.Name == base.Name {
+			t.Fatalf("expected a named variant, got %+v", v)
+		}
+		names[v.Name] = true
+		if d := v.HP - base.HP; d < -VariantMaxDelta || d > VariantMaxDelta || v.HP < 1 {
+			t.Fatalf("%s: HP %d out of bounds of %d", v.Name, v.HP, base.HP)
+		}
+		for _, d := range []int{v.AttackMin - base.AttackMin, v.AttackMax - base.AttackMax} {
+			if d < -VariantMaxDelta || d > VariantMaxDelta {
+				t.Fatalf("%s: attack %d-%d out of bounds of %d-%d", v.Name, v.AttackMin, v.AttackMax, base.AttackMin, base.AttackMax)
+			}
+		}
+		if v.ID != base.ID || v.XP != base.XP {
+			t.Fatalf("variant should keep ID and rewards, got %+v", v)
+		}
+	}
+	if len(names) < 3 {
+		t.Fatalf("expected several distinct variant names, got %v", names)
+	}
+	for _, v := range Variants {
+		if abs(v.HP) > VariantMaxDelta || abs(v.Attack) > VariantMaxDelta {
+			t.Fatalf("variant %q exceeds VariantMaxDelta", v.Adjective)
+		}
+	}
+}
+
+func TestChooseEncounter_VariantNamesTheEncounter(t *testing.T) {
+	old := EnemyVariants
+	EnemyVariants = true
+	defer func() { EnemyVariants = old }()
+
+	state := DefaultState()
+	// ChooseEnemy's roll, then the variant pick: goblin, scarred.
+	enemies := ChooseEncounter(&state, 0, &seqRNG{ints: []int{0, 0}})
+	if enemies[0].Name != "Scarred Goblin" {
+		t.Fatalf("expected Scarred Goblin, got %q", enemies[0].Name)
+	}
+
+	_, events := ResolveCombat(&state, enemies[0], &seqRNG{})
+	started, ok := events[0].(EncounterStarted)
+	if !ok || started.EnemyID != "goblin" || started.Name != "Scarred Goblin" {
+		t.Fatalf("expected a named goblin encounter, got %#v", events[0])
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
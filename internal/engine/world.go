@@ -0,0 +1,72 @@
+package engine
+
+import "sort"
+
+// ================================
+// World Modifiers
+// ================================
+
+// WorldModifier is an ambient world state that scales find, drop and
+// encounter odds until the next rotation.
+type WorldModifier struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	// FindScale multiplies explore's treasure and item chances.
+	FindScale float64 `json:"find_scale"`
+	// LootScale multiplies every enemy drop chance.
+	LootScale float64 `json:"loot_scale"`
+	// EncounterScale multiplies EncounterRate; 0 means 1.
+	EncounterScale float64 `json:"encounter_scale,omitempty"`
+}
+
+// WorldNeutral is the modifier in effect when Meta.World is unset.
+const WorldNeutral = "clear"
+
+// WorldModifiers is the global modifier registry.
+var WorldModifiers = map[string]WorldModifier{
+	WorldNeutral: {ID: WorldNeutral, Name: "Clear Skies", FindScale: 1, LootScale: 1},
+	"storm":      {ID: "storm", Name: "Storm", FindScale: 0.5, LootScale: 1, EncounterScale: 1.5},
+	"blessed":    {ID: "blessed", Name: "Blessed", FindScale: 2, LootScale: 1.5},
+}
+
+// WorldRotateInterval is how many commands pass between world rotations.
+// It is a variable so it can be tuned; zero disables rotation.
+var WorldRotateInterval = 15
+
+// CurrentWorld returns the modifier in effect, falling back to neutral.
+func CurrentWorld(state *State) WorldModifier {
+	if m, ok := WorldModifiers[state.Meta.World]; ok {
+		return m
+	}
+	return WorldModifiers[WorldNeutral]
+}
+
+// RotateWorld counts one command toward the next rotation and, when it is
+// due, draws a new modifier with one rng.Intn. Drawing the current modifier
+// again changes nothing and emits no event.
+func RotateWorld(state *State, rng RNG) Events {
+	if WorldRotateInterval <= 0 {
+		return nil
+	}
+
+	state.Meta.WorldTicks++
+	if state.Meta.WorldTicks < WorldRotateInterval {
+		return nil
+	}
+	state.Meta.WorldTicks = 0
+
+	ids := make([]string, 0, len(WorldModifiers))
+	for id := range WorldModifiers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	from := CurrentWorld(state)
+	to := WorldModifiers[ids[rng.Intn(len(ids))]]
+	if to.ID == from.ID {
+		return nil
+	}
+	state.Meta.World = to.ID
+	return emit(nil, WorldChanged{From: from.ID, To: to.ID})
+}
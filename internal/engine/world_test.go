@@ -0,0 +1,89 @@
+package engine
+
+import "testing"
+
+func TestExplore_BlessedWorldWidensTreasureBand(t *testing.T) {
+	// roll 3 is an item find under clear skies but treasure when blessed.
+	for _, tc := range []struct {
+		world string
+		want  string
+	}{
+		{world: "", want: "item"},
+		{world: "blessed", want: "treasure"},
+	} {
+		state := DefaultState()
+		state.Meta.World = tc.world
+		events, err := Explore(&state, &seqRNG{ints: []int{2, 0, 0}})
+		if err != nil {
+			t.Fatalf("Explore returned error: %v", err)
+		}
+		if r, ok := events[0].(ExplorationResult); !ok || r.Kind != tc.want {
+			t.Fatalf("world %q: expected %s, got %v", tc.world, tc.want, events)
+		}
+	}
+}
+
+func TestEncounterBand_StormBringsMoreFights(t *testing.T) {
+	state := DefaultState()
+	clear := encounterBand(&state, 0)
+	state.Meta.World = "storm"
+	if got := encounterBand(&state, 0); got != int(float64(clear)*1.5+0.5) {
+		t.Fatalf("expected a storm to widen the encounter band from %d, got %d", clear, got)
+	}
+}
+
+func TestRotateWorld_ChangesOnInterval(t *testing.T) {
+	state := DefaultState()
+	state.Meta.WorldTicks = WorldRotateInterval - 1
+
+	// sorted IDs: blessed, clear, storm
+	events := RotateWorld(&state, &seqRNG{ints: []int{2}})
+	if state.Meta.World != "storm" || state.Meta.WorldTicks != 0 {
+		t.Fatalf("expected storm after rotation, got %q (ticks %d)", state.Meta.World, state.Meta.WorldTicks)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected WorldChanged, got %v", events)
+	}
+	if w, ok := events[0].(WorldChanged); !ok || w.From != WorldNeutral || w.To != "storm" {
+		t.Fatalf("unexpected event %v", events[0])
+	}
+
+	if events := RotateWorld(&state, &seqRNG{}); len(events) != 0 || state.Meta.World != "storm" {
+		t.Fatalf("expected no rotation before the interval, got %v", events)
+	}
+}
+
+func TestChooseEnemy_NightShiftsTowardSkeletons(t *testing.T) {
+	// Roll 45 lands past skeletons (25+20) under neutral weights, but inside
+	// them once night adds TimeOfDayBias.
+	state := DefaultState()
+	if got := ChooseEnemy(&state, 0, &seqRNG{ints: []int{45}}); got != "bandit" {
+		t.Fatalf("expected bandit under neutral weights, got %s", got)
+	}
+	state.Meta.TimeOfDay = TimeNight
+	if got := ChooseEnemy(&state, 0, &seqRNG{ints: []int{45}}); got != "skeleton" {
+		t.Fatalf("expected skeleton at night, got %s", got)
+	}
+}
+
+func TestAdvanceTime_AlternatesOnInterval(t *testing.T) {
+	state := DefaultState()
+	state.Meta.TimeTicks = DayNightInterval - 1
+
+	events := AdvanceTime(&state)
+	if state.Meta.TimeOfDay != TimeNight || state.Meta.TimeTicks != 0 {
+		t.Fatalf("expected night after interval, got %q (ticks %d)", state.Meta.TimeOfDay, state.Meta.TimeTicks)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected TimeChanged, got %v", events)
+	}
+	if ev, ok := events[0].(TimeChanged); !ok || ev.From != "" || ev.To != TimeNight {
+		t.Fatalf("unexpected event %#v", events[0])
+	}
+
+	state.Meta.TimeTicks = DayNightInterval - 1
+	AdvanceTime(&state)
+	if state.Meta.TimeOfDay != TimeDay {
+		t.Fatalf("expected day after night, got %q", state.Meta.TimeOfDay)
+	}
+}
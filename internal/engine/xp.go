@@ -4,6 +4,10 @@ package engine
 // XP & Leveling
 // ================================
 
+// XPPercent scales every XP award, on top of any prestige bonus. 100 pays
+// XP as listed.
+var XPPercent = 100
+
 // XPToNext returns the XP required to reach the next level.
 // Linear curve: level * 100
 func XPToNext(level int) int {
@@ -13,18 +17,21 @@ func XPToNext(level int) int {
 	return level * 100
 }
 
-// GrantXP adds XP to the player, processes level-ups,
-// mutates state, and emits progression events.
+// GrantXP adds XP to the player, scaled by the prestige bonus and
+// XPPercent, processes level-ups, mutates state, and emits progression
+// events.
 func GrantXP(state *State, amount int) Events {
 	events := Events{}
 
 	if amount <= 0 {
 		return events
 	}
+	amount = amount * PrestigeXPPercent(state.Meta.Prestige) / 100
+	amount = amount * XPPercent / 100
 
 	// Apply XP gain
 	state.Player.XP += amount
-	events = append(events, XPGained{Amount: amount})
+	events = emit(events, XPGained{Amount: amount})
 
 	// Handle level-ups
 	for {
@@ -37,16 +44,13 @@ func GrantXP(state *State, amount int) Events {
 
 		// Level up
 		state.Player.XP -= need
-		state.Player.Level++
-
-		// Increase max HP
-		state.Player.MaxHP += 10
+		levelUp(&state.Player)
 
 		// Heal some HP on level-up (matches Python semantics)
 		state.Player.HP += 10
 		state.Player.ClampHP()
 
-		events = append(events, LevelUp{
+		events = emit(events, LevelUp{
 			NewLevel: state.Player.Level,
 			NewMaxHP: state.Player.MaxHP,
 		})
@@ -54,3 +58,10 @@ func GrantXP(state *State, amount int) Events {
 
 	return events
 }
+
+// levelUp raises the player one level, with the max HP and SP it brings.
+func levelUp(p *Player) {
+	p.Level++
+	p.MaxHP += 10
+	p.MaxSP++
+}
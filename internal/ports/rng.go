@@ -9,3 +9,12 @@ type RNG interface {
 	// Float64 returns a float in [0.0, 1.0).
 	Float64() float64
 }
+
+// ReplayableRNG is an RNG whose stream position can be persisted and later
+// resumed from the same seed.
+type ReplayableRNG interface {
+	RNG
+
+	// Position returns the seed and the number of source draws made so far.
+	Position() (seed, draws int64)
+}
@@ -10,4 +10,17 @@ type Store interface {
 
 	// Save persists the given state atomically.
 	Save(state *engine.State) error
+
+	// Archive moves the current save aside so a fresh game can start,
+	// returning where it went ("" if there was nothing to archive).
+	Archive() (string, error)
+}
+
+// SlotStore enumerates every saved game alongside the active one.
+type SlotStore interface {
+	// Slots lists the available slot names in a stable order.
+	Slots() ([]string, error)
+
+	// LoadSlot reads a slot without touching the active save.
+	LoadSlot(name string) (*engine.State, error)
 }
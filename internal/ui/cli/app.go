@@ -4,45 +4,152 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/divijg19/Grimoire/internal/engine"
 	"github.com/divijg19/Grimoire/internal/ports"
+	"github.com/divijg19/Grimoire/internal/ui/format"
 )
 
 type App struct {
 	state *engine.State
 	store ports.Store
 	rng   ports.RNG
+
+	// lootSummary collapses per-item drop lines into one LootFound line.
+	lootSummary bool
+
+	// levelSummary collapses a run of level-ups into one line.
+	levelSummary bool
+
+	// verbosity picks every hit or a per-fight summary.
+	verbosity engine.CombatVerbosity
+
+	// bell rings the terminal bell on high-priority events.
+	bell bool
+
+	// autosave saves after every command; with it off only `save` and
+	// `exit` persist, and dirty records unsaved changes.
+	autosave bool
+	dirty    bool
+
+	// undoStack holds prior states, newest last, bounded by undoLimit.
+	undoStack []engine.State
+
+	// confirm is the pending Confirm callback, answered by the next line.
+	confirm func(yes bool) error
+
+	// start is the state at launch and started the launch time, for the
+	// session summary printed on exit.
+	start   *engine.State
+	started time.Time
+
+	// quit is set by `exit`; Run returns once the command finishes.
+	quit bool
 }
 
+// undoLimit bounds how many prior states undo can restore.
+const undoLimit = 10
+
 func NewApp(state *engine.State, store ports.Store, rng ports.RNG) *App {
 	return &App{
-		state: state,
-		store: store,
-		rng:   rng,
+		state:        state,
+		store:        store,
+		rng:          rng,
+		lootSummary:  true,
+		levelSummary: true,
+		verbosity:    engine.CombatVerbose,
+		bell:         true,
+		autosave:     true,
+		start:        state.Clone(),
+		started:      time.Now(),
 	}
 }
 
+// SetAutosave turns the per-command save on or off.
+func (a *App) SetAutosave(on bool) {
+	a.autosave = on
+}
+
+// Run reads commands from stdin until `exit`, end of input or SIGINT/SIGTERM,
+// then returns so the caller can finish up.
 func (a *App) Run() {
-	reader := bufio.NewScanner(os.Stdin)
+	lines := make(chan string)
+	go func() {
+		reader := bufio.NewScanner(os.Stdin)
+		for reader.Scan() {
+			lines <- reader.Text()
+		}
+		close(lines)
+	}()
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
 
 	fmt.Println(cs("Grimoire — interactive mode. Type 'help'.", bold, cyan))
 	RenderHUD(a.state)
+	if run := a.state.Dungeon; run != nil {
+		fmt.Println(c(format.Run(run)+". `dungeon next` continues, `dungeon leave` gives up.", yellow))
+	}
 
-	for {
+	for !a.quit {
 		fmt.Print(cs("> ", bold, cyan))
-		if !reader.Scan() {
-			fmt.Println(cs("\nExiting and saving...", yellow))
-			_ = a.store.Save(a.state)
+		var line string
+		select {
+		case sig := <-sigs:
+			a.shutdown(sig)
 			return
+		case l, ok := <-lines:
+			if !ok {
+				if a.autosave || !a.dirty {
+					fmt.Println(cs("\nExiting and saving...", yellow))
+					_ = a.store.Save(a.state)
+				} else {
+					fmt.Println(cs("\nExiting. Autosave is off; unsaved changes were discarded.", yellow))
+				}
+				return
+			}
+			line = strings.TrimSpace(l)
 		}
-
-		line := strings.TrimSpace(reader.Text())
-		if line == "" {
+		// A blank line still answers a pending prompt, declining it.
+		if line == "" && a.confirm == nil {
 			continue
 		}
-
 		a.dispatch(line)
 	}
 }
+
+// shutdown saves the current state on SIGINT/SIGTERM, unless autosave is
+// off. Signals are only handled between commands, so a half-applied
+// command is never saved.
+func (a *App) shutdown(sig os.Signal) {
+	if !a.autosave && a.dirty {
+		fmt.Println(cs("\nReceived "+sig.String()+". Autosave is off; unsaved changes were discarded.", yellow))
+		return
+	}
+	if err := a.store.Save(a.state); err != nil {
+		fmt.Println(cs("\nReceived "+sig.String()+"; save failed: "+err.Error(), bold, red))
+		return
+	}
+	fmt.Println(cs("\nReceived "+sig.String()+". Game saved. Goodbye.", green))
+}
+
+// autoSave saves after a change, or marks the state dirty while autosave
+// is off.
+func (a *App) autoSave() {
+	if !a.autosave {
+		a.dirty = true
+		return
+	}
+	_ = a.store.Save(a.state)
+}
+
+// save is an explicit save.
+func (a *App) save() {
+	_ = a.store.Save(a.state)
+	a.dirty = false
+}
@@ -0,0 +1,302 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/divijg19/Grimoire/internal/adapters"
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+type memStore struct {
+	saved *engine.State
+	saves int
+}
+
+func (s *memStore) Load() (*engine.State, error) {
+	state := engine.DefaultState()
+	return &state, nil
+}
+
+func (s *memStore) Save(state *engine.State) error {
+	copied := *state
+	s.saved = &copied
+	s.saves++
+	return nil
+}
+
+func (s *memStore) Archive() (string, error) {
+	s.saved = nil
+	return "", nil
+}
+
+func TestDispatch_UndoRestoresPreHuntState(t *testing.T) {
+	state := engine.DefaultState()
+	store := &memStore{}
+	app := NewApp(&state, store, adapters.NewSeededMathRNG(7))
+
+	before := state.Player
+	app.dispatch("hunt 1")
+	if state.Player.SP == before.SP {
+		t.Fatalf("expected hunt to spend SP")
+	}
+
+	app.dispatch("undo")
+	if state.Player.SP != before.SP || state.Player.XP != before.XP || state.Player.Gold != before.Gold {
+		t.Fatalf("undo did not restore stats: sp=%d xp=%d gold=%d", state.Player.SP, state.Player.XP, state.Player.Gold)
+	}
+	if store.saved == nil || store.saved.Player.SP != before.SP {
+		t.Fatalf("expected restored state to be saved")
+	}
+}
+
+func TestDispatch_UndoStackIsBounded(t *testing.T) {
+	state := engine.DefaultState()
+	state.Player.SP = 100
+	app := NewApp(&state, &memStore{}, adapters.NewSeededMathRNG(7))
+
+	for i := 0; i < undoLimit+5; i++ {
+		app.dispatch("rest 1")
+	}
+	if len(app.undoStack) != undoLimit {
+		t.Fatalf("expected undo stack capped at %d, got %d", undoLimit, len(app.undoStack))
+	}
+}
+
+func TestDispatch_NewArchivesSaveAndResetsState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grimoire.json")
+	store := adapters.NewJSONStore(path)
+
+	state := engine.DefaultState()
+	state.Player.Gold = 999
+	if err := store.Save(&state); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	app := NewApp(&state, store, adapters.NewSeededMathRNG(7))
+
+	app.dispatch("new")
+	app.dispatch("no")
+	if state.Player.Gold != 999 {
+		t.Fatalf("expected declined reset to keep state")
+	}
+
+	app.dispatch("new")
+	app.dispatch("yes")
+	if !reflect.DeepEqual(state, engine.DefaultState()) {
+		t.Fatalf("expected DefaultState after reset, got %+v", state)
+	}
+
+	archives, _ := filepath.Glob(path + ".archive.*")
+	if len(archives) != 1 {
+		t.Fatalf("expected one archived save, got %v", archives)
+	}
+	old, err := adapters.ReadStateFile(archives[0])
+	if err != nil || old.Player.Gold != 999 {
+		t.Fatalf("expected archived save to keep gold 999, got %+v, %v", old, err)
+	}
+}
+
+func TestShutdown_SavesLatestState(t *testing.T) {
+	state := engine.DefaultState()
+	store := &memStore{}
+	app := NewApp(&state, store, adapters.NewSeededMathRNG(7))
+
+	app.dispatch("rest 2")
+	state.Player.Gold = 4242 // changed since the last auto-save
+	app.shutdown(os.Interrupt)
+
+	if store.saved == nil || store.saved.Player.Gold != 4242 || store.saved.Player.SP != state.Player.SP {
+		t.Fatalf("expected shutdown to save latest state, got %+v", store.saved)
+	}
+}
+
+func TestRunScript_AppliesEveryCommand(t *testing.T) {
+	state := engine.DefaultState()
+	store := &memStore{}
+	app := NewApp(&state, store, adapters.NewSeededMathRNG(7))
+
+	script := `# warm up
+rest 2
+dance
+
+rest 3
+exit
+rest 1
+`
+	if err := app.RunScript(strings.NewReader(script), false); err != nil {
+		t.Fatalf("RunScript returned error: %v", err)
+	}
+	if state.Player.SP != engine.DefaultMaxSP-5 {
+		t.Fatalf("expected both rests applied (SP %d), got %d", engine.DefaultMaxSP-5, state.Player.SP)
+	}
+	if store.saved == nil || store.saved.Player.SP != state.Player.SP {
+		t.Fatalf("expected final state saved")
+	}
+}
+
+func TestRunScript_StrictStopsOnError(t *testing.T) {
+	state := engine.DefaultState()
+	app := NewApp(&state, &memStore{}, adapters.NewSeededMathRNG(7))
+
+	err := app.RunScript(strings.NewReader("rest 2\nrest 999\nrest 3\n"), true)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected strict failure on line 2, got %v", err)
+	}
+	if state.Player.SP != engine.DefaultMaxSP-2 {
+		t.Fatalf("expected script to stop after line 2, SP %d", state.Player.SP)
+	}
+}
+
+func TestDispatch_NoAutosaveOnlySavesExplicitly(t *testing.T) {
+	state := engine.DefaultState()
+	store := &memStore{}
+	app := NewApp(&state, store, adapters.NewSeededMathRNG(7))
+	app.SetAutosave(false)
+
+	app.dispatch("explore")
+	app.dispatch("rest 1")
+	app.dispatch("undo")
+	if store.saves != 0 {
+		t.Fatalf("expected no saves with autosave off, got %d", store.saves)
+	}
+	if !app.dirty {
+		t.Fatal("expected unsaved changes recorded")
+	}
+
+	app.dispatch("save")
+	if store.saves != 1 || app.dirty {
+		t.Fatalf("expected one explicit save, got %d (dirty=%v)", store.saves, app.dirty)
+	}
+}
+
+func TestDispatch_UnsavedNoticeOnlyWhenFirstDirty(t *testing.T) {
+	state := engine.DefaultState()
+	app := NewApp(&state, &memStore{}, adapters.NewSeededMathRNG(7))
+	app.SetAutosave(false)
+
+	const notice = "Autosave off: unsaved changes"
+	out := captureStdout(t, func() {
+		app.dispatch("rest 1")
+		app.dispatch("rest 1")
+	})
+	if n := strings.Count(out, notice); n != 1 {
+		t.Fatalf("expected the notice once, got %d in %q", n, out)
+	}
+
+	app.dispatch("save")
+	out = captureStdout(t, func() { app.dispatch("rest 1") })
+	if !strings.Contains(out, notice) {
+		t.Fatalf("expected the notice again after a save, got %q", out)
+	}
+}
+
+func TestConfirm_ParsesReplies(t *testing.T) {
+	state := engine.DefaultState()
+	app := NewApp(&state, &memStore{}, adapters.NewSeededMathRNG(7))
+
+	for reply, want := range map[string]bool{
+		"y": true, "yes": true, "Y": true, " YES ": true,
+		"n": false, "no": false, "": false, "maybe": false,
+	} {
+		var got, answered bool
+		app.Confirm("Proceed?", func(yes bool) error {
+			got, answered = yes, true
+			return nil
+		})
+		if err := app.dispatch(reply); err != nil {
+			t.Fatalf("dispatch(%q): %v", reply, err)
+		}
+		if !answered || got != want {
+			t.Fatalf("reply %q: expected %v, got %v (answered %v)", reply, want, got, answered)
+		}
+	}
+
+	// Once answered, the next line is a command again.
+	if app.confirm != nil {
+		t.Fatal("expected no pending prompt after the reply")
+	}
+}
+
+// withStdin feeds input to Run through a pipe standing in for os.Stdin.
+func withStdin(t *testing.T, input string) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	if _, err := w.WriteString(input); err != nil {
+		t.Fatalf("write stdin: %v", err)
+	}
+	w.Close()
+	old := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = old; r.Close() })
+}
+
+// captureStdout returns what fn prints to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	old := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = old
+	w.Close()
+	out, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatalf("read stdout: %v", err)
+	}
+	return string(out)
+}
+
+func TestRun_ExitReturnsToCaller(t *testing.T) {
+	withStdin(t, "exit\nrest 1\n")
+	state := engine.DefaultState()
+	state.Player.HP = 50
+	store := &memStore{}
+	app := NewApp(&state, store, adapters.NewSeededMathRNG(7))
+
+	app.Run()
+	if store.saves == 0 {
+		t.Fatal("expected exit to save")
+	}
+	if state.Player.HP != 50 {
+		t.Fatalf("expected nothing after exit to run, HP %d", state.Player.HP)
+	}
+}
+
+func TestRun_BlankReplyDeclines(t *testing.T) {
+	withStdin(t, "sell all\n\n")
+	state := engine.DefaultState()
+	before := len(state.Player.Inventory)
+	app := NewApp(&state, &memStore{}, adapters.NewSeededMathRNG(7))
+
+	app.Run()
+	if app.confirm != nil {
+		t.Fatal("expected the blank line to answer the prompt")
+	}
+	if len(state.Player.Inventory) != before {
+		t.Fatalf("expected nothing sold, inventory %v", state.Player.Inventory)
+	}
+}
+
+func TestRunScript_BlankReplyDeclines(t *testing.T) {
+	state := engine.DefaultState()
+	before := len(state.Player.Inventory)
+	app := NewApp(&state, &memStore{}, adapters.NewSeededMathRNG(7))
+
+	if err := app.RunScript(strings.NewReader("sell all\n\n"), true); err != nil {
+		t.Fatalf("RunScript returned error: %v", err)
+	}
+	if app.confirm != nil || len(state.Player.Inventory) != before {
+		t.Fatalf("expected the prompt declined, pending %v, inventory %v", app.confirm != nil, state.Player.Inventory)
+	}
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+	"github.com/divijg19/Grimoire/internal/ui/format"
+)
+
+// RenderDiff prints a structured diff between two saves.
+func RenderDiff(nameA, nameB string, d engine.StateDiff) {
+	fmt.Println(cs(fmt.Sprintf("Comparing %s -> %s", nameA, nameB), bold, cyan))
+	if d.Empty() {
+		fmt.Println(c("No differences.", dim))
+		return
+	}
+
+	if len(d.Changed) > 0 {
+		fmt.Println(cs("Fields:", bold, cyan))
+		for _, f := range d.Changed {
+			fmt.Println(c(fmt.Sprintf("  ~ %s: %s -> %s", f.Field, f.Before, f.After), yellow))
+		}
+	}
+
+	if len(d.ItemsAdded)+len(d.ItemsRemoved)+len(d.ItemsChanged) > 0 {
+		fmt.Println(cs("Inventory:", bold, cyan))
+		for _, it := range d.ItemsAdded {
+			fmt.Println(c(fmt.Sprintf("  + %s x%d", format.ItemName(it.ItemID), it.After), green))
+		}
+		for _, it := range d.ItemsRemoved {
+			fmt.Println(c(fmt.Sprintf("  - %s x%d", format.ItemName(it.ItemID), it.Before), red))
+		}
+		for _, it := range d.ItemsChanged {
+			fmt.Println(c(fmt.Sprintf("  ~ %s x%d -> x%d", format.ItemName(it.ItemID), it.Before, it.After), yellow))
+		}
+	}
+}
@@ -1,71 +1,377 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
-	"os"
 	"strings"
+	"time"
 
+	"github.com/divijg19/Grimoire/internal/buildinfo"
 	"github.com/divijg19/Grimoire/internal/engine"
+	"github.com/divijg19/Grimoire/internal/ports"
+	"github.com/divijg19/Grimoire/internal/ui/commands"
+	"github.com/divijg19/Grimoire/internal/ui/format"
 )
 
-func (a *App) dispatch(line string) {
-	parts := strings.Fields(line)
+// dispatch runs one command line. It returns the error of a failed or
+// unknown gameplay command so scripts can stop on it.
+func (a *App) dispatch(line string) error {
+	line = engine.SanitizeInput(line)
+	if a.confirm != nil {
+		then := a.confirm
+		a.confirm = nil
+		return then(commands.Confirmed(line))
+	}
+	if line == "" {
+		return nil
+	}
+
+	parts := engine.SplitArgs(line)
 	cmd := parts[0]
 	args := parts[1:]
 
-	var (
-		events engine.Events
-		err    error
-	)
-
 	switch cmd {
 
 	case "help":
-		PrintHelp()
-		return
+		PrintHelp(args)
+		return nil
 
 	case "status":
 		RenderHUD(a.state)
-		return
+		return nil
+
+	case "profile":
+		RenderSheet(a.state)
+		return nil
 
-	case "explore":
-		events, err = engine.Explore(a.state, a.rng)
+	case "inventory", "inv":
+		RenderInventory(a.state, strings.Join(args, " "))
+		return nil
 
-	case "hunt":
-		extra := 0
-		if len(args) > 0 {
-			fmt.Sscanf(args[0], "%d", &extra)
+	case "examine":
+		if len(args) == 0 {
+			fmt.Println(c("Usage: examine <item_id>", yellow))
+			return nil
+		}
+		lines, err := engine.DescribeItem(args[0])
+		if err != nil {
+			fmt.Println(cs("Error: "+err.Error(), bold, red))
+			return nil
+		}
+		fmt.Println(cs(lines[0], bold, cyan))
+		for _, l := range lines[1:] {
+			fmt.Println(l)
 		}
-		events, err = engine.Hunt(a.state, extra, a.rng)
+		return nil
+
+	case "bank":
+		fmt.Println(c(format.Bank(&a.state.Player)+".", cyan))
+		return nil
+
+	case "version":
+		fmt.Println(c(buildinfo.String(), cyan))
+		return nil
+
+	case "analytics":
+		RenderAnalytics(a.state)
+		return nil
 
-	case "rest":
-		sp := 1
-		if len(args) > 0 {
-			fmt.Sscanf(args[0], "%d", &sp)
+	case "simulate", "sim":
+		lines, err := commands.Simulate(a.state, args)
+		if err != nil {
+			fmt.Println(cs("Error: "+err.Error(), bold, red))
+			return nil
 		}
-		events, err = engine.Rest(a.state, sp)
+		fmt.Println(cs(lines[0], bold, cyan))
+		for _, l := range lines[1:] {
+			fmt.Println(l)
+		}
+		return nil
 
-	case "use":
-		if len(args) == 0 {
-			fmt.Println(c("Usage: use <item_id>", yellow))
-			return
+	case "score":
+		fmt.Println(c(fmt.Sprintf("Score: %d (gold + level×100 + kills)", engine.ChallengeScore(a.state)), cyan))
+		return nil
+
+	case "undo":
+		a.undo()
+		return nil
+
+	case "recipes":
+		RenderRecipes()
+		return nil
+
+	case "achievements":
+		RenderAchievements(a.state)
+		return nil
+
+	case "dungeons":
+		RenderDungeons(a.state)
+		return nil
+
+	case "leaderboard", "top":
+		a.leaderboard()
+		return nil
+
+	case "bestiary":
+		RenderBestiary(a.state)
+		return nil
+
+	case "loot":
+		a.setLootMode(args)
+		return nil
+
+	case "lootlog":
+		n, err := commands.LootLogCount(args)
+		if err != nil {
+			fmt.Println(c(err.Error(), yellow))
+			return nil
 		}
-		events, err = engine.UseItem(a.state, args[0], a.rng)
+		RenderLootLog(a.state, n)
+		return nil
+
+	case "levelups":
+		a.setLevelMode(args)
+		return nil
+
+	case "verbosity":
+		a.setVerbosity(args)
+		return nil
+
+	case "bell":
+		a.setBell(args)
+		return nil
+
+	case "targeting":
+		a.setTargeting(args)
+		return nil
+
+	case "sell":
+		if len(args) > 0 && args[0] == "all" {
+			a.Confirm("Sell every item you carry?", a.answerSellAll)
+			return nil
+		}
+		return a.apply(line)
+
+	case "new":
+		a.Confirm("Start a new game? The current save will be archived.", a.answerNew)
+		return nil
 
 	case "save":
-		_ = a.store.Save(a.state)
+		a.save()
 		fmt.Println(c("Game saved.", green))
-		return
+		return nil
+
+	case "autosave":
+		a.setAutosave(args)
+		return nil
 
 	case "exit", "quit":
-		_ = a.store.Save(a.state)
+		RenderSummary(engine.SessionSummary(a.start, a.state, a.started))
+		a.save()
 		fmt.Println(c("Game saved. Goodbye.", green))
-		os.Exit(0)
+		a.quit = true
+		return nil
 
 	default:
+		return a.apply(line)
+	}
+}
+
+// apply runs a gameplay command through the engine, remembering the prior
+// state so it can be undone.
+func (a *App) apply(line string) error {
+	next, events, err := engine.ApplyCommand(*a.state, line, a.rng)
+	if errors.Is(err, engine.ErrUnknownCommand) {
 		fmt.Println(c("Unknown command. Type 'help'.", yellow))
+		return err
+	}
+	if err == nil {
+		a.pushUndo(*a.state)
+		*a.state = next
+	}
+	a.handle(events, err)
+	return err
+}
+
+func (a *App) pushUndo(prev engine.State) {
+	a.undoStack = append(a.undoStack, prev)
+	if len(a.undoStack) > undoLimit {
+		a.undoStack = a.undoStack[len(a.undoStack)-undoLimit:]
+	}
+}
+
+func (a *App) undo() {
+	if len(a.undoStack) == 0 {
+		fmt.Println(c("Nothing to undo.", yellow))
 		return
 	}
+	last := len(a.undoStack) - 1
+	*a.state = a.undoStack[last]
+	a.undoStack = a.undoStack[:last]
+	a.autoSave()
 
-	a.handle(events, err)
+	fmt.Println(c("Undid last command.", cyan))
+	RenderHUD(a.state)
+}
+
+// Confirm prints prompt and hands the player's next line to then; see
+// commands.Confirmer.
+func (a *App) Confirm(prompt string, then func(yes bool) error) {
+	a.confirm = then
+	fmt.Println(c(commands.ConfirmPrompt(prompt), yellow))
+}
+
+// answerNew handles the reply to the `new` confirmation prompt.
+func (a *App) answerNew(yes bool) error {
+	if !yes {
+		fmt.Println(c("New game cancelled.", dim))
+		return nil
+	}
+
+	archived, err := a.store.Archive()
+	if err != nil {
+		fmt.Println(cs("Error: could not archive save: "+err.Error(), bold, red))
+		return nil
+	}
+	*a.state = engine.DefaultState()
+	a.start, a.started = a.state.Clone(), time.Now()
+	a.undoStack = nil
+	_ = a.store.Save(a.state)
+
+	if archived != "" {
+		fmt.Println(c("Previous save archived to "+archived+".", dim))
+	}
+	fmt.Println(cs("A new adventure begins.", bold, green))
+	RenderHUD(a.state)
+	return nil
+}
+
+// answerSellAll handles the reply to the `sell all` confirmation prompt.
+func (a *App) answerSellAll(yes bool) error {
+	if !yes {
+		fmt.Println(c("Nothing sold.", dim))
+		return nil
+	}
+	return a.apply("sell all")
+}
+
+func (a *App) leaderboard() {
+	slots, ok := a.store.(ports.SlotStore)
+	if !ok {
+		fmt.Println(c("This store has no save slots.", yellow))
+		return
+	}
+	entries, warnings, err := engine.Leaderboard(slots)
+	if err != nil {
+		fmt.Println(cs("Error: "+err.Error(), bold, red))
+		return
+	}
+	for _, w := range warnings {
+		fmt.Println(c("Warning: "+w, yellow))
+	}
+	RenderLeaderboard(entries)
+}
+
+func (a *App) setBell(args []string) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "on":
+			a.bell = true
+		case "off":
+			a.bell = false
+		default:
+			fmt.Println(c("Usage: bell [on|off]", yellow))
+			return
+		}
+	}
+	mode := "off"
+	if a.bell {
+		mode = "on"
+	}
+	fmt.Println(c("Bell: "+mode+".", cyan))
+}
+
+func (a *App) setAutosave(args []string) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "on":
+			a.autosave = true
+			if a.dirty {
+				a.save()
+			}
+		case "off":
+			a.autosave = false
+		default:
+			fmt.Println(c("Usage: autosave [on|off]", yellow))
+			return
+		}
+	}
+	mode := "off"
+	if a.autosave {
+		mode = "on"
+	}
+	fmt.Println(c("Autosave: "+mode+".", cyan))
+}
+
+func (a *App) setTargeting(args []string) {
+	mode, err := commands.Targeting(a.state, args)
+	if err != nil {
+		fmt.Println(c("Usage: targeting [on|off]", yellow))
+		return
+	}
+	if len(args) > 0 {
+		a.autoSave()
+	}
+	fmt.Println(c("Targeting: "+mode+".", cyan))
+}
+
+func (a *App) setVerbosity(args []string) {
+	if len(args) > 0 {
+		switch v := engine.CombatVerbosity(args[0]); v {
+		case engine.CombatVerbose, engine.CombatSummary:
+			a.verbosity = v
+		default:
+			fmt.Println(c("Usage: verbosity [verbose|summary]", yellow))
+			return
+		}
+	}
+	fmt.Println(c("Combat display: "+string(a.verbosity)+".", cyan))
+}
+
+func (a *App) setLevelMode(args []string) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "summary":
+			a.levelSummary = true
+		case "each":
+			a.levelSummary = false
+		default:
+			fmt.Println(c("Usage: levelups [summary|each]", yellow))
+			return
+		}
+	}
+	mode := "each"
+	if a.levelSummary {
+		mode = "summary"
+	}
+	fmt.Println(c("Level-up display: "+mode+".", cyan))
+}
+
+func (a *App) setLootMode(args []string) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "summary":
+			a.lootSummary = true
+		case "items":
+			a.lootSummary = false
+		default:
+			fmt.Println(c("Usage: loot [summary|items]", yellow))
+			return
+		}
+	}
+	mode := "items"
+	if a.lootSummary {
+		mode = "summary"
+	}
+	fmt.Println(c("Loot display: "+mode+".", cyan))
 }
@@ -2,51 +2,249 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/divijg19/Grimoire/internal/engine"
+	"github.com/divijg19/Grimoire/internal/ui/commands"
+	"github.com/divijg19/Grimoire/internal/ui/format"
 )
 
 func (a *App) handle(events engine.Events, err error) {
 	if err != nil {
 		fmt.Println(cs("Error: "+err.Error(), bold, red))
+		if hint := commands.Hint(err); hint != "" {
+			fmt.Println(c(hint, dim))
+		}
 		return
 	}
 
+	if a.lootSummary {
+		events = engine.CollapseLoot(events)
+	}
+	if a.levelSummary {
+		events = engine.CollapseLevelUps(events)
+	}
+	if a.verbosity == engine.CombatSummary {
+		events = engine.SummarizeCombat(events)
+	}
 	for _, e := range events {
+		if _, ok := e.(engine.LootFound); ok && !a.lootSummary {
+			continue
+		}
 		renderEvent(e)
 	}
+	if a.bell && engine.HighestPriority(events) == engine.PriorityHigh {
+		fmt.Print("\a")
+	}
 
 	// After handling events, show compact HP-only UI for minimal output.
 	RenderHP(a.state)
-	_ = a.store.Save(a.state)
+	wasDirty := a.dirty
+	a.autoSave()
+	if !a.autosave && !wasDirty {
+		fmt.Println(c("Autosave off: unsaved changes. Type 'save' to keep them.", dim))
+	}
 }
 
 func renderEvent(e engine.Event) {
 	switch ev := e.(type) {
 
 	case engine.EncounterStarted:
-		fmt.Println(cs(fmt.Sprintf("You encountered a %s.", ev.EnemyID), bold, yellow))
+		fmt.Println(cs(fmt.Sprintf("You encountered a %s.", encounterName(ev)), bold, yellow))
 
 	case engine.DamageDealt:
 		if ev.Target == "player" {
 			fmt.Println(c(fmt.Sprintf("You took %d damage (%d HP left).", ev.Amount, ev.HPLeft), red))
 		} else {
-			fmt.Println(c(fmt.Sprintf("You dealt %d damage (%d HP left).", ev.Amount, ev.HPLeft), green))
+			msg := fmt.Sprintf("You dealt %d damage (%d HP left).", ev.Amount, ev.HPLeft)
+			if ev.Overkill > 0 {
+				msg = fmt.Sprintf("You dealt %d damage, %d of it overkill!", ev.Amount, ev.Overkill)
+			}
+			fmt.Println(c(msg, green))
 		}
 
 	case engine.EnemyDefeated:
-		fmt.Println(c(fmt.Sprintf("Enemy defeated! +%d XP, +%d gold.", ev.XP, ev.Gold), green))
+		fmt.Println(c(fmt.Sprintf("Enemy defeated! +%s XP, +%s gold.", format.Int(ev.XP), format.Int(ev.Gold)), green))
+
+	case engine.RepeatStopped:
+		fmt.Println(c(fmt.Sprintf("Stopped after %d of %d %s: %s.", ev.Done, ev.Times, ev.Command, ev.Reason), yellow))
+
+	case engine.Hint:
+		fmt.Println(c("Hint: "+ev.Text, dim))
+
+	case engine.Stolen:
+		if ev.ItemID != "" {
+			fmt.Println(c(fmt.Sprintf("The %s steals your %s!", ev.EnemyID, format.ItemName(ev.ItemID)), red))
+		} else {
+			fmt.Println(c(fmt.Sprintf("The %s steals %s gold!", ev.EnemyID, format.Int(ev.Gold)), red))
+		}
+
+	case engine.StolenRecovered:
+		parts := make([]string, 0, len(ev.Items)+1)
+		if ev.Gold > 0 {
+			parts = append(parts, format.Int(ev.Gold)+" gold")
+		}
+		for _, it := range ev.Items {
+			parts = append(parts, format.ItemName(it))
+		}
+		fmt.Println(c("You recover what was stolen: "+strings.Join(parts, ", "), green))
+
+	case engine.CombatStalemate:
+		fmt.Println(c(fmt.Sprintf("Neither you nor the %s can land a telling blow. You disengage.", ev.EnemyID), yellow))
 
 	case engine.PlayerDefeated:
 		fmt.Println(cs("You were defeated.", bold, red))
 
+	case engine.CombatTotals:
+		fmt.Println(c(format.Totals(ev)+".", dim))
+
 	case engine.LevelUp:
 		fmt.Println(cs(fmt.Sprintf("Level up! Level %d. Max HP %d.", ev.NewLevel, ev.NewMaxHP), bold, magenta))
 
+	case engine.LevelsGained:
+		fmt.Println(cs(format.LevelUps(ev.Count, ev.NewLevel, ev.NewMaxHP)+".", bold, magenta))
+
+	case engine.AchievementUnlocked:
+		fmt.Println(cs(fmt.Sprintf("Achievement unlocked: %s!", ev.Name), bold, magenta))
+
 	case engine.ItemAdded:
-		fmt.Println(c(fmt.Sprintf("Obtained %s x%d.", ev.ItemID, ev.Count), cyan))
+		fmt.Println(c(fmt.Sprintf("Obtained %s x%d.", format.Affixed(format.ItemName(ev.ItemID), ev.Affix), ev.Count), cyan))
+
+	case engine.UpgradeAvailable:
+		fmt.Println(cs(format.Upgrade(ev), bold, green))
+
+	case engine.InventoryFull:
+		fmt.Println(c(fmt.Sprintf("Too heavy: left %s x%d behind.", format.ItemName(ev.ItemID), ev.Dropped), yellow))
+
+	case engine.LootFound:
+		names := make([]string, 0, len(ev.Items))
+		for _, it := range ev.Items {
+			names = append(names, format.ItemName(it))
+		}
+		fmt.Println(c("Loot: "+strings.Join(names, ", "), cyan))
+
+	case engine.ItemCrafted:
+		fmt.Println(cs(fmt.Sprintf("Crafted %s x%d.", format.ItemName(ev.ItemID), ev.Count), bold, green))
 
 	case engine.GoldGained:
-		fmt.Println(c(fmt.Sprintf("Gained %d gold.", ev.Amount), yellow))
+		fmt.Println(c(fmt.Sprintf("Gained %s gold.", format.Int(ev.Amount)), yellow))
+
+	case engine.PriceQuoted:
+		fmt.Println(c(fmt.Sprintf("%s x%d would fetch %s gold.", format.ItemName(ev.ItemID), ev.Qty, format.Int(ev.Price)), cyan))
+
+	case engine.Haggled:
+		if ev.WalkedAway {
+			fmt.Println(c(fmt.Sprintf("The merchant walks away; you keep your %s, but they won't haggle over it for a while.", format.ItemName(ev.ItemID)), yellow))
+		} else {
+			fmt.Println(c(fmt.Sprintf("You haggle %s gold to %s.", format.Int(ev.Quote), format.Int(ev.Price)), cyan))
+		}
+
+	case engine.StatTrained:
+		fmt.Println(cs(fmt.Sprintf("Trained %s: +%d (×%d).", ev.Stat, ev.Amount, ev.Times), bold, green))
+
+	case engine.ItemWorn:
+		if ev.Durability == 0 {
+			fmt.Println(c(fmt.Sprintf("%s breaks! Repair it to restore its bonus.", format.ItemName(ev.ItemID)), yellow))
+		} else {
+			fmt.Println(c(fmt.Sprintf("%s wears (%d/%d).", format.ItemName(ev.ItemID), ev.Durability, ev.MaxDurability), dim))
+		}
+
+	case engine.ItemRepaired:
+		fmt.Println(c(fmt.Sprintf("Repaired %s (%d/%d).", format.ItemName(ev.ItemID), ev.Durability, ev.Durability), green))
+
+	case engine.TurnStarted:
+		parts := make([]string, 0, len(ev.Targets))
+		for _, t := range ev.Targets {
+			parts = append(parts, fmt.Sprintf("%d) %s %d HP", t.Index, t.EnemyID, t.HP))
+		}
+		fmt.Println(cs(fmt.Sprintf("Round %d — attack which? ", ev.Turn)+strings.Join(parts, "  "), bold, yellow))
+
+	case engine.ChoiceOffered:
+		fmt.Println(c(fmt.Sprintf("The cache holds %s gold and %s. Take %s?",
+			format.Int(ev.Gold), format.ItemName(ev.Item), strings.Join(ev.Options, " | ")), yellow))
+
+	case engine.MerchantOffered:
+		fmt.Println(c(format.Merchant(ev), yellow))
+
+	case engine.MerchantLeft:
+		fmt.Println(c("The caravan rolls on.", dim))
+
+	case engine.GoldSpent:
+		fmt.Println(c(fmt.Sprintf("Spent %s gold.", format.Int(ev.Amount)), yellow))
+
+	case engine.GoldBanked:
+		fmt.Println(c(fmt.Sprintf("Deposited %s gold (%s banked).", format.Int(ev.Amount), format.Int(ev.Banked)), cyan))
+
+	case engine.GoldWithdrawn:
+		fmt.Println(c(fmt.Sprintf("Withdrew %s gold (%s banked).", format.Int(ev.Amount), format.Int(ev.Banked)), cyan))
+
+	case engine.InterestAccrued:
+		fmt.Println(c(fmt.Sprintf("Your bank paid %s gold interest (%s banked).", format.Int(ev.Amount), format.Int(ev.Banked)), green))
+
+	case engine.ItemEquipped:
+		fmt.Println(c(fmt.Sprintf("Equipped %s (%s).", format.ItemName(ev.ItemID), ev.Slot), cyan))
+
+	case engine.ItemUnequipped:
+		fmt.Println(c(fmt.Sprintf("Took off %s.", format.ItemName(ev.ItemID)), dim))
+
+	case engine.CurseLifted:
+		fmt.Println(c(fmt.Sprintf("The curse on your %s lifts.", format.ItemName(ev.ItemID)), green))
+
+	case engine.SetBonusActive:
+		fmt.Println(cs(fmt.Sprintf("Set complete: %s!", engine.ItemSets[ev.SetID].Name), bold, magenta))
+
+	case engine.SetBonusEnded:
+		fmt.Println(c(fmt.Sprintf("Set broken: %s.", engine.ItemSets[ev.SetID].Name), dim))
+
+	case engine.BuffGained:
+		fmt.Println(c(fmt.Sprintf("+%d %s for %d encounters.", ev.Amount, ev.Stat, ev.Encounters), magenta))
+
+	case engine.BuffExpired:
+		fmt.Println(c(fmt.Sprintf("Your +%d %s buff wears off.", ev.Amount, ev.Stat), dim))
+
+	case engine.Prestiged:
+		fmt.Println(cs(fmt.Sprintf("Prestige %d! Back to level 1, now earning %d%% XP.", ev.Prestige, ev.XPPercent), bold, magenta))
+
+	case engine.WorldChanged:
+		fmt.Println(cs(fmt.Sprintf("The world shifts: %s.", engine.WorldModifiers[ev.To].Name), bold, cyan))
+
+	case engine.TimeChanged:
+		fmt.Println(c(timeChangedText(ev.To), cyan))
+
+	case engine.EncounterAvoided:
+		fmt.Println(c(fmt.Sprintf("You spot a %s, far too strong for you, and slip away.", ev.EnemyID), yellow))
+
+	case engine.EncounterEnded:
+		if ev.DungeonID == "" {
+			fmt.Println(c("You slip away from the fight unseen.", cyan))
+		} else {
+			fmt.Println(c(fmt.Sprintf("You slip out of %s unseen.", dungeonName(ev.DungeonID)), cyan))
+		}
+
+	case engine.DungeonEntered:
+		fmt.Println(cs(fmt.Sprintf("You enter %s. %d fights lie ahead.", dungeonName(ev.DungeonID), ev.Stages), bold, yellow))
+
+	case engine.DungeonCleared:
+		fmt.Println(cs(fmt.Sprintf("%s cleared!", dungeonName(ev.DungeonID)), bold, magenta))
+
+	case engine.DungeonFailed:
+		fmt.Println(c(fmt.Sprintf("You leave %s behind.", dungeonName(ev.DungeonID)), red))
+
+	case engine.SPRegained:
+		fmt.Println(c(fmt.Sprintf("You feel refreshed. +%d SP.", ev.Amount), blue))
+	}
+}
+
+func dungeonName(id string) string {
+	if d, ok := engine.Dungeons[id]; ok {
+		return d.Name
+	}
+	return id
+}
+
+func timeChangedText(phase string) string {
+	if phase == engine.TimeNight {
+		return "Night falls. The dead stir."
 	}
+	return "Day breaks. Beasts roam the wilds."
 }
@@ -1,15 +1,29 @@
 package cli
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/divijg19/Grimoire/internal/ui/commands"
+)
+
+// PrintHelp prints the command list, or detailed help for one command.
+func PrintHelp(args []string) {
+	if len(args) > 0 {
+		lines, err := commands.Help(args[0], false)
+		if err != nil {
+			fmt.Println(c(err.Error()+". Type 'help'.", yellow))
+			return
+		}
+		fmt.Println(cs(lines[0], bold, green))
+		for _, l := range lines[1:] {
+			fmt.Println(c(l, dim))
+		}
+		return
+	}
 
-// PrintHelp prints available CLI commands.
-func PrintHelp() {
 	fmt.Println(cs("Commands:", bold, cyan))
-	fmt.Println(cs("status", bold, green) + " " + c("Show HUD", dim))
-	fmt.Println(cs("explore", bold, green) + " " + c("Explore for events/loot/enemies", dim))
-	fmt.Println(cs("hunt [extra_sp]", bold, green) + " " + c("Hunt enemies; stake extra SP", dim))
-	fmt.Println(cs("rest [sp]", bold, green) + " " + c("Convert SP into HP", dim))
-	fmt.Println(cs("use <item_id>", bold, green) + " " + c("Use an item", dim))
-	fmt.Println(cs("save", bold, green) + " " + c("Save game", dim))
-	fmt.Println(cs("exit / quit", bold, green) + " " + c("Save and exit", dim))
+	for _, cmd := range commands.For(false) {
+		fmt.Println(cs(cmd.Usage, bold, green) + " " + c(cmd.Summary, dim))
+	}
+	fmt.Println(c("Type 'help <command>' for details.", dim))
 }
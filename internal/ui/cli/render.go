@@ -4,8 +4,15 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/term"
 
 	"github.com/divijg19/Grimoire/internal/engine"
+	"github.com/divijg19/Grimoire/internal/ui/format"
 )
 
 // ================================
@@ -60,86 +67,314 @@ const (
 // HUD
 // ================================
 
+const (
+	defaultHUDWidth = 64
+	minHUDWidth     = 40
+	maxHUDWidth     = 100
+
+	// defaultBarWidth is the HP/XP bar width at defaultHUDWidth; bars scale
+	// proportionally with the HUD.
+	defaultBarWidth = 30
+)
+
+// hudWidth sizes the HUD to the terminal (or $COLUMNS), clamped to
+// [minHUDWidth, maxHUDWidth], falling back to defaultHUDWidth.
+func hudWidth() int {
+	if w, _, err := term.GetSize(os.Stdout.Fd()); err == nil && w > 0 {
+		return clampHUDWidth(w)
+	}
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		return clampHUDWidth(cols)
+	}
+	return defaultHUDWidth
+}
+
+func clampHUDWidth(w int) int {
+	return min(maxHUDWidth, max(minHUDWidth, w))
+}
+
+func barWidth(width int) int {
+	return width * defaultBarWidth / defaultHUDWidth
+}
+
 func RenderHUD(state *engine.State) {
-	p := state.Player
-	width := 64
+	printLines(hudLines(state, hudWidth()))
+}
 
-	hr := "+" + repeat("-", width-2) + "+"
-	title := fmt.Sprintf(" %s (%s) - Lv %d ", p.Name, p.Class, p.Level)
-	loc := state.Meta.Location
+// RenderHP prints a compact view showing only the HP line (used after commands)
+func RenderHP(state *engine.State) {
+	printLines(hpLines(state, hudWidth()))
+}
 
-	//header := "|" + padRight(title, width-2-len(loc)) + loc + "|"
+func hudLines(state *engine.State, width int) []string {
+	p := state.Player
+	hr := "+" + repeat("-", width-2) + "+"
 
-	fmt.Println(c(hr, cyan))
-	// keep padding calculations on raw strings, then apply bold+cyan to the
-	// title portion when printing so alignment stays correct.
-	leftRaw := padRight(title, width-2-len(loc))
-	fmt.Println(cs("|"+leftRaw, bold, cyan) + cs(loc+"|", cyan))
+	lines := []string{c(hr, cyan), headerLine(state, width)}
 
 	// HP bar
-	hpBar := bar(p.HP, p.MaxHP, 30)
+	hpBar := bar(p.HP, p.MaxHP, barWidth(width))
 	hpLine := fmt.Sprintf(
 		"| HP %s %s %d/%d",
 		hpBar, heart, p.HP, p.MaxHP,
 	)
-	fmt.Println(colorByRatio(hpLine, p.HP, p.MaxHP))
+	lines = append(lines, colorByRatio(hpLine, p.HP, p.MaxHP, width))
 
 	// SP bar
-	spBar := "[" + repeat("●", min(p.SP, 12)) + repeat(" ", max(0, 12-p.SP)) + "]"
-	spLine := fmt.Sprintf("| SP %s %s %d", spBar, spark, p.SP)
-	fmt.Println(cs(padRight(spLine, width-1)+"|", magenta, bold))
+	spFilled := 0
+	if p.MaxSP > 0 {
+		spFilled = min(12, p.SP*12/p.MaxSP)
+	}
+	spBar := "[" + repeat("●", spFilled) + repeat(" ", 12-spFilled) + "]"
+	spLine := fmt.Sprintf("| SP %s %s %d/%d", spBar, spark, p.SP, p.MaxSP)
+	lines = append(lines, cs(fit(spLine, width-1)+"|", magenta, bold))
 
 	// XP
 	need := engine.XPToNext(p.Level)
-	xpBar := bar(p.XP, need, 30)
-	xpLine := fmt.Sprintf("| XP %s %d/%d", xpBar, p.XP, need)
-	fmt.Println(cs(padRight(xpLine, width-1)+"|", blue, bold))
+	xpBar := bar(p.XP, need, barWidth(width))
+	xpLine := fmt.Sprintf("| XP %s %s/%s", xpBar, format.Int(p.XP), format.Int(need))
+	lines = append(lines, cs(fit(xpLine, width-1)+"|", blue, bold))
 
 	// Resources
 	res := fmt.Sprintf(
-		"| Gold: %d | Commands: %d",
-		p.Gold, state.Meta.CommandCount,
+		"| Gold: %s | Commands: %s",
+		format.Int(p.Gold), format.Int(state.Meta.CommandCount),
 	)
-	fmt.Println(cs(padRight(res, width-1)+"|", cyan, bold))
+	if phase := state.Meta.TimeOfDay; phase != "" {
+		res += " | " + format.PhaseName(phase)
+	}
+	lines = append(lines, cs(fit(res, width-1)+"|", cyan, bold))
 
 	// Inventory
-	fmt.Println(cs("| Inventory:", bold, cyan))
+	header := "| Inventory:"
+	if p.MaxCarryWeight > 0 {
+		header = fmt.Sprintf("| Inventory (weight %d/%d):", engine.CarryWeight(&p), p.MaxCarryWeight)
+	}
+	lines = append(lines, cs(fit(header, width-1)+"|", bold, cyan))
 	if len(p.Inventory) == 0 {
-		fmt.Println(c("|  (empty)", dim))
+		lines = append(lines, c(fit("|  (empty)", width-1)+"|", dim))
 	} else {
-		renderInventory(p, width)
+		lines = append(lines, inventoryLines(p, engine.Cooldowns(state), width)...)
 	}
 
-	fmt.Println(c(hr, cyan))
+	return append(lines, c(hr, cyan))
 }
 
-// RenderHP prints a compact view showing only the HP line (used after commands)
-func RenderHP(state *engine.State) {
+func hpLines(state *engine.State, width int) []string {
 	p := state.Player
-	width := 64
-
 	hr := "+" + repeat("-", width-2) + "+"
+
+	hpBar := bar(p.HP, p.MaxHP, barWidth(width))
+	hpLine := fmt.Sprintf("| HP %s %s %d/%d", hpBar, heart, p.HP, p.MaxHP)
+
+	return []string{
+		c(hr, cyan),
+		headerLine(state, width),
+		colorByRatio(hpLine, p.HP, p.MaxHP, width),
+		c(hr, cyan),
+	}
+}
+
+// headerLine renders the bold title with the location right-aligned. Padding
+// is computed on the raw strings so alignment survives the color codes.
+func headerLine(state *engine.State, width int) string {
+	p := state.Player
 	title := fmt.Sprintf(" %s (%s) - Lv %d ", p.Name, p.Class, p.Level)
 	loc := state.Meta.Location
 
-	leftRaw := padRight(title, width-2-len(loc))
-	// compact header with bold title
-	fmt.Println(c(hr, cyan))
-	fmt.Println(cs("|"+leftRaw, bold, cyan) + cs(loc+"|", cyan))
+	inner := width - 2
+	if displayWidth(loc) > inner/2 {
+		loc = truncate(loc, inner/2)
+	}
+	leftRaw := fit(title, inner-displayWidth(loc))
+	return cs("|"+leftRaw, bold, cyan) + cs(loc+"|", cyan)
+}
 
-	// HP line only
-	hpBar := bar(p.HP, p.MaxHP, 30)
-	hpLine := fmt.Sprintf("| HP %s %s %d/%d", hpBar, heart, p.HP, p.MaxHP)
-	fmt.Println(colorByRatio(hpLine, p.HP, p.MaxHP))
+func printLines(lines []string) {
+	for _, l := range lines {
+		fmt.Println(l)
+	}
+}
+
+// ================================
+// Recipes
+// ================================
 
-	fmt.Println(c(hr, cyan))
+// RenderRecipes lists every crafting recipe with its ingredients.
+func RenderRecipes() {
+	fmt.Println(cs("Recipes:", bold, cyan))
+	for _, id := range engine.RecipeIDs() {
+		r := engine.Recipes[id]
+		line := fmt.Sprintf("%s: %s -> %s", id, engine.FormatIngredients(r.Inputs), format.ItemName(r.Output))
+		if r.GoldCost > 0 {
+			line += fmt.Sprintf(" (+%d gold)", r.GoldCost)
+		}
+		fmt.Println(cs(id, bold, green) + c(line[len(id):], dim))
+	}
+}
+
+// ================================
+// Achievements
+// ================================
+
+// RenderDungeons lists the dungeons and the active run's progress.
+func RenderDungeons(state *engine.State) {
+	fmt.Println(cs("Dungeons:", bold, cyan))
+	for _, id := range engine.DungeonIDs() {
+		d := engine.Dungeons[id]
+		fmt.Println(cs(id, bold, green) + " " + c(fmt.Sprintf("%s: %d fights, reward %d gold, %d XP, %s", d.Name, len(d.Enemies), d.Gold, d.XP, format.ItemName(d.Item)), dim))
+	}
+	if run := state.Dungeon; run != nil {
+		fmt.Println(c(format.Run(run)+".", yellow))
+	}
+}
+
+// RenderAchievements lists every achievement, marking the unlocked ones.
+func RenderAchievements(state *engine.State) {
+	fmt.Println(cs("Achievements:", bold, cyan))
+	for _, ach := range engine.Achievements {
+		if state.Meta.Achievements[ach.ID] {
+			fmt.Println(cs("[x] "+ach.Name, bold, green) + " " + c(ach.Description, dim))
+		} else {
+			fmt.Println(c("[ ] "+ach.Name+" "+ach.Description, dim))
+		}
+	}
+}
+
+// RenderSummary prints what the session achieved.
+func RenderSummary(s engine.Summary) {
+	fmt.Println(cs("Session summary:", bold, cyan))
+	fmt.Println(c(fmt.Sprintf("  Commands %s · Gold %s · XP +%s", format.Int(s.Commands), signedInt(s.Gold), format.Int(s.XP)), cyan))
+	fmt.Println(c(fmt.Sprintf("  Enemies defeated %d · Items found %d · Time %s", s.Kills, s.Items, s.Duration.Round(time.Second)), cyan))
+}
+
+func signedInt(n int) string {
+	if n >= 0 {
+		return "+" + format.Int(n)
+	}
+	return format.Int(n)
+}
+
+// RenderLeaderboard prints ranked save slots as a table.
+func RenderLeaderboard(entries []engine.LeaderboardEntry) {
+	fmt.Println(cs("Leaderboard:", bold, cyan))
+	if len(entries) == 0 {
+		fmt.Println(c("No saves found.", dim))
+		return
+	}
+	fmt.Println(c(fmt.Sprintf("%-3s %-14s %-14s %5s %7s %6s", "#", "Slot", "Name", "Level", "Gold", "Cmds"), dim))
+	for i, e := range entries {
+		row := fmt.Sprintf("%-3d %s %s %5d %7d %6d",
+			i+1, padRight(truncate(e.Slot, 14), 14), padRight(truncate(e.Name, 14), 14), e.Level, e.Gold, e.Commands)
+		if i == 0 {
+			row = cs(row, bold, yellow)
+		}
+		fmt.Println(row)
+	}
+}
+
+// RenderBestiary lists every enemy; ones never encountered stay hidden.
+func RenderBestiary(state *engine.State) {
+	fmt.Println(cs("Bestiary:", bold, cyan))
+	for _, e := range engine.AllEnemies() {
+		entry, ok := state.Bestiary[e.ID]
+		if !ok || entry.Seen == 0 {
+			fmt.Println(c("??? (not yet encountered)", dim))
+			continue
+		}
+		fmt.Println(cs(e.Name, bold, red) + " " + c(fmt.Sprintf("HP %d  ATK %d-%d  XP %d  Gold %d", e.HP, e.AttackMin, e.AttackMax, e.XP, e.Gold), dim))
+		fmt.Println(c(fmt.Sprintf("  seen %d, killed %d", entry.Seen, entry.Killed), dim))
+	}
+}
+
+// RenderSheet prints the character sheet's derived stats.
+func RenderSheet(state *engine.State) {
+	s := engine.CharacterSheet(state)
+	p := state.Player
+	fmt.Println(cs(fmt.Sprintf("%s (%s), level %d", p.Name, p.Class, s.Level), bold, cyan))
+	fmt.Println(c(fmt.Sprintf("XP %s/%s (%s to next, %d%% gain)", format.Int(s.XP), format.Int(s.XPToNext), format.Int(s.XPToNext-s.XP), s.XPPercent), blue))
+	fmt.Println(c(fmt.Sprintf("Attack %d-%d  Defense %d  Luck %d", s.AttackMin, s.AttackMax, s.Defense, s.Luck), green))
+	fmt.Println(c(fmt.Sprintf("Fights %d  Wins %d  Win rate %.0f%%  Biggest overkill %d", s.Fights, s.Wins, s.WinRate*100, s.MaxOverkill), dim))
+	for _, slot := range []string{engine.SlotWeapon, engine.SlotArmor, engine.SlotTrinket} {
+		if id, ok := p.Equipment[slot]; ok {
+			name := format.Affixed(format.ItemName(id), p.EquippedAffixes[slot])
+			fmt.Println(c(fmt.Sprintf("%s: %s%s", slot, name, durabilityNote(&p, id)), cyan))
+		}
+	}
+	for _, id := range s.Sets {
+		fmt.Println(cs("Set bonus: "+engine.ItemSets[id].Name, bold, magenta))
+	}
+}
+
+// RenderLootLog prints the n newest loot records, newest first.
+func RenderLootLog(state *engine.State, n int) {
+	records := engine.RecentLoot(state, n)
+	if len(records) == 0 {
+		fmt.Println(c(format.LootLogEmpty, dim))
+		return
+	}
+	fmt.Println(cs(format.LootLogTitle, bold, cyan))
+	for _, r := range records {
+		item, source := format.LootRow(r)
+		fmt.Println(item + " " + c(source, dim))
+	}
+}
+
+// RenderAnalytics prints the save's event counts, most frequent first.
+func RenderAnalytics(state *engine.State) {
+	tally := engine.EventTally(state)
+	if len(tally) == 0 {
+		fmt.Println(c(format.AnalyticsEmpty, dim))
+		return
+	}
+	fmt.Println(cs(format.AnalyticsTitle, bold, cyan))
+	for _, row := range tally {
+		fmt.Println(format.AnalyticsRow(row))
+	}
+}
+
+// durabilityNote renders " (7/10)" for gear that wears, or nothing.
+func durabilityNote(p *engine.Player, id string) string {
+	if it, _ := engine.ItemByID(id); it.MaxDurability > 0 {
+		return fmt.Sprintf(" (%d/%d)", engine.Durability(p, id), it.MaxDurability)
+	}
+	return ""
 }
 
 // ================================
 // Inventory
 // ================================
 
-func renderInventory(p engine.Player, width int) {
+// RenderInventory prints the inventory alone, one item per line, keeping
+// only items matching filter when it is set.
+func RenderInventory(state *engine.State, filter string) {
+	printLines(inventoryListLines(state, filter))
+}
+
+func inventoryListLines(state *engine.State, filter string) []string {
+	lines := []string{cs("Inventory", bold, cyan)}
+	entries := engine.InventoryList(&state.Player, filter)
+	if len(entries) == 0 {
+		if filter != "" {
+			return append(lines, c(fmt.Sprintf("  Nothing matches %q.", filter), dim))
+		}
+		return append(lines, c("  (empty)", dim))
+	}
+	cooling := engine.Cooldowns(state)
+	for _, e := range entries {
+		line := "  - " + format.InventoryItem(e.Name, e.Count, e.Rare, e.Slot)
+		if n := cooling[e.ID]; n > 0 && e.Slot == "" {
+			lines = append(lines, c(fmt.Sprintf("%s (cd %d)", line, n), dim))
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// inventoryLines lays the inventory out in two columns. Items cooling down
+// are marked with the commands left before they can be used again.
+func inventoryLines(p engine.Player, cooling map[string]int, width int) []string {
 	type entry struct {
 		id    string
 		count int
@@ -153,22 +388,46 @@ func renderInventory(p engine.Player, width int) {
 	// keep inventory display deterministic
 	sort.Slice(items, func(i, j int) bool { return items[i].id < items[j].id })
 
+	// columns are measured in display width, so long or wide-rune names are
+	// truncated to their column instead of pushing the box border out.
 	colW := (width - 6) / 2
+	lines := make([]string, 0, (len(items)+1)/2)
 	for i := 0; i < len(items); i += 2 {
-		left := fmt.Sprintf("  - %s x%d", items[i].id, items[i].count)
+		left := inventoryCell(items[i].id, items[i].count, cooling[items[i].id], colW)
 		right := ""
 		if i+1 < len(items) {
-			right = fmt.Sprintf("  - %s x%d", items[i+1].id, items[i+1].count)
+			right = inventoryCell(items[i+1].id, items[i+1].count, cooling[items[i+1].id], colW)
 		}
-		line := padRight(left, colW) + "  " + padRight(right, colW)
-		fmt.Println(c("|"+padRight(line, width-2)+"|", dim))
+		line := fit(left, colW) + "  " + fit(right, colW)
+		lines = append(lines, c("|"+fit(line, width-2)+"|", dim))
 	}
+	return lines
+}
+
+// inventoryCell renders "  - Name xN" within colW columns, truncating the
+// name rather than the count. A cooling item gets " (cd N)" after it.
+func inventoryCell(itemID string, count, cooldown, colW int) string {
+	prefix := "  - "
+	suffix := fmt.Sprintf(" x%d", count)
+	if cooldown > 0 {
+		suffix += fmt.Sprintf(" (cd %d)", cooldown)
+	}
+	nameW := colW - displayWidth(prefix) - displayWidth(suffix)
+	return prefix + truncate(format.ItemName(itemID), nameW) + suffix
 }
 
 // ================================
 // Helpers
 // ================================
 
+// encounterName is "scarred goblin" for a variant, else the enemy ID.
+func encounterName(ev engine.EncounterStarted) string {
+	if ev.Name != "" {
+		return strings.ToLower(ev.Name)
+	}
+	return ev.EnemyID
+}
+
 func bar(cur, max, w int) string {
 	if max <= 0 {
 		return "[" + repeat(" ", w) + "]"
@@ -177,15 +436,30 @@ func bar(cur, max, w int) string {
 	return "[" + repeat("█", filled) + repeat(" ", w-filled) + "]"
 }
 
-func colorByRatio(line string, cur, max int) string {
-	ratio := float64(cur) / float64(max)
+// RatioThresholds are the fill ratios below which colorByRatio turns a bar
+// red (Low) or yellow (Medium); anything else is green.
+type RatioThresholds struct {
+	Low    float64
+	Medium float64
+}
+
+// HPColors colors the HUD's HP line.
+var HPColors = RatioThresholds{Low: 0.4, Medium: 0.75}
+
+// colorByRatio pads line to the HUD width, closes it with the right border
+// and colors it by cur/max against HPColors.
+func colorByRatio(line string, cur, max, width int) string {
+	ratio := 0.0
+	if max > 0 {
+		ratio = float64(cur) / float64(max)
+	}
 	switch {
-	case ratio < 0.4:
-		return c(padRight(line, 63)+"|", red)
-	case ratio < 0.75:
-		return c(padRight(line, 63)+"|", yellow)
+	case ratio < HPColors.Low:
+		return c(fit(line, width-1)+"|", red)
+	case ratio < HPColors.Medium:
+		return c(fit(line, width-1)+"|", yellow)
 	default:
-		return c(padRight(line, 63)+"|", green)
+		return c(fit(line, width-1)+"|", green)
 	}
 }
 
@@ -197,9 +471,31 @@ func repeat(s string, n int) string {
 	return out
 }
 
+// padRight pads s with spaces to n display columns.
 func padRight(s string, n int) string {
-	for len(s) < n {
-		s += " "
+	if w := displayWidth(s); w < n {
+		s += repeat(" ", n-w)
 	}
 	return s
 }
+
+// fit truncates or pads s to exactly n display columns.
+func fit(s string, n int) string {
+	return padRight(truncate(s, n), n)
+}
+
+func truncate(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if displayWidth(s) <= n {
+		return s
+	}
+	return ansi.Truncate(s, n, "…")
+}
+
+// displayWidth measures terminal columns rather than bytes, so multi-byte
+// and wide runes align correctly.
+func displayWidth(s string) int {
+	return ansi.StringWidth(s)
+}
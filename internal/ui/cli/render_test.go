@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+func TestHUDLines_FitTargetWidth(t *testing.T) {
+	noColor = true
+	defer func() { noColor = false }()
+
+	state := engine.DefaultState()
+	state.Player.Inventory["healing_potion"] = 3
+	state.Player.Inventory["orcish_blade"] = 1
+
+	for _, width := range []int{minHUDWidth, 52, defaultHUDWidth, 80, maxHUDWidth} {
+		for i, line := range hudLines(&state, width) {
+			if w := displayWidth(line); w != width {
+				t.Fatalf("width %d: hud line %d has width %d: %q", width, i, w, line)
+			}
+		}
+		for i, line := range hpLines(&state, width) {
+			if w := displayWidth(line); w != width {
+				t.Fatalf("width %d: hp line %d has width %d: %q", width, i, w, line)
+			}
+		}
+	}
+}
+
+func TestBarWidth_ScalesWithHUD(t *testing.T) {
+	if got := barWidth(defaultHUDWidth); got != defaultBarWidth {
+		t.Fatalf("expected default bar width %d, got %d", defaultBarWidth, got)
+	}
+	if barWidth(maxHUDWidth) <= barWidth(minHUDWidth) {
+		t.Fatalf("expected wider HUD to produce wider bars")
+	}
+}
+
+func TestClampHUDWidth_Bounds(t *testing.T) {
+	if got := clampHUDWidth(10); got != minHUDWidth {
+		t.Fatalf("expected min clamp %d, got %d", minHUDWidth, got)
+	}
+	if got := clampHUDWidth(500); got != maxHUDWidth {
+		t.Fatalf("expected max clamp %d, got %d", maxHUDWidth, got)
+	}
+}
+
+func TestInventoryLines_UseDisplayNamesAndStayAligned(t *testing.T) {
+	noColor = true
+	defer func() { noColor = false }()
+
+	engine.Items["test_long_item"] = engine.Item{ID: "test_long_item", Name: "Exceedingly Long Ceremonial Greatsword of Dawn"}
+	engine.Items["test_wide_item"] = engine.Item{ID: "test_wide_item", Name: "龍の牙の護符"}
+	defer delete(engine.Items, "test_long_item")
+	defer delete(engine.Items, "test_wide_item")
+
+	p := engine.DefaultState().Player
+	p.Inventory["test_long_item"] = 1
+	p.Inventory["test_wide_item"] = 2
+
+	width := defaultHUDWidth
+	colW := (width - 6) / 2
+	lines := inventoryLines(p, nil, width)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 two-column rows, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if w := displayWidth(line); w != width {
+			t.Fatalf("inventory row %d has width %d, want %d: %q", i, w, width, line)
+		}
+	}
+
+	joined := lines[0] + lines[1]
+	for _, want := range []string{"Rusty Dagger x1", "Torch x1", "x2", "Exceedingly"} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("expected %q in inventory rows: %q", want, joined)
+		}
+	}
+	if strings.Contains(joined, "rusty_dagger") {
+		t.Fatalf("expected display names instead of raw IDs: %q", joined)
+	}
+
+	// Left cell is exactly colW wide, so the right column starts at the same
+	// display offset on every row.
+	for _, line := range lines {
+		left := ansi.Cut(line, 1, 1+colW)
+		if w := displayWidth(left); w != colW {
+			t.Fatalf("left column width %d, want %d in %q", w, colW, line)
+		}
+		if !strings.HasPrefix(ansi.Cut(line, 1+colW, 1+colW+4), "  ") {
+			t.Fatalf("expected column gap after left cell in %q", line)
+		}
+	}
+}
+
+func TestColorByRatio_ThresholdsAndWidth(t *testing.T) {
+	saved, savedNoColor := HPColors, noColor
+	defer func() { HPColors, noColor = saved, savedNoColor }()
+	noColor = false
+
+	for _, tc := range []struct {
+		cur  int
+		want color
+	}{
+		{cur: 39, want: red},
+		{cur: 40, want: yellow},
+		{cur: 74, want: yellow},
+		{cur: 75, want: green},
+	} {
+		if got := colorByRatio("| HP", tc.cur, 100, 50); !strings.HasPrefix(got, string(tc.want)) {
+			t.Fatalf("%d/100: expected color %q, got %q", tc.cur, tc.want, got)
+		}
+	}
+
+	HPColors = RatioThresholds{Low: 0.2, Medium: 0.5}
+	if got := colorByRatio("| HP", 39, 100, 50); !strings.HasPrefix(got, string(yellow)) {
+		t.Fatalf("expected configured thresholds to make 39/100 yellow, got %q", got)
+	}
+
+	for _, width := range []int{minHUDWidth, defaultHUDWidth, maxHUDWidth} {
+		if w := displayWidth(colorByRatio("| HP", 10, 100, width)); w != width {
+			t.Fatalf("expected padding to width %d, got %d", width, w)
+		}
+	}
+}
+
+func TestInventoryListLines_ListsEveryItemWithNameAndCount(t *testing.T) {
+	noColor = true
+	defer func() { noColor = false }()
+
+	state := engine.DefaultState()
+	state.Player.Inventory = map[string]int{"healing_potion": 3, "torch": 1, "ancient_coin": 2}
+	state.Player.Equipment = map[string]string{engine.SlotWeapon: "rusty_dagger"}
+
+	out := strings.Join(inventoryListLines(&state, ""), "\n")
+	for _, want := range []string{
+		engine.Items["healing_potion"].Name + " x3",
+		engine.Items["torch"].Name + " x1",
+		engine.Items["ancient_coin"].Name + " x2 (rare)",
+		engine.Items["rusty_dagger"].Name + " x1 [weapon]",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in inventory listing:\n%s", want, out)
+		}
+	}
+
+	filtered := strings.Join(inventoryListLines(&state, "potion"), "\n")
+	if !strings.Contains(filtered, engine.Items["healing_potion"].Name) || strings.Contains(filtered, engine.Items["torch"].Name) {
+		t.Fatalf("expected only the potion to match, got:\n%s", filtered)
+	}
+	if none := strings.Join(inventoryListLines(&state, "dragon"), "\n"); !strings.Contains(none, "Nothing matches") {
+		t.Fatalf("expected a no-match note, got:\n%s", none)
+	}
+}
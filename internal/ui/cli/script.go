@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RunScript executes commands from r line by line, echoing each one. Blank
+// lines (other than a reply to a pending prompt) and lines starting with '#'
+// are skipped, and `exit`/`quit` ends the
+// script early. A failing line is reported and the script carries on, unless
+// strict is set, in which case RunScript stops and returns that error. The
+// state is saved once the script ends either way.
+func (a *App) RunScript(r io.Reader, strict bool) error {
+	defer func() { _ = a.store.Save(a.state) }()
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if (line == "" && a.confirm == nil) || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line == "exit" || line == "quit" {
+			break
+		}
+
+		fmt.Println(cs("> ", bold, cyan) + line)
+		if err := a.dispatch(line); err != nil && strict {
+			return fmt.Errorf("line %d: %s: %w", n, line, err)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,212 @@
+// Package commands is the command registry both UIs draw their help and
+// completion from.
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+// Command describes one command the player can type.
+type Command struct {
+	Name    string
+	Aliases []string
+	Usage   string
+	Summary string
+	Detail  []string
+
+	// TUIOnly commands are hidden from the CLI.
+	TUIOnly bool
+
+	// NoComplete keeps a rarely typed command out of tab completion so it
+	// doesn't shadow a common prefix (export vs explore).
+	NoComplete bool
+}
+
+// DefaultLootLogLines is how many entries `lootlog` shows without a count.
+const DefaultLootLogLines = 10
+
+// LootLogCount parses `lootlog [n]`.
+func LootLogCount(args []string) (int, error) {
+	if len(args) == 0 {
+		return DefaultLootLogLines, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n <= 0 {
+		return 0, errors.New("usage: lootlog [n]")
+	}
+	return n, nil
+}
+
+// All returns every command in help order. Details quoting tunable numbers
+// are built on each call so they stay current.
+func All() []Command {
+	hunt := engine.HuntTunables
+	return []Command{
+		{Name: "help", Usage: "help [command]", Summary: "List commands, or explain one"},
+		{Name: "status", Usage: "status", Summary: "Show the HUD"},
+		{Name: "profile", Usage: "profile", Summary: "Show derived stats: attack, defense, win rate",
+			Detail: []string{"Attack and defense include buffs, equipment and set bonuses."}},
+		{Name: "score", Usage: "score", Summary: "Show the challenge score for this run",
+			Detail: []string{"Score is gold + level×100 + enemies killed."}},
+		{Name: "explore", Usage: "explore [times]", Summary: "Explore for treasure, items, gold or a fight",
+			Detail: []string{
+				"Costs no SP. Luck and the world modifier widen the treasure and item odds.",
+				fmt.Sprintf("`explore 5` explores five times in a row (at most %d), stopping early if you fall or something needs an answer.", engine.MaxRepeat),
+			}},
+		{Name: "hunt", Usage: "hunt [enemy_id] [extra_sp] [times]", Summary: "Hunt enemies; stake extra SP for more reward",
+			Detail: []string{
+				fmt.Sprintf("Costs %d SP, plus up to %d extra SP staked.", engine.HuntBaseSP, engine.HuntExtraSPMax),
+				fmt.Sprintf("Each extra SP adds ×%.2f to XP and gold (×%.2f at the maximum stake).",
+					hunt.RewardPerSP, hunt.Multiplier(engine.HuntExtraSPMax)),
+				"Staking also makes tougher enemies more likely.",
+				"Name an enemy (e.g. `hunt wolf 1`) to hunt it alone, skipping the random pick.",
+				"A count after the stake repeats the hunt (`hunt 0 5`), stopping early if you fall or run out of SP.",
+			}},
+		{Name: "attack", Usage: "attack <target>", Summary: "Strike one enemy in a targeted fight",
+			Detail: []string{"Targets are numbered each round. Only attack and use work until the fight ends."}},
+		{Name: "targeting", Usage: "targeting [on|off]", Summary: "Pick targets yourself when a pack attacks"},
+		{Name: "rest", Usage: "rest [sp]", Summary: "Convert SP into HP (default 1 SP)",
+			Detail: []string{fmt.Sprintf("Each SP restores %d HP, varying by class. Not allowed inside a dungeon.", engine.RestHPPerSP)}},
+		{Name: "camp", Aliases: []string{"wait"}, Usage: "camp", Summary: "Recover HP and SP; risk an ambush",
+			Detail: []string{fmt.Sprintf("Restores %d HP and %d SP. %.0f%% of camps are ambushed.",
+				engine.CampHP, engine.CampSP, engine.CampAmbushChance*100)}},
+		{Name: "take", Usage: "take gold | item | both", Summary: "Choose what to take from a large treasure",
+			Detail: []string{"Other gameplay commands wait until you choose. Both is offered only if the item fits."}},
+		{Name: "trade", Usage: "trade buy | sell | leave", Summary: "Answer a merchant caravan met while exploring",
+			Detail: []string{"Buy its rare item at a discount or sell it what it wants at a premium; after one trade it moves on."}},
+		{Name: "inventory", Aliases: []string{"inv"}, Usage: "inventory [filter]", Summary: "List your items, or only those matching filter",
+			Detail: []string{"Shows names, counts, rare items and what you have equipped."}},
+		{Name: "examine", Usage: "examine <item_id>", Summary: "Describe an item and what it does", NoComplete: true},
+		{Name: "use", Usage: "use <item_id>", Summary: "Use an item, e.g. healing_potion"},
+		{Name: "equip", Usage: "equip <item_id>", Summary: "Wear a weapon, armor or trinket",
+			Detail: []string{"Whatever was in the slot goes back to the inventory. Matching gear completes item sets."}},
+		{Name: "unequip", Usage: "unequip <slot|item_id>", Summary: "Take gear off"},
+		{Name: "repair", Usage: "repair <item_id|slot>", Summary: "Pay gold to restore worn gear",
+			Detail: []string{
+				"Gear loses a durability point each fight and its bonus shrinks as it wears; broken gear gives none.",
+				fmt.Sprintf("Repairs cost %d gold per point and can't be done inside a dungeon.", engine.RepairGoldPerPoint),
+			}},
+		{Name: "dungeon", Usage: "dungeon enter <id> | next | leave", Summary: "Run a dungeon's fights in order",
+			Detail: []string{"Clearing every fight pays a bonus. Losing a fight or leaving ends the run."}},
+		{Name: "dungeons", Usage: "dungeons", Summary: "List dungeons and run progress"},
+		{Name: "revive", Usage: "revive", Summary: "Pay gold to get back up in town (HP 0 only)",
+			Detail: []string{fmt.Sprintf("Costs %d gold plus %d per level.", engine.ReviveBaseCost, engine.ReviveCostPerLevel)}},
+		{Name: "train", Usage: "train hp | sp | attack", Summary: "Pay gold for a small permanent stat boost",
+			Detail: []string{
+				fmt.Sprintf("Each training adds %d max HP, %d max SP or %d attack.", engine.TrainHPAmount, engine.TrainSPAmount, engine.TrainAttackAmount),
+				fmt.Sprintf("The first costs %d gold; each further training of a stat costs %d more. Town only.", engine.TrainBaseCost, engine.TrainBaseCost),
+			}},
+		{Name: "prestige", Usage: "prestige", Summary: "Reset to level 1 for a permanent XP bonus",
+			Detail: []string{fmt.Sprintf("Requires level %d. Each prestige adds %d%% XP.", engine.PrestigeMinLevel, engine.PrestigeXPBonusPercent)}},
+		{Name: "craft", Usage: "craft <recipe>", Summary: "Craft an item from ingredients"},
+		{Name: "sell", Usage: "sell junk | all | <item> [qty] | price <item> [qty] | haggle <item> [qty]", Summary: "Sell junk items, everything (asks first), or one item",
+			Detail: []string{"`sell price` shows the offer without selling. With --haggle, `sell haggle` tries for a better price once, but the merchant may walk away."}},
+		{Name: "bank", Usage: "bank", Summary: "Show banked gold and the interest it earns",
+			Detail: []string{
+				fmt.Sprintf("Banked gold earns %d%% every %d commands and is safe from revive fees.", engine.InterestPercent, engine.InterestInterval),
+			}},
+		{Name: "deposit", Usage: "deposit <n|all>", Summary: "Bank gold from your purse (not in dungeons)"},
+		{Name: "withdraw", Usage: "withdraw <n|all>", Summary: "Take banked gold back out (not in dungeons)"},
+		{Name: "recipes", Usage: "recipes", Summary: "List crafting recipes"},
+		{Name: "achievements", Usage: "achievements", Summary: "List achievements"},
+		{Name: "bestiary", Usage: "bestiary", Summary: "Show the enemies you have met"},
+		{Name: "leaderboard", Aliases: []string{"top"}, Usage: "leaderboard", Summary: "Rank every save slot"},
+		{Name: "undo", Usage: "undo", Summary: "Revert the last gameplay command"},
+		{Name: "loot", Usage: "loot [summary|items]", Summary: "Toggle loot display mode"},
+		{Name: "lootlog", Usage: "lootlog [n]", Summary: fmt.Sprintf("List the last n items gained (default %d) and where they came from", DefaultLootLogLines)},
+		{Name: "verbosity", Usage: "verbosity [verbose|summary]", Summary: "Show every hit, or each fight's start, outcome and damage totals"},
+		{Name: "levelups", Usage: "levelups [summary|each]", Summary: "Show several level-ups as one line, or each level"},
+		{Name: "bell", Usage: "bell [on|off]", Summary: "Alert on level-ups, rare drops and defeat"},
+		{Name: "scrollback", Usage: "scrollback [lines]", Summary: "Show or set how many log lines are kept", TUIOnly: true,
+			Detail: []string{"Defaults to 300. Scroll past the top of the log to page through older lines."}},
+		{Name: "export", Usage: "export log <file>", Summary: "Write the kept log to a text file", TUIOnly: true, NoComplete: true},
+		{Name: "theme", Usage: "theme [name]", Summary: "Show or switch color theme", TUIOnly: true},
+		{Name: "new", Usage: "new", Summary: "Archive the save and start over"},
+		{Name: "analytics", Usage: "analytics", Summary: "Count every kind of event this save has seen"},
+		{Name: "simulate", Aliases: []string{"sim"}, Usage: "simulate <enemy_id> [fights] [seed]", Summary: "Simulate fights against an enemy without playing them",
+			Detail: []string{fmt.Sprintf("Runs %d fights by default from seed %d against a copy of you; nothing in your game changes.", DefaultSimFights, DefaultSimSeed)}},
+		{Name: "version", Usage: "version", Summary: "Show the build version and save schema"},
+		{Name: "save", Usage: "save", Summary: "Save game"},
+		{Name: "autosave", Usage: "autosave [on|off]", Summary: "Save after every command, or only on save/exit",
+			Detail: []string{"With autosave off, quitting without `exit` discards unsaved changes (the TUI asks first)."}},
+		{Name: "exit", Aliases: []string{"quit"}, Usage: "exit", Summary: "Save and exit"},
+	}
+}
+
+// For returns the commands shown in one UI.
+func For(tui bool) []Command {
+	var out []Command
+	for _, c := range All() {
+		if tui || !c.TUIOnly {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Words returns every completable command name and alias in a UI, in help
+// order.
+func Words(tui bool) []string {
+	var words []string
+	for _, c := range For(tui) {
+		if c.NoComplete {
+			continue
+		}
+		words = append(words, c.Name)
+		words = append(words, c.Aliases...)
+	}
+	return words
+}
+
+// Lookup finds a command by name or alias.
+func Lookup(name string, tui bool) (Command, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, c := range For(tui) {
+		if c.Name == name {
+			return c, true
+		}
+		for _, a := range c.Aliases {
+			if a == name {
+				return c, true
+			}
+		}
+	}
+	return Command{}, false
+}
+
+// Help returns the detailed help lines for one command.
+func Help(name string, tui bool) ([]string, error) {
+	c, ok := Lookup(name, tui)
+	if !ok {
+		return nil, fmt.Errorf("no such command: %s", name)
+	}
+	lines := []string{"Usage: " + c.Usage, c.Summary + "."}
+	lines = append(lines, c.Detail...)
+	if len(c.Aliases) > 0 {
+		lines = append(lines, "Also: "+strings.Join(c.Aliases, ", "))
+	}
+	return lines, nil
+}
+
+// Hint suggests a next step for an action error, or returns "".
+func Hint(err error) string {
+	switch {
+	case errors.Is(err, engine.ErrNotEnoughSP):
+		return "SP comes back over time; `camp` restores some now."
+	case errors.Is(err, engine.ErrItemNotFound):
+		return "`status` lists what you carry."
+	case errors.Is(err, engine.ErrUnknownItem), errors.Is(err, engine.ErrInvalidItem):
+		return "Item IDs look like healing_potion; Tab completes them."
+	case errors.Is(err, engine.ErrUnknownEnemy):
+		return "`bestiary` lists the enemies you can hunt."
+	case errors.Is(err, engine.ErrNoRestInDungeon):
+		return "`dungeon leave` returns to town."
+	default:
+		return ""
+	}
+}
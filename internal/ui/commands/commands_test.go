@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+func TestHelp_HuntMentionsSPCost(t *testing.T) {
+	lines, err := Help("hunt", false)
+	if err != nil {
+		t.Fatalf("Help returned error: %v", err)
+	}
+	text := strings.Join(lines, "\n")
+	for _, want := range []string{
+		fmt.Sprintf("Costs %d SP", engine.HuntBaseSP),
+		fmt.Sprintf("up to %d extra SP", engine.HuntExtraSPMax),
+		"Usage: hunt [enemy_id] [extra_sp]",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in hunt help:\n%s", want, text)
+		}
+	}
+}
+
+func TestHelp_UnknownCommand(t *testing.T) {
+	if _, err := Help("fly", false); err == nil || err.Error() != "no such command: fly" {
+		t.Fatalf("expected not-found error, got %v", err)
+	}
+	// theme only exists in the TUI.
+	if _, err := Help("theme", false); err == nil {
+		t.Fatal("expected theme to be unknown in the CLI")
+	}
+	if _, err := Help("theme", true); err != nil {
+		t.Fatalf("expected theme help in the TUI, got %v", err)
+	}
+}
+
+func TestLookup_FindsAliases(t *testing.T) {
+	c, ok := Lookup("quit", false)
+	if !ok || c.Name != "exit" {
+		t.Fatalf("expected quit to resolve to exit, got %+v", c)
+	}
+}
+
+func TestHint_SuggestsCampWhenOutOfSP(t *testing.T) {
+	err := fmt.Errorf("hunt: %w", engine.ErrNotEnoughSP)
+	if hint := Hint(err); !strings.Contains(hint, "camp") {
+		t.Fatalf("expected a camp hint, got %q", hint)
+	}
+	if hint := Hint(errors.New("something else")); hint != "" {
+		t.Fatalf("expected no hint, got %q", hint)
+	}
+}
+
+func TestSimulate_ReportsAndValidatesArgs(t *testing.T) {
+	state := engine.DefaultState()
+	lines, err := Simulate(&state, []string{"goblin", "5", "3"})
+	if err != nil {
+		t.Fatalf("Simulate: %v", err)
+	}
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "5 fights vs Goblin (seed 3)") {
+		t.Fatalf("unexpected report: %q", lines)
+	}
+	for _, args := range [][]string{nil, {"goblin", "many"}, {"goblin", "5", "x"}} {
+		if _, err := Simulate(&state, args); err == nil {
+			t.Errorf("expected %q to be rejected", args)
+		}
+	}
+}
+
+func TestTargeting_StoresModeInState(t *testing.T) {
+	state := engine.DefaultState()
+	if mode, err := Targeting(&state, []string{"on"}); err != nil || mode != "on" || !state.Meta.TargetedCombat {
+		t.Fatalf("targeting on: %q, %v, saved %v", mode, err, state.Meta.TargetedCombat)
+	}
+	if mode, err := Targeting(&state, nil); err != nil || mode != "on" {
+		t.Fatalf("targeting: %q, %v", mode, err)
+	}
+	if _, err := Targeting(&state, []string{"maybe"}); err == nil || !state.Meta.TargetedCombat {
+		t.Fatal("expected a bad mode rejected and the setting kept")
+	}
+}
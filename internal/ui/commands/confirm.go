@@ -0,0 +1,23 @@
+package commands
+
+import "strings"
+
+// Confirmer asks the player a yes/no question. Neither UI can block on the
+// reply (the TUI answers from its update loop), so the answer goes to then
+// once the player's next line arrives. An error from then is reported like
+// any failed command.
+type Confirmer interface {
+	Confirm(prompt string, then func(yes bool) error)
+}
+
+// ConfirmPrompt is prompt as shown to the player, with its default of no.
+func ConfirmPrompt(prompt string) string {
+	return prompt + " (y/N)"
+}
+
+// Confirmed reports whether reply accepts a confirmation: "y" or "yes", in
+// any case. Anything else, an empty reply included, declines.
+func Confirmed(reply string) bool {
+	r := strings.ToLower(strings.TrimSpace(reply))
+	return r == "y" || r == "yes"
+}
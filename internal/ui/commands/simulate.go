@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+// DefaultSimFights and DefaultSimSeed fill in what `simulate` leaves out.
+// A fixed seed keeps repeated runs comparable while tuning.
+const (
+	DefaultSimFights = 100
+	DefaultSimSeed   = 1
+)
+
+var errSimulateUsage = errors.New("usage: simulate <enemy_id> [fights] [seed]")
+
+// Simulate runs `simulate <enemy_id> [fights] [seed]` for either UI and
+// returns the report, title first. The game state is left untouched.
+func Simulate(state *engine.State, args []string) ([]string, error) {
+	if len(args) == 0 || len(args) > 3 {
+		return nil, errSimulateUsage
+	}
+	fights, seed := DefaultSimFights, int64(DefaultSimSeed)
+	if len(args) > 1 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil {
+			return nil, errSimulateUsage
+		}
+		fights = n
+	}
+	if len(args) > 2 {
+		s, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return nil, errSimulateUsage
+		}
+		seed = s
+	}
+
+	r, err := engine.Simulate(state, args[0], fights, rand.New(rand.NewSource(seed)))
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		fmt.Sprintf("%d fights vs %s (seed %d)", r.Fights, engine.Enemies[r.EnemyID].Name, seed),
+		fmt.Sprintf("  Win rate     %5.1f%%  (%d won, %d lost, %d drawn)", r.WinRate()*100, r.Wins, r.Losses, r.Stalemates),
+		fmt.Sprintf("  Damage taken %6.1f  per fight", r.AvgDamageTaken),
+		fmt.Sprintf("  Rewards      %6.1f XP, %.1f gold per fight", r.AvgXP, r.AvgGold),
+	}, nil
+}
@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+// Targeting applies `targeting [on|off]` to state and returns the mode now
+// in effect. The setting is kept in the save, so it survives a restart.
+func Targeting(state *engine.State, args []string) (string, error) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "on":
+			state.Meta.TargetedCombat = true
+		case "off":
+			state.Meta.TargetedCombat = false
+		default:
+			return "", errors.New("usage: targeting [on|off]")
+		}
+	}
+	if state.Meta.TargetedCombat {
+		return "on", nil
+	}
+	return "off", nil
+}
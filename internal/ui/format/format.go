@@ -0,0 +1,70 @@
+// Package format holds presentation helpers shared by both UIs.
+package format
+
+import (
+	"os"
+	"strconv"
+)
+
+// Separator groups thousands in Int. GRIMOIRE_THOUSANDS_SEP overrides the
+// default comma, e.g. "." or " "; set it empty to disable grouping.
+var Separator = separator()
+
+func separator() string {
+	if sep, ok := os.LookupEnv("GRIMOIRE_THOUSANDS_SEP"); ok {
+		return sep
+	}
+	return ","
+}
+
+// Int renders n with Separator between groups of three digits, so 1250
+// becomes "1,250". Numbers under 1000 are unchanged.
+func Int(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if Separator == "" || len(digits) <= 3 {
+		return sign + digits
+	}
+
+	head := len(digits) % 3
+	if head == 0 {
+		head = 3
+	}
+	out := sign + digits[:head]
+	for i := head; i < len(digits); i += 3 {
+		out += Separator + digits[i:i+3]
+	}
+	return out
+}
+
+// LevelUps summarizes several level-ups in one line, e.g. "Leveled up x3!
+// Now level 4, Max HP 130".
+func LevelUps(count, level, maxHP int) string {
+	return "Leveled up x" + strconv.Itoa(count) + "! Now level " + strconv.Itoa(level) + ", Max HP " + Int(maxHP)
+}
+
+// InventoryItem renders one inventory line for both UIs, e.g.
+// "Orcish Blade x1 (rare) [weapon]". slot is where the item is equipped,
+// if it is.
+func InventoryItem(name string, count int, rare bool, slot string) string {
+	out := name + " x" + strconv.Itoa(count)
+	if rare {
+		out += " (rare)"
+	}
+	if slot != "" {
+		out += " [" + slot + "]"
+	}
+	return out
+}
+
+// Affixed tags an item name with its affix, e.g. "Rusty Dagger (cursed)".
+// A plain item keeps its name.
+func Affixed(name, affix string) string {
+	if affix == "" {
+		return name
+	}
+	return name + " (" + affix + ")"
+}
@@ -0,0 +1,77 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+func TestInt_GroupsThousands(t *testing.T) {
+	tests := map[int]string{
+		0:        "0",
+		7:        "7",
+		999:      "999",
+		1250:     "1,250",
+		100000:   "100,000",
+		1234567:  "1,234,567",
+		-1250:    "-1,250",
+		-999:     "-999",
+		12345678: "12,345,678",
+	}
+	for n, want := range tests {
+		if got := Int(n); got != want {
+			t.Fatalf("Int(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestInt_UsesSeparator(t *testing.T) {
+	old := Separator
+	defer func() { Separator = old }()
+
+	Separator = "."
+	if got := Int(1250); got != "1.250" {
+		t.Fatalf("expected 1.250, got %q", got)
+	}
+	Separator = ""
+	if got := Int(1250); got != "1250" {
+		t.Fatalf("expected grouping disabled, got %q", got)
+	}
+}
+
+func TestAffixed(t *testing.T) {
+	if got := Affixed("Rusty Dagger", "cursed"); got != "Rusty Dagger (cursed)" {
+		t.Fatalf("Affixed = %q", got)
+	}
+	if got := Affixed("Torch", ""); got != "Torch" {
+		t.Fatalf("plain item renamed: %q", got)
+	}
+}
+
+func TestItemName_NormalizesLookup(t *testing.T) {
+	got := ItemName("Healing Potion")
+	if got != engine.Items["healing_potion"].Name {
+		t.Fatalf("unexpected display name %q", got)
+	}
+}
+
+func TestItemName_PrettifiesUnknown(t *testing.T) {
+	if got := ItemName("mystery_box"); got != "Mystery Box" {
+		t.Fatalf("unknown item rendered as %q", got)
+	}
+}
+
+func TestLootRow_SplitsItemAndSource(t *testing.T) {
+	item, source := LootRow(engine.LootRecord{ItemID: "rusty_dagger", Count: 2, Affix: "cursed", Command: 12, Source: "goblin"})
+	if item != "  #12    Rusty Dagger (cursed) x2" || source != "from "+engine.Enemies["goblin"].Name {
+		t.Fatalf("got %q / %q", item, source)
+	}
+}
+
+func TestUpgrade_NamesBothItems(t *testing.T) {
+	got := Upgrade(engine.UpgradeAvailable{ItemID: "healing_potion", Over: "mystery_box", Attack: 2})
+	want := "New! " + engine.Items["healing_potion"].Name + " (+2 attack over Mystery Box)"
+	if got != want {
+		t.Fatalf("Upgrade = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,132 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+// ================================
+// Names
+// ================================
+
+// ItemName returns the catalog display name for an item, or its ID
+// prettified when the catalog doesn't know it.
+func ItemName(itemID string) string {
+	if it, ok := engine.ItemByID(itemID); ok {
+		return it.Name
+	}
+	return PrettyID(itemID)
+}
+
+// PrettyID turns "orcish_blade" into "Orcish Blade".
+func PrettyID(id string) string {
+	parts := strings.Fields(strings.ReplaceAll(id, "_", " "))
+	for i, part := range parts {
+		runes := []rune(strings.ToLower(part))
+		if len(runes) == 0 {
+			continue
+		}
+		runes[0] = unicode.ToUpper(runes[0])
+		parts[i] = string(runes)
+	}
+	return strings.Join(parts, " ")
+}
+
+// PhaseName is "Day" or "Night".
+func PhaseName(phase string) string {
+	if phase == engine.TimeNight {
+		return "Night"
+	}
+	return "Day"
+}
+
+// ================================
+// Game Text
+// ================================
+
+// Run reads like "In Goblin Warren: fight 2 of 3 next, 5 XP and 4 gold
+// earned so far".
+func Run(run *engine.DungeonRun) string {
+	d := engine.Dungeons[run.ID]
+	msg := fmt.Sprintf("In %s: fight %d of %d next", d.Name, run.Stage+1, len(d.Enemies))
+	if run.Stage > 0 {
+		msg += fmt.Sprintf(", %s XP and %s gold earned so far", Int(run.XP), Int(run.Gold))
+	}
+	return msg
+}
+
+// Totals reads like "You dealt 42 damage and took 17 over 9 hits".
+func Totals(t engine.CombatTotals) string {
+	return fmt.Sprintf("You dealt %s damage and took %s over %d hits",
+		Int(t.Dealt), Int(t.Taken), t.Hits)
+}
+
+// Bank reads like "Bank: 120 gold, earning 2% every 25 commands".
+func Bank(p *engine.Player) string {
+	if p.BankedGold == 0 {
+		return "Bank: nothing deposited. `deposit <n>` banks gold from your purse"
+	}
+	return fmt.Sprintf("Bank: %s gold, earning %d%% every %d commands",
+		Int(p.BankedGold), engine.InterestPercent, engine.InterestInterval)
+}
+
+// Upgrade reads like "New! Orcish Blade (+2 attack over Rusty Dagger)".
+func Upgrade(ev engine.UpgradeAvailable) string {
+	var diffs []string
+	if ev.Attack != 0 {
+		diffs = append(diffs, fmt.Sprintf("%+d attack", ev.Attack))
+	}
+	if ev.Defense != 0 {
+		diffs = append(diffs, fmt.Sprintf("%+d defense", ev.Defense))
+	}
+	detail := strings.Join(diffs, ", ")
+	if ev.Over != "" {
+		detail += " over " + ItemName(ev.Over)
+	}
+	return fmt.Sprintf("New! %s (%s)", ItemName(ev.ItemID), detail)
+}
+
+// Merchant describes a caravan's deal and how to answer it.
+func Merchant(ev engine.MerchantOffered) string {
+	msg := fmt.Sprintf("A merchant offers %s for %s gold", ItemName(ev.Item), Int(ev.Price))
+	if ev.Wants != "" {
+		msg += fmt.Sprintf(" and would pay %s gold for your %s", Int(ev.Offer), ItemName(ev.Wants))
+	}
+	return msg + ". Trade " + strings.Join(ev.Options, " | ") + "?"
+}
+
+// ================================
+// Loot Log & Analytics
+// ================================
+
+// Titles and empty notes of `lootlog` and `analytics`.
+const (
+	LootLogTitle   = "Recent loot"
+	LootLogEmpty   = "Nothing looted yet."
+	AnalyticsTitle = "Events this save"
+	AnalyticsEmpty = "No events recorded yet."
+)
+
+// LootSource names where a loot record came from: an enemy's name, or
+// the source itself (explore, crafted, bought, ...).
+func LootSource(source string) string {
+	if e, ok := engine.EnemyByID(source); ok {
+		return e.Name
+	}
+	return source
+}
+
+// LootRow renders one `lootlog` row as the item part, e.g. "  #12    Orcish
+// Blade x1", and the source part, "from Orc", so a UI can dim the source.
+func LootRow(r engine.LootRecord) (item, source string) {
+	name := Affixed(ItemName(r.ItemID), r.Affix)
+	return fmt.Sprintf("  #%-5d %s x%d", r.Command, name, r.Count), "from " + LootSource(r.Source)
+}
+
+// AnalyticsRow renders one `analytics` row, e.g. "  damage_dealt   1,204".
+func AnalyticsRow(row engine.EventCount) string {
+	return fmt.Sprintf("  %-22s %s", row.Type, Int(row.Count))
+}
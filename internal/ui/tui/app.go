@@ -1,35 +1,61 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
-	"sort"
 	"strconv"
 	"strings"
-	"unicode"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
+	"github.com/divijg19/Grimoire/internal/buildinfo"
 	"github.com/divijg19/Grimoire/internal/engine"
 	"github.com/divijg19/Grimoire/internal/ports"
+	"github.com/divijg19/Grimoire/internal/ui/commands"
+	"github.com/divijg19/Grimoire/internal/ui/format"
 )
 
 type App struct {
 	state *engine.State
 	store ports.Store
 	rng   ports.RNG
+
+	autosave bool
+	setup    bool
 }
 
 func NewApp(state *engine.State, store ports.Store, rng ports.RNG) *App {
-	return &App{state: state, store: store, rng: rng}
+	return &App{state: state, store: store, rng: rng, autosave: true}
+}
+
+// SetAutosave turns the per-command save on or off.
+func (a *App) SetAutosave(on bool) {
+	a.autosave = on
+}
+
+// SetSetup opens the new-game wizard before play. Use it only when there
+// is no save to resume.
+func (a *App) SetSetup(on bool) {
+	a.setup = on
 }
 
 func (a *App) Run() error {
 	m := newModel(a.state, a.store, a.rng)
+	m.autosave = a.autosave
+	if a.setup {
+		m.setup = newSetupWizard(a.state.Player)
+	}
 	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
-	_, err := p.Run()
+	final, err := p.Run()
+	// The alt screen is gone by now, so the summary stays on the terminal.
+	if fm, ok := final.(model); ok && len(fm.farewell) > 0 {
+		fmt.Println(strings.Join(fm.farewell, "\n"))
+	}
 	return err
 }
 
@@ -47,7 +73,56 @@ type model struct {
 	width  int
 	height int
 
+	// logLimit caps how many log lines are kept; logOffset is how many of
+	// the newest lines sit below the rendered window while scrolled back.
+	logLimit  int
+	logOffset int
+
+	// lootSummary collapses per-item drop lines into one LootFound line.
+	lootSummary bool
+
+	// levelSummary collapses a run of level-ups into one line.
+	levelSummary bool
+
+	// verbosity picks every hit or a per-fight summary.
+	verbosity engine.CombatVerbosity
+
+	// alert flashes the event log border on high-priority events; flashing
+	// is set while the flash is showing.
+	alert    bool
+	flashing bool
+
+	// undoStack holds prior states, newest last, bounded by undoLimit.
+	undoStack []engine.State
+
+	// panel selects what the right column shows.
+	panel panelMode
+
+	// series holds recent HP and gold values for the stats panel.
+	series statSeries
+
+	// confirm is the pending Confirm callback, answered by the next line
+	// entered; confirmPrompt is shown in the input panel meanwhile.
+	confirm       func(yes bool) error
+	confirmPrompt string
+
+	// autosave saves after every command; with it off only `save` and
+	// `exit` persist, dirty records unsaved changes, and confirmQuit is
+	// set after a first Ctrl+C that would discard them.
+	autosave    bool
+	dirty       bool
+	confirmQuit bool
+
 	quitting bool
+
+	// setup is the new-game wizard; while set it owns Update and View.
+	setup *setupWizard
+
+	// start is the state at launch and started the launch time, for the
+	// session summary; farewell holds that summary once `exit` runs.
+	start    *engine.State
+	started  time.Time
+	farewell []string
 }
 
 func newModel(state *engine.State, store ports.Store, rng ports.RNG) model {
@@ -61,25 +136,40 @@ func newModel(state *engine.State, store ports.Store, rng ports.RNG) model {
 	vp.SetContent("")
 
 	m := model{
-		state:      state,
-		store:      store,
-		rng:        rng,
-		input:      input,
-		viewport:   vp,
-		historyPos: -1,
+		state:        state,
+		store:        store,
+		rng:          rng,
+		input:        input,
+		viewport:     vp,
+		historyPos:   -1,
+		logLimit:     defaultLogLimit,
+		lootSummary:  true,
+		levelSummary: true,
+		verbosity:    engine.CombatVerbose,
+		alert:        true,
+		autosave:     true,
+		start:        state.Clone(),
+		started:      time.Now(),
 	}
+	m.series.sample(state)
 	m.addLines(
 		welcomeLine,
 		introLine,
 	)
+	if run := state.Dungeon; run != nil {
+		m.addLines(warnStyle.Render(format.Run(run) + ". `dungeon next` continues, `dungeon leave` gives up."))
+	}
 	return m
 }
 
 func (m model) Init() tea.Cmd {
-	return nil
+	return tea.Tick(sizeTimeout, func(time.Time) tea.Msg { return sizeTimeoutMsg{} })
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.setup != nil {
+		return m.updateSetup(msg)
+	}
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -87,19 +177,48 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.layout()
 		return m, nil
 
+	case sizeTimeoutMsg:
+		// Some terminals never report a size; render at a fixed one.
+		if m.width == 0 || m.height == 0 {
+			m.width, m.height = fallbackWidth, fallbackHeight
+			m.layout()
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c":
-			_ = m.store.Save(m.state)
+			if !m.autosave && m.dirty {
+				if !m.confirmQuit {
+					m.confirmQuit = true
+					m.addLines(warnStyle.Render("Unsaved changes. Ctrl+C again quits without saving; `exit` saves and quits."))
+					return m, nil
+				}
+			} else {
+				_ = m.store.Save(m.state)
+			}
 			m.quitting = true
 			return m, tea.Quit
 
+		case "tab":
+			completed, options := completeInput(m.input.Value(), m.state.Player.Inventory)
+			if len(options) > 0 {
+				m.addLines(dimStyle.Render("Options: " + strings.Join(options, "  ")))
+			}
+			m.input.SetValue(completed)
+			m.input.CursorEnd()
+			return m, nil
+
 		case "pgup":
-			m.viewport.HalfPageUp()
+			if !m.scrollBack() {
+				m.viewport.HalfPageUp()
+			}
 			return m, nil
 
 		case "pgdown":
-			m.viewport.HalfPageDown()
+			if !m.scrollForward() {
+				m.viewport.HalfPageDown()
+			}
 			return m, nil
 
 		case "home":
@@ -107,15 +226,31 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case "end":
+			if m.logOffset > 0 {
+				m.logOffset = 0
+				m.refreshViewportContent()
+			}
 			m.viewport.GotoBottom()
 			return m, nil
 
+		case "ctrl+t":
+			if m.panel == panelStats {
+				m.panel = panelInventory
+			} else {
+				m.panel = panelStats
+			}
+			return m, nil
+
 		case "ctrl+k":
-			m.viewport.ScrollUp(1)
+			if !m.scrollBack() {
+				m.viewport.ScrollUp(1)
+			}
 			return m, nil
 
 		case "ctrl+j":
-			m.viewport.ScrollDown(1)
+			if !m.scrollForward() {
+				m.viewport.ScrollDown(1)
+			}
 			return m, nil
 
 		case "up":
@@ -147,12 +282,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "enter":
 			line := strings.TrimSpace(m.input.Value())
-			if line == "" {
+			if line == "" && m.confirm == nil {
 				return m, nil
 			}
 
 			m.history = append(m.history, line)
 			m.historyPos = -1
+			m.confirmQuit = false
 			m.addLines(promptStyle.Render("❯ ") + line)
 			m.input.SetValue("")
 
@@ -161,16 +297,27 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, tea.Quit
 			}
 			m.layout()
+			if m.flashing {
+				return m, tea.Tick(flashDuration, func(time.Time) tea.Msg { return flashEndMsg{} })
+			}
 			return m, nil
 		}
 
+	case flashEndMsg:
+		m.flashing = false
+		return m, nil
+
 	case tea.MouseMsg:
 		switch msg.Button {
 		case tea.MouseButtonWheelUp:
-			m.viewport.ScrollUp(wheelScrollLines)
+			if !m.scrollBack() {
+				m.viewport.ScrollUp(wheelScrollLines)
+			}
 			return m, nil
 		case tea.MouseButtonWheelDown:
-			m.viewport.ScrollDown(wheelScrollLines)
+			if !m.scrollForward() {
+				m.viewport.ScrollDown(wheelScrollLines)
+			}
 			return m, nil
 		}
 	}
@@ -181,7 +328,16 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *model) execute(line string) bool {
-	parts := strings.Fields(line)
+	line = engine.SanitizeInput(line)
+	if m.confirm != nil {
+		then := m.confirm
+		m.confirm, m.confirmPrompt = nil, ""
+		if err := then(commands.Confirmed(line)); err != nil {
+			m.addError(err.Error())
+		}
+		return false
+	}
+	parts := engine.SplitArgs(line)
 	if len(parts) == 0 {
 		return false
 	}
@@ -191,53 +347,232 @@ func (m *model) execute(line string) bool {
 
 	switch cmd {
 	case "help", "?":
-		m.addLines(helpLines()...)
+		m.addLines(helpLines(args)...)
 		return false
 
 	case "status":
 		m.addLines("Status refreshed.")
 		return false
 
-	case "explore":
-		events, err := engine.Explore(m.state, m.rng)
-		m.handle(events, err)
+	case "profile":
+		m.addLines(sheetLines(m.state)...)
+		return false
+
+	case "inventory", "inv":
+		m.addLines(inventoryLines(m.state, strings.Join(args, " "))...)
+		return false
+
+	case "examine":
+		if len(args) == 0 {
+			m.addError("usage: examine <item_id>")
+			return false
+		}
+		lines, err := engine.DescribeItem(args[0])
+		if err != nil {
+			m.addError(err.Error())
+			return false
+		}
+		m.addLines(titleStyle.Render(lines[0]))
+		for _, l := range lines[1:] {
+			m.addLines("  " + l)
+		}
+		return false
+
+	case "bank":
+		m.addLines(infoStyle.Render(format.Bank(&m.state.Player)))
+		return false
+
+	case "version":
+		m.addLines(infoStyle.Render(buildinfo.String()))
+		return false
+
+	case "lootlog":
+		n, err := commands.LootLogCount(args)
+		if err != nil {
+			m.addError(err.Error())
+			return false
+		}
+		m.addLines(lootLogLines(m.state, n)...)
+		return false
+
+	case "analytics":
+		m.addLines(analyticsLines(m.state)...)
+		return false
+
+	case "simulate", "sim":
+		lines, err := commands.Simulate(m.state, args)
+		if err != nil {
+			m.addError(err.Error())
+			return false
+		}
+		m.addLines(titleStyle.Render(lines[0]))
+		m.addLines(lines[1:]...)
+		return false
+
+	case "score":
+		m.addLines(infoStyle.Render(fmt.Sprintf("Score: %d (gold + level×100 + kills)", engine.ChallengeScore(m.state))))
+		return false
+
+	case "undo":
+		m.undo()
+		return false
+
+	case "export":
+		if len(args) < 2 || args[0] != "log" {
+			m.addError("usage: export log <file>")
+			return false
+		}
+		if err := m.exportLog(args[1]); err != nil {
+			m.addError(err.Error())
+			return false
+		}
+		m.addLines(successStyle.Render(fmt.Sprintf("Exported %d log lines to %s.", len(m.logs), args[1])))
+		return false
+
+	case "scrollback":
+		if len(args) == 0 {
+			m.addLines(infoStyle.Render(fmt.Sprintf("Keeping the last %d log lines.", m.logLimit)))
+			return false
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			m.addError("scrollback expects a positive line count")
+			return false
+		}
+		m.setLogLimit(n)
+		m.addLines(infoStyle.Render(fmt.Sprintf("Keeping the last %d log lines.", n)))
+		return false
+
+	case "recipes":
+		m.addLines(recipeLines()...)
+		return false
+
+	case "achievements":
+		m.addLines(achievementLines(m.state)...)
+		return false
+
+	case "dungeons":
+		m.addLines(dungeonLines(m.state)...)
+		return false
+
+	case "leaderboard", "top":
+		m.addLines(leaderboardLines(m.store)...)
+		return false
+
+	case "bestiary":
+		if m.panel == panelBestiary {
+			m.panel = panelInventory
+			m.addLines(infoStyle.Render("Showing inventory."))
+		} else {
+			m.panel = panelBestiary
+			m.addLines(infoStyle.Render("Showing bestiary."))
+		}
+		return false
+
+	case "bell":
+		if len(args) > 0 {
+			switch args[0] {
+			case "on":
+				m.alert = true
+			case "off":
+				m.alert = false
+			default:
+				m.addError("usage: bell [on|off]")
+				return false
+			}
+		}
+		mode := "off"
+		if m.alert {
+			mode = "on"
+		}
+		m.addLines(infoStyle.Render("Alert flash: " + mode + "."))
+		return false
+
+	case "targeting":
+		mode, err := commands.Targeting(m.state, args)
+		if err != nil {
+			m.addError(err.Error())
+			return false
+		}
+		if len(args) > 0 {
+			m.autoSave()
+		}
+		m.addLines(infoStyle.Render("Targeting: " + mode + "."))
 		return false
 
-	case "hunt":
-		extra := 0
+	case "loot":
 		if len(args) > 0 {
-			v, err := strconv.Atoi(args[0])
-			if err != nil {
-				m.addError("hunt expects an integer extra_sp")
+			switch args[0] {
+			case "summary":
+				m.lootSummary = true
+			case "items":
+				m.lootSummary = false
+			default:
+				m.addError("usage: loot [summary|items]")
 				return false
 			}
-			extra = v
 		}
-		events, err := engine.Hunt(m.state, extra, m.rng)
-		m.handle(events, err)
+		mode := "items"
+		if m.lootSummary {
+			mode = "summary"
+		}
+		m.addLines(infoStyle.Render("Loot display: " + mode + "."))
 		return false
 
-	case "rest":
-		sp := 1
+	case "levelups":
 		if len(args) > 0 {
-			v, err := strconv.Atoi(args[0])
-			if err != nil {
-				m.addError("rest expects an integer sp amount")
+			switch args[0] {
+			case "summary":
+				m.levelSummary = true
+			case "each":
+				m.levelSummary = false
+			default:
+				m.addError("usage: levelups [summary|each]")
 				return false
 			}
-			sp = v
 		}
-		events, err := engine.Rest(m.state, sp)
-		m.handle(events, err)
+		mode := "each"
+		if m.levelSummary {
+			mode = "summary"
+		}
+		m.addLines(infoStyle.Render("Level-up display: " + mode + "."))
 		return false
 
-	case "use":
+	case "verbosity":
+		if len(args) > 0 {
+			switch v := engine.CombatVerbosity(args[0]); v {
+			case engine.CombatVerbose, engine.CombatSummary:
+				m.verbosity = v
+			default:
+				m.addError("usage: verbosity [verbose|summary]")
+				return false
+			}
+		}
+		m.addLines(infoStyle.Render("Combat display: " + string(m.verbosity) + "."))
+		return false
+
+	case "theme":
 		if len(args) == 0 {
-			m.addError("usage: use <item_id>")
+			m.addLines(infoStyle.Render("Theme: " + activeTheme.Name + " (available: " + strings.Join(themeNames(), ", ") + ")"))
 			return false
 		}
-		events, err := engine.UseItem(m.state, args[0], m.rng)
-		m.handle(events, err)
+		if err := SetTheme(args[0]); err != nil {
+			m.addError(err.Error())
+			return false
+		}
+		m.addLines(infoStyle.Render("Theme set to " + activeTheme.Name + "."))
+		return false
+
+	case "sell":
+		if len(args) > 0 && args[0] == "all" {
+			m.Confirm("Sell every item you carry?", m.answerSellAll)
+			return false
+		}
+		m.apply(line)
+		return false
+
+	case "new":
+		m.Confirm("Start a new game? The current save will be archived.", m.answerNew)
 		return false
 
 	case "save":
@@ -245,10 +580,36 @@ func (m *model) execute(line string) bool {
 			m.addError("save failed: " + err.Error())
 			return false
 		}
+		m.dirty = false
 		m.addLines(successStyle.Render("Game saved."))
 		return false
 
+	case "autosave":
+		if len(args) > 0 {
+			switch args[0] {
+			case "on":
+				m.autosave = true
+				if m.dirty {
+					m.autoSave()
+					m.dirty = false
+				}
+			case "off":
+				m.autosave = false
+			default:
+				m.addError("usage: autosave [on|off]")
+				return false
+			}
+		}
+		mode := "off"
+		if m.autosave {
+			mode = "on"
+		}
+		m.addLines(infoStyle.Render("Autosave: " + mode + "."))
+		return false
+
 	case "exit", "quit":
+		m.farewell = summaryLines(engine.SessionSummary(m.start, m.state, m.started))
+		m.addLines(m.farewell...)
 		if err := m.store.Save(m.state); err != nil {
 			m.addError("save failed on exit: " + err.Error())
 		} else {
@@ -257,14 +618,102 @@ func (m *model) execute(line string) bool {
 		return true
 
 	default:
-		m.addError("unknown command. Type 'help'.")
+		m.apply(line)
 		return false
 	}
 }
 
+// apply runs a gameplay command through the engine, remembering the prior
+// state so it can be undone.
+func (m *model) apply(line string) {
+	next, events, err := engine.ApplyCommand(*m.state, line, m.rng)
+	if errors.Is(err, engine.ErrUnknownCommand) {
+		m.addError("unknown command. Type 'help'.")
+		return
+	}
+	if err == nil {
+		m.pushUndo(*m.state)
+		*m.state = next
+		m.series.sample(m.state)
+	}
+	m.handle(events, err)
+}
+
+// Confirm logs prompt, shows it in the input panel and hands the next line
+// entered to then; see commands.Confirmer.
+func (m *model) Confirm(prompt string, then func(yes bool) error) {
+	m.confirm, m.confirmPrompt = then, commands.ConfirmPrompt(prompt)
+	m.addLines(warnStyle.Render(m.confirmPrompt))
+}
+
+// answerSellAll handles the reply to the `sell all` confirmation prompt.
+func (m *model) answerSellAll(yes bool) error {
+	if !yes {
+		m.addLines(dimStyle.Render("Nothing sold."))
+		return nil
+	}
+	m.apply("sell all")
+	return nil
+}
+
+// answerNew handles the reply to the `new` confirmation prompt. Starting over
+// clears the log, history and undo stack along with the state.
+func (m *model) answerNew(yes bool) error {
+	if !yes {
+		m.addLines(dimStyle.Render("New game cancelled."))
+		return nil
+	}
+
+	archived, err := m.store.Archive()
+	if err != nil {
+		return errors.New("could not archive save: " + err.Error())
+	}
+	*m.state = engine.DefaultState()
+	m.start, m.started = m.state.Clone(), time.Now()
+	m.undoStack = nil
+	m.history = nil
+	m.historyPos = -1
+	m.logs = nil
+	m.series = statSeries{}
+	m.series.sample(m.state)
+	if err := m.store.Save(m.state); err != nil {
+		m.addError("save failed: " + err.Error())
+	}
+
+	m.addLines(welcomeLine, successStyle.Render("A new adventure begins."))
+	if archived != "" {
+		m.addLines(dimStyle.Render("Previous save archived to " + archived + "."))
+	}
+	return nil
+}
+
+func (m *model) pushUndo(prev engine.State) {
+	m.undoStack = append(m.undoStack, prev)
+	if len(m.undoStack) > undoLimit {
+		m.undoStack = m.undoStack[len(m.undoStack)-undoLimit:]
+	}
+}
+
+func (m *model) undo() {
+	if len(m.undoStack) == 0 {
+		m.addError("nothing to undo")
+		return
+	}
+	last := len(m.undoStack) - 1
+	*m.state = m.undoStack[last]
+	m.undoStack = m.undoStack[:last]
+	m.series.sample(m.state)
+
+	m.addLines(infoStyle.Render("Undid last command."))
+	m.autoSave()
+}
+
 func (m *model) handle(events engine.Events, err error) {
 	if err != nil {
 		m.addError(err.Error())
+		if hint := commands.Hint(err); hint != "" {
+			m.addLines(dimStyle.Render(hint))
+		}
 		return
 	}
 
@@ -272,12 +721,39 @@ func (m *model) handle(events engine.Events, err error) {
 		m.addLines(dimStyle.Render("No events."))
 	}
 
+	if m.lootSummary {
+		events = engine.CollapseLoot(events)
+	}
+	if m.levelSummary {
+		events = engine.CollapseLevelUps(events)
+	}
+	if m.verbosity == engine.CombatSummary {
+		events = engine.SummarizeCombat(events)
+	}
+	shown := make(engine.Events, 0, len(events))
 	for _, ev := range events {
-		m.addLines(formatEvent(ev))
+		if _, ok := ev.(engine.LootFound); ok && !m.lootSummary {
+			continue
+		}
+		shown = append(shown, ev)
+	}
+	m.addLines(formatEvents(shown)...)
+	if m.alert && engine.HighestPriority(events) == engine.PriorityHigh {
+		m.flashing = true
 	}
 
-	if saveErr := m.store.Save(m.state); saveErr != nil {
-		m.addError("auto-save failed: " + saveErr.Error())
+	m.autoSave()
+}
+
+// autoSave saves after a change, or marks the state dirty while autosave
+// is off.
+func (m *model) autoSave() {
+	if !m.autosave {
+		m.dirty = true
+		return
+	}
+	if err := m.store.Save(m.state); err != nil {
+		m.addError("auto-save failed: " + err.Error())
 	}
 }
 
@@ -287,19 +763,21 @@ func (m *model) addError(message string) {
 
 func (m *model) addLines(lines ...string) {
 	m.logs = append(m.logs, lines...)
-	if len(m.logs) > 300 {
-		m.logs = m.logs[len(m.logs)-300:]
+	if len(m.logs) > m.logLimit {
+		m.logs = m.logs[len(m.logs)-m.logLimit:]
 	}
+	m.logOffset = 0
 	m.refreshViewportContent()
 	m.viewport.GotoBottom()
 }
 
 func (m *model) refreshViewportContent() {
+	lines := m.windowLines()
 	if m.viewport.Width <= 0 {
-		m.viewport.SetContent(strings.Join(m.logs, "\n"))
+		m.viewport.SetContent(strings.Join(lines, "\n"))
 		return
 	}
-	m.viewport.SetContent(wrapLogLines(m.logs, m.viewport.Width))
+	m.viewport.SetContent(wrapLogLines(lines, m.viewport.Width))
 }
 
 func (m *model) layout() {
@@ -346,6 +824,9 @@ func (m model) View() string {
 	if m.quitting {
 		return ""
 	}
+	if m.setup != nil {
+		return m.setupView()
+	}
 
 	if m.width == 0 || m.height == 0 {
 		return "Loading..."
@@ -379,17 +860,30 @@ func (m model) View() string {
 
 	logTitle := titleStyle.Render(eventLogTitle)
 	logPaneContentWidth := max(1, leftOuter-logPanelStyle.GetHorizontalFrameSize())
-	logPane := logPanelStyle.Width(logPaneContentWidth).Render(logTitle + "\n" + m.viewport.View())
+	logStyle := logPanelStyle
+	if m.flashing {
+		logStyle = logStyle.BorderForeground(activeTheme.Warn)
+	}
+	logPane := logStyle.Width(logPaneContentWidth).Render(logTitle + "\n" + m.viewport.View())
 
-	footer := renderFooter(availWidth)
-	input := renderInputPanel(leftOuter, m.input.Value())
+	footer := m.footer(availWidth)
+	hint := completionHint(m.input.Value(), m.state.Player.Inventory)
+	input := renderInputPanelWithHint(leftOuter, m.input.Value(), hint)
+	if m.confirm != nil {
+		input = renderPromptPanel(leftOuter, m.input.Value(), "", m.confirmPrompt)
+	}
 
 	leftColumn := lipgloss.JoinVertical(lipgloss.Left, hud, logPane, input)
-	rightPanel := renderInventoryPanel(
-		m.state,
-		rightOuter,
-		max(1, lipgloss.Height(leftColumn)-inventoryPanelStyle.GetVerticalFrameSize()),
-	)
+	rightHeight := max(1, lipgloss.Height(leftColumn)-inventoryPanelStyle.GetVerticalFrameSize())
+	var rightPanel string
+	switch m.panel {
+	case panelStats:
+		rightPanel = renderStatsPanel(m.state, m.series, rightOuter, rightHeight)
+	case panelBestiary:
+		rightPanel = renderBestiaryPanel(m.state, rightOuter, rightHeight)
+	default:
+		rightPanel = renderInventoryPanel(m.state, rightOuter, rightHeight)
+	}
 	mainRow := lipgloss.JoinHorizontal(lipgloss.Top, leftColumn, strings.Repeat(" ", columnGapCols), rightPanel)
 	body := lipgloss.JoinVertical(lipgloss.Left, mainRow, footer)
 	return outerFrameStyle.Render(body)
@@ -469,18 +963,25 @@ func renderArea(termWidth, termHeight int) (int, int) {
 func renderHUDPanel(state *engine.State, outerWidth int) string {
 	p := state.Player
 	need := engine.XPToNext(p.Level)
+	location := state.Meta.Location
+	if phase := state.Meta.TimeOfDay; phase != "" {
+		location += " · " + format.PhaseName(phase)
+	}
 
 	lines := []string{
 		titleStyle.Render(fmt.Sprintf("%s (%s)", p.Name, p.Class)),
-		dimStyle.Render(state.Meta.Location),
+		dimStyle.Render(location),
 		"",
 		fmt.Sprintf("Level %d", p.Level),
 		fmt.Sprintf("HP %d/%d %s", p.HP, p.MaxHP, ratioBar(p.HP, p.MaxHP, 18)),
-		fmt.Sprintf("SP %d %s", p.SP, simpleBar(min(p.SP, 12), 12, 12)),
-		fmt.Sprintf("XP %d/%d %s", p.XP, need, ratioBar(p.XP, need, 18)),
-		fmt.Sprintf("Gold %d", p.Gold),
+		fmt.Sprintf("SP %d/%d %s", p.SP, p.MaxSP, simpleBar(p.SP, p.MaxSP, 12)),
+		fmt.Sprintf("XP %s/%s %s", format.Int(p.XP), format.Int(need), ratioBar(p.XP, need, 18)),
+		"Gold " + format.Int(p.Gold),
 		fmt.Sprintf("Commands %d", state.Meta.CommandCount),
 	}
+	if p.MaxCarryWeight > 0 {
+		lines = append(lines, fmt.Sprintf("Weight %d/%d", engine.CarryWeight(&p), p.MaxCarryWeight))
+	}
 	contentWidth := max(1, outerWidth-sidePanelStyle.GetHorizontalFrameSize())
 	return sidePanelStyle.Width(contentWidth).Render(strings.Join(lines, "\n"))
 }
@@ -488,26 +989,83 @@ func renderHUDPanel(state *engine.State, outerWidth int) string {
 func renderInventoryPanel(state *engine.State, outerWidth, contentHeight int) string {
 	lines := []string{titleStyle.Render("Inventory"), ""}
 	contentWidth := max(1, outerWidth-inventoryPanelStyle.GetHorizontalFrameSize())
-	if len(state.Player.Inventory) == 0 {
+	entries := engine.InventoryList(&state.Player, "")
+	if len(entries) == 0 {
 		lines = append(lines, dimStyle.Render("(empty)"))
 		return inventoryPanelStyle.Width(contentWidth).Height(max(1, contentHeight)).Render(strings.Join(lines, "\n"))
 	}
 
-	keys := make([]string, 0, len(state.Player.Inventory))
-	for k := range state.Player.Inventory {
-		keys = append(keys, k)
+	cooling := engine.Cooldowns(state)
+	for _, e := range entries {
+		line := "• " + format.InventoryItem(e.Name, e.Count, e.Rare, e.Slot)
+		// Items still cooling down are dimmed with their commands left.
+		if n := cooling[e.ID]; n > 0 && e.Slot == "" {
+			line = dimStyle.Render(fmt.Sprintf("%s (%d)", line, n))
+		}
+		lines = append(lines, line)
 	}
-	sort.Strings(keys)
-	for _, k := range keys {
-		count := state.Player.Inventory[k]
-		itemName := itemDisplayName(k)
-		lines = append(lines, fmt.Sprintf("• %s x%d", itemName, count))
+
+	return inventoryPanelStyle.Width(contentWidth).Height(max(1, contentHeight)).Render(strings.Join(lines, "\n"))
+}
+
+// inventoryLines lists the inventory in the log, one item per line like the
+// panel, keeping only items matching filter when it is set.
+func inventoryLines(state *engine.State, filter string) []string {
+	lines := []string{titleStyle.Render("Inventory")}
+	entries := engine.InventoryList(&state.Player, filter)
+	if len(entries) == 0 {
+		if filter != "" {
+			return append(lines, dimStyle.Render(fmt.Sprintf("  Nothing matches %q.", filter)))
+		}
+		return append(lines, dimStyle.Render("  (empty)"))
 	}
+	cooling := engine.Cooldowns(state)
+	for _, e := range entries {
+		line := "  • " + format.InventoryItem(e.Name, e.Count, e.Rare, e.Slot)
+		if n := cooling[e.ID]; n > 0 && e.Slot == "" {
+			line = dimStyle.Render(fmt.Sprintf("%s (%d)", line, n))
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
 
+// renderBestiaryPanel lists every enemy in the right column, hiding ones the
+// player has not met yet. Lines are truncated and capped so the panel never
+// grows past the left column.
+func renderBestiaryPanel(state *engine.State, outerWidth, contentHeight int) string {
+	contentWidth := max(1, outerWidth-inventoryPanelStyle.GetHorizontalFrameSize())
+	lines := []string{titleStyle.Render("Bestiary"), ""}
+	for _, e := range engine.AllEnemies() {
+		entry, ok := state.Bestiary[e.ID]
+		if !ok || entry.Seen == 0 {
+			lines = append(lines, dimStyle.Render("???"))
+			continue
+		}
+		lines = append(lines,
+			truncateText(fmt.Sprintf("• %s ✕%d", e.Name, entry.Killed), contentWidth),
+			dimStyle.Render(truncateText(fmt.Sprintf("  HP %d ATK %d-%d", e.HP, e.AttackMin, e.AttackMax), contentWidth)),
+		)
+	}
+	if limit := max(1, contentHeight); len(lines) > limit {
+		lines = lines[:limit]
+	}
 	return inventoryPanelStyle.Width(contentWidth).Height(max(1, contentHeight)).Render(strings.Join(lines, "\n"))
 }
 
 func renderInputPanel(outerWidth int, inputValue string) string {
+	return renderInputPanelWithHint(outerWidth, inputValue, "")
+}
+
+// renderInputPanelWithHint renders the prompt with ghost completion text
+// dimmed after the typed value.
+func renderInputPanelWithHint(outerWidth int, inputValue, hint string) string {
+	return renderPromptPanel(outerWidth, inputValue, hint, promptPlaceholder)
+}
+
+// renderPromptPanel renders the input panel, showing placeholder while
+// nothing is typed.
+func renderPromptPanel(outerWidth int, inputValue, hint, placeholder string) string {
 	contentWidth := max(1, outerWidth-inputPanelStyle.GetHorizontalFrameSize())
 	lineWidth := max(1, contentWidth-2)
 	promptPrefix := "❯ "
@@ -516,8 +1074,11 @@ func renderInputPanel(outerWidth int, inputValue string) string {
 	inputTextWidth := max(1, lineWidth-prefixWidth)
 	inputText := truncateText(cleanValue, inputTextWidth)
 	inputLine := promptStyle.Render(promptPrefix) + inputText
+	if ghostWidth := inputTextWidth - lipgloss.Width(inputText); hint != "" && ghostWidth > 0 {
+		inputLine += dimStyle.Render(truncateText(hint, ghostWidth))
+	}
 	if strings.TrimSpace(cleanValue) == "" {
-		inputLine = promptStyle.Render(promptPrefix) + dimStyle.Render(truncateText(promptPlaceholder, inputTextWidth))
+		inputLine = promptStyle.Render(promptPrefix) + dimStyle.Render(truncateText(placeholder, inputTextWidth))
 	}
 	hint1 := dimStyle.Render(truncateText(promptExampleLine1, lineWidth))
 	hint2 := dimStyle.Render(truncateText(promptExampleLine2, lineWidth))
@@ -532,27 +1093,26 @@ func renderFooter(width int) string {
 	return footerStyle.Width(width).Render(truncateText(footerHint, width))
 }
 
-func truncateText(s string, maxWidth int) string {
-	if maxWidth <= 0 {
-		return ""
-	}
-	if lipgloss.Width(s) <= maxWidth {
-		return s
+// footer is renderFooter, led by the autosave state while it's off.
+func (m model) footer(width int) string {
+	if m.autosave || width <= 0 {
+		return renderFooter(width)
 	}
-	if maxWidth <= 1 {
-		return "…"
+	state := "Autosave OFF"
+	if m.dirty {
+		state += " (unsaved)"
 	}
+	return footerStyle.Width(width).Render(truncateText(state+"  •  "+footerHint, width))
+}
 
-	limit := maxWidth - lipgloss.Width("…")
-	prefix := ""
-	for _, r := range s {
-		next := prefix + string(r)
-		if lipgloss.Width(next) > limit {
-			break
-		}
-		prefix = next
+// truncateText cuts s to at most maxWidth terminal cells, ending in "…".
+// Widths are measured per grapheme, so wide CJK/emoji runes and embedded
+// ANSI styling never push the result past maxWidth.
+func truncateText(s string, maxWidth int) string {
+	if maxWidth <= 0 {
+		return ""
 	}
-	return prefix + "…"
+	return ansi.Truncate(s, maxWidth, "…")
 }
 
 func wrapLogLines(lines []string, width int) string {
@@ -570,18 +1130,162 @@ func wrapLogLines(lines []string, width int) string {
 	return strings.Join(out, "\n")
 }
 
-func helpLines() []string {
+// helpLines lists every command, or explains the one named in args.
+func helpLines(args []string) []string {
+	if len(args) > 0 {
+		lines, err := commands.Help(args[0], true)
+		if err != nil {
+			return []string{errorStyle.Render("Error: " + err.Error())}
+		}
+		out := []string{titleStyle.Render(lines[0])}
+		for _, l := range lines[1:] {
+			out = append(out, "  "+l)
+		}
+		return out
+	}
+
+	lines := []string{titleStyle.Render(commandsTitle)}
+	for _, cmd := range commands.For(true) {
+		lines = append(lines, fmt.Sprintf("  %-20s %s", cmd.Usage, cmd.Summary))
+	}
+	return append(lines, dimStyle.Render("  Type 'help <command>' for details."))
+}
+
+func recipeLines() []string {
+	lines := []string{titleStyle.Render("Recipes")}
+	for _, id := range engine.RecipeIDs() {
+		r := engine.Recipes[id]
+		line := fmt.Sprintf("  %s: %s → %s", id, engine.FormatIngredients(r.Inputs), format.ItemName(r.Output))
+		if r.GoldCost > 0 {
+			line += fmt.Sprintf(" (+%d gold)", r.GoldCost)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func leaderboardLines(store ports.Store) []string {
+	slots, ok := store.(ports.SlotStore)
+	if !ok {
+		return []string{warnStyle.Render("This store has no save slots.")}
+	}
+	entries, warnings, err := engine.Leaderboard(slots)
+	if err != nil {
+		return []string{errorStyle.Render("Error: " + err.Error())}
+	}
+
+	lines := []string{titleStyle.Render("Leaderboard")}
+	for _, w := range warnings {
+		lines = append(lines, warnStyle.Render("  "+w))
+	}
+	if len(entries) == 0 {
+		return append(lines, dimStyle.Render("  No saves found."))
+	}
+	for i, e := range entries {
+		lines = append(lines, fmt.Sprintf("  %d. %s (%s) Lv %d, %d gold, %d cmds", i+1, e.Slot, e.Name, e.Level, e.Gold, e.Commands))
+	}
+	return lines
+}
+
+func dungeonLines(state *engine.State) []string {
+	lines := []string{titleStyle.Render("Dungeons")}
+	for _, id := range engine.DungeonIDs() {
+		d := engine.Dungeons[id]
+		lines = append(lines, fmt.Sprintf("  %s: %s, %d fights → %d gold, %d XP, %s", id, d.Name, len(d.Enemies), d.Gold, d.XP, format.ItemName(d.Item)))
+	}
+	if run := state.Dungeon; run != nil {
+		lines = append(lines, warnStyle.Render("  "+format.Run(run)))
+	}
+	return lines
+}
+
+func dungeonName(id string) string {
+	if d, ok := engine.Dungeons[id]; ok {
+		return d.Name
+	}
+	return id
+}
+
+func timeChangedText(phase string) string {
+	if phase == engine.TimeNight {
+		return "Night falls. The dead stir."
+	}
+	return "Day breaks. Beasts roam the wilds."
+}
+
+// summaryLines renders what the session achieved.
+func summaryLines(s engine.Summary) []string {
+	gold := format.Int(s.Gold)
+	if s.Gold >= 0 {
+		gold = "+" + gold
+	}
 	return []string{
-		titleStyle.Render(commandsTitle),
-		"  help                Show this help",
-		"  status              Show current HUD",
-		"  explore             Explore once",
-		"  hunt [extra_sp]     Hunt with optional SP stake",
-		"  rest [sp]           Convert SP to HP (default 1)",
-		"  use <item_id>       Use item, e.g. healing_potion",
-		"  save                Save game",
-		"  exit | quit         Save and exit",
+		titleStyle.Render("Session summary"),
+		infoStyle.Render(fmt.Sprintf("  Commands %s · Gold %s · XP +%s", format.Int(s.Commands), gold, format.Int(s.XP))),
+		infoStyle.Render(fmt.Sprintf("  Enemies defeated %d · Items found %d · Time %s", s.Kills, s.Items, s.Duration.Round(time.Second))),
+	}
+}
+
+func sheetLines(state *engine.State) []string {
+	s := engine.CharacterSheet(state)
+	p := state.Player
+	lines := []string{
+		titleStyle.Render(fmt.Sprintf("%s (%s), level %d", p.Name, p.Class, s.Level)),
+		fmt.Sprintf("  XP %s/%s (%s to next, %d%% gain)", format.Int(s.XP), format.Int(s.XPToNext), format.Int(s.XPToNext-s.XP), s.XPPercent),
+		fmt.Sprintf("  Attack %d-%d  Defense %d  Luck %d", s.AttackMin, s.AttackMax, s.Defense, s.Luck),
+		dimStyle.Render(fmt.Sprintf("  Fights %d  Wins %d  Win rate %.0f%%  Biggest overkill %d", s.Fights, s.Wins, s.WinRate*100, s.MaxOverkill)),
+	}
+	for _, slot := range []string{engine.SlotWeapon, engine.SlotArmor, engine.SlotTrinket} {
+		if id, ok := p.Equipment[slot]; ok {
+			note := ""
+			if it, _ := engine.ItemByID(id); it.MaxDurability > 0 {
+				note = fmt.Sprintf(" (%d/%d)", engine.Durability(&p, id), it.MaxDurability)
+			}
+			name := format.Affixed(format.ItemName(id), p.EquippedAffixes[slot])
+			lines = append(lines, infoStyle.Render(fmt.Sprintf("  %s: %s%s", slot, name, note)))
+		}
+	}
+	for _, id := range s.Sets {
+		lines = append(lines, successStyle.Render("  Set bonus: "+engine.ItemSets[id].Name))
+	}
+	return lines
+}
+
+func lootLogLines(state *engine.State, n int) []string {
+	records := engine.RecentLoot(state, n)
+	if len(records) == 0 {
+		return []string{dimStyle.Render(format.LootLogEmpty)}
 	}
+	lines := []string{titleStyle.Render(format.LootLogTitle)}
+	for _, r := range records {
+		item, source := format.LootRow(r)
+		lines = append(lines, item+" "+dimStyle.Render(source))
+	}
+	return lines
+}
+
+func analyticsLines(state *engine.State) []string {
+	tally := engine.EventTally(state)
+	if len(tally) == 0 {
+		return []string{dimStyle.Render(format.AnalyticsEmpty)}
+	}
+	lines := []string{titleStyle.Render(format.AnalyticsTitle)}
+	for _, row := range tally {
+		lines = append(lines, format.AnalyticsRow(row))
+	}
+	return lines
+}
+
+func achievementLines(state *engine.State) []string {
+	lines := []string{titleStyle.Render("Achievements")}
+	for _, ach := range engine.Achievements {
+		if state.Meta.Achievements[ach.ID] {
+			lines = append(lines, successStyle.Render("  ✓ "+ach.Name)+" "+dimStyle.Render(ach.Description))
+		} else {
+			lines = append(lines, dimStyle.Render("  · "+ach.Name+" "+ach.Description))
+		}
+	}
+	return lines
 }
 
 func formatEvent(e engine.Event) string {
@@ -594,32 +1298,151 @@ func formatEvent(e engine.Event) string {
 			return infoStyle.Render("You find a useful item.")
 		case "gold":
 			return infoStyle.Render("You discover scattered gold.")
+		case "merchant":
+			return infoStyle.Render("A merchant caravan creaks up the road.")
 		default:
 			return dimStyle.Render("The path yields nothing this time.")
 		}
 	case engine.EncounterStarted:
-		return warnStyle.Render("Encounter: " + prettyID(ev.EnemyID))
+		name := format.PrettyID(ev.EnemyID)
+		if ev.Name != "" {
+			name = ev.Name
+		}
+		return warnStyle.Render("Encounter: " + name)
 	case engine.DamageDealt:
 		if ev.Target == "player" {
 			return errorStyle.Render(fmt.Sprintf("You take %d damage (%d HP left)", ev.Amount, ev.HPLeft))
 		}
+		if ev.Overkill > 0 {
+			return successStyle.Render(fmt.Sprintf("You deal %d damage • %d overkill!", ev.Amount, ev.Overkill))
+		}
 		return successStyle.Render(fmt.Sprintf("You deal %d damage (%d enemy HP left)", ev.Amount, ev.HPLeft))
 	case engine.EnemyDefeated:
-		return successStyle.Render(fmt.Sprintf("Defeated %s • +%d XP • +%d gold", prettyID(ev.EnemyID), ev.XP, ev.Gold))
+		return successStyle.Render(fmt.Sprintf("Defeated %s • +%s XP • +%s gold", format.PrettyID(ev.EnemyID), format.Int(ev.XP), format.Int(ev.Gold)))
+	case engine.CombatTotals:
+		return dimStyle.Render(format.Totals(ev))
+	case engine.RepeatStopped:
+		return warnStyle.Render(fmt.Sprintf("Stopped after %d of %d %s: %s", ev.Done, ev.Times, ev.Command, ev.Reason))
+	case engine.Hint:
+		return dimStyle.Render("Hint: " + ev.Text)
+	case engine.Stolen:
+		if ev.ItemID != "" {
+			return errorStyle.Render(fmt.Sprintf("%s steals your %s!", format.PrettyID(ev.EnemyID), format.ItemName(ev.ItemID)))
+		}
+		return errorStyle.Render(fmt.Sprintf("%s steals %s gold!", format.PrettyID(ev.EnemyID), format.Int(ev.Gold)))
+	case engine.StolenRecovered:
+		parts := make([]string, 0, len(ev.Items)+1)
+		if ev.Gold > 0 {
+			parts = append(parts, format.Int(ev.Gold)+" gold")
+		}
+		for _, it := range ev.Items {
+			parts = append(parts, format.ItemName(it))
+		}
+		return successStyle.Render("Recovered: " + strings.Join(parts, ", "))
+	case engine.CombatStalemate:
+		return warnStyle.Render(fmt.Sprintf("Stalemate with the %s after %d turns. You disengage.", ev.EnemyID, ev.Turns))
 	case engine.PlayerDefeated:
 		return errorStyle.Render("You were defeated.")
 	case engine.XPGained:
-		return infoStyle.Render(fmt.Sprintf("+%d XP", ev.Amount))
+		return infoStyle.Render("+" + format.Int(ev.Amount) + " XP")
 	case engine.LevelUp:
 		return successStyle.Bold(true).Render(fmt.Sprintf("Level up! Now level %d (Max HP %d)", ev.NewLevel, ev.NewMaxHP))
+	case engine.LevelsGained:
+		return successStyle.Bold(true).Render(format.LevelUps(ev.Count, ev.NewLevel, ev.NewMaxHP))
+	case engine.AchievementUnlocked:
+		return successStyle.Bold(true).Render("Achievement unlocked: " + ev.Name)
 	case engine.ItemAdded:
-		return infoStyle.Render(fmt.Sprintf("Obtained %s x%d", itemDisplayName(ev.ItemID), ev.Count))
+		return infoStyle.Render(fmt.Sprintf("Obtained %s x%d", format.Affixed(format.ItemName(ev.ItemID), ev.Affix), ev.Count))
+	case engine.UpgradeAvailable:
+		return successStyle.Render(format.Upgrade(ev))
+	case engine.LootFound:
+		names := make([]string, 0, len(ev.Items))
+		for _, it := range ev.Items {
+			names = append(names, format.ItemName(it))
+		}
+		return infoStyle.Render("Loot: " + strings.Join(names, ", "))
+	case engine.ItemEquipped:
+		return infoStyle.Render(fmt.Sprintf("Equipped %s (%s)", format.ItemName(ev.ItemID), ev.Slot))
+	case engine.ItemUnequipped:
+		return dimStyle.Render(fmt.Sprintf("Took off %s", format.ItemName(ev.ItemID)))
+	case engine.CurseLifted:
+		return successStyle.Render(fmt.Sprintf("The curse on your %s lifts", format.ItemName(ev.ItemID)))
+	case engine.SetBonusActive:
+		return successStyle.Bold(true).Render("Set complete: " + engine.ItemSets[ev.SetID].Name + "!")
+	case engine.SetBonusEnded:
+		return dimStyle.Render("Set broken: " + engine.ItemSets[ev.SetID].Name)
+	case engine.InventoryFull:
+		return warnStyle.Render(fmt.Sprintf("Too heavy: left %s x%d behind", format.ItemName(ev.ItemID), ev.Dropped))
 	case engine.ItemRemoved:
-		return dimStyle.Render(fmt.Sprintf("Used %s x%d", itemDisplayName(ev.ItemID), ev.Count))
+		return dimStyle.Render(fmt.Sprintf("Used %s x%d", format.ItemName(ev.ItemID), ev.Count))
+	case engine.ItemCrafted:
+		return successStyle.Bold(true).Render(fmt.Sprintf("Crafted %s x%d", format.ItemName(ev.ItemID), ev.Count))
 	case engine.GoldGained:
-		return successStyle.Render(fmt.Sprintf("+%d gold", ev.Amount))
+		return successStyle.Render("+" + format.Int(ev.Amount) + " gold")
+	case engine.PriceQuoted:
+		return infoStyle.Render(fmt.Sprintf("%s x%d would fetch %s gold", format.ItemName(ev.ItemID), ev.Qty, format.Int(ev.Price)))
+	case engine.Haggled:
+		if ev.WalkedAway {
+			return warnStyle.Render(fmt.Sprintf("The merchant walks away; you keep your %s, but they won't haggle over it for a while", format.ItemName(ev.ItemID)))
+		}
+		return infoStyle.Render(fmt.Sprintf("You haggle %s gold to %s", format.Int(ev.Quote), format.Int(ev.Price)))
+	case engine.StatTrained:
+		return successStyle.Bold(true).Render(fmt.Sprintf("Trained %s: +%d (×%d)", ev.Stat, ev.Amount, ev.Times))
+	case engine.ItemWorn:
+		if ev.Durability == 0 {
+			return warnStyle.Render(fmt.Sprintf("%s breaks! Repair it to restore its bonus", format.ItemName(ev.ItemID)))
+		}
+		return dimStyle.Render(fmt.Sprintf("%s wears (%d/%d)", format.ItemName(ev.ItemID), ev.Durability, ev.MaxDurability))
+	case engine.ItemRepaired:
+		return successStyle.Render(fmt.Sprintf("Repaired %s (%d/%d)", format.ItemName(ev.ItemID), ev.Durability, ev.Durability))
+	case engine.TurnStarted:
+		parts := make([]string, 0, len(ev.Targets))
+		for _, t := range ev.Targets {
+			parts = append(parts, fmt.Sprintf("%d) %s %d HP", t.Index, format.PrettyID(t.EnemyID), t.HP))
+		}
+		return warnStyle.Render(fmt.Sprintf("Round %d — attack which? ", ev.Turn) + strings.Join(parts, "  "))
+	case engine.ChoiceOffered:
+		return warnStyle.Render(fmt.Sprintf("The cache holds %s gold and %s. Take %s?",
+			format.Int(ev.Gold), format.ItemName(ev.Item), strings.Join(ev.Options, " | ")))
+	case engine.MerchantOffered:
+		return warnStyle.Render(format.Merchant(ev))
+	case engine.MerchantLeft:
+		return dimStyle.Render("The caravan rolls on.")
+	case engine.GoldSpent:
+		return warnStyle.Render("-" + format.Int(ev.Amount) + " gold")
+	case engine.GoldBanked:
+		return infoStyle.Render(fmt.Sprintf("Deposited %s gold (%s banked)", format.Int(ev.Amount), format.Int(ev.Banked)))
+	case engine.GoldWithdrawn:
+		return infoStyle.Render(fmt.Sprintf("Withdrew %s gold (%s banked)", format.Int(ev.Amount), format.Int(ev.Banked)))
+	case engine.InterestAccrued:
+		return successStyle.Render(fmt.Sprintf("+%s gold interest (%s banked)", format.Int(ev.Amount), format.Int(ev.Banked)))
 	case engine.SPSpent:
 		return dimStyle.Render(fmt.Sprintf("Spent %d SP", ev.Amount))
+	case engine.BuffGained:
+		return infoStyle.Render(fmt.Sprintf("+%d %s for %d encounters", ev.Amount, ev.Stat, ev.Encounters))
+	case engine.BuffExpired:
+		return dimStyle.Render(fmt.Sprintf("Your +%d %s buff wears off", ev.Amount, ev.Stat))
+	case engine.Prestiged:
+		return successStyle.Bold(true).Render(fmt.Sprintf("Prestige %d! Back to level 1, now earning %d%% XP", ev.Prestige, ev.XPPercent))
+	case engine.WorldChanged:
+		return infoStyle.Render(fmt.Sprintf("The world shifts: %s.", engine.WorldModifiers[ev.To].Name))
+	case engine.TimeChanged:
+		return dimStyle.Render(timeChangedText(ev.To))
+	case engine.EncounterAvoided:
+		return warnStyle.Render(fmt.Sprintf("You spot a %s, far too strong for you, and slip away.", format.PrettyID(ev.EnemyID)))
+	case engine.EncounterEnded:
+		if ev.DungeonID == "" {
+			return infoStyle.Render("You slip away from the fight unseen.")
+		}
+		return infoStyle.Render(fmt.Sprintf("You slip out of %s unseen.", dungeonName(ev.DungeonID)))
+	case engine.DungeonEntered:
+		return warnStyle.Render(fmt.Sprintf("You enter %s. %d fights lie ahead.", dungeonName(ev.DungeonID), ev.Stages))
+	case engine.DungeonCleared:
+		return successStyle.Render(fmt.Sprintf("%s cleared!", dungeonName(ev.DungeonID)))
+	case engine.DungeonFailed:
+		return errorStyle.Render(fmt.Sprintf("You leave %s behind.", dungeonName(ev.DungeonID)))
+	case engine.SPRegained:
+		return infoStyle.Render(fmt.Sprintf("Regained %d SP", ev.Amount))
 	case engine.HPRestored:
 		return successStyle.Render(fmt.Sprintf("Restored %d HP", ev.Amount))
 	default:
@@ -627,26 +1450,6 @@ func formatEvent(e engine.Event) string {
 	}
 }
 
-func itemDisplayName(itemID string) string {
-	if it, ok := engine.Items[engine.NormalizeItemID(itemID)]; ok {
-		return it.Name
-	}
-	return prettyID(itemID)
-}
-
-func prettyID(id string) string {
-	parts := strings.Fields(strings.ReplaceAll(id, "_", " "))
-	for i, part := range parts {
-		runes := []rune(strings.ToLower(part))
-		if len(runes) == 0 {
-			continue
-		}
-		runes[0] = unicode.ToUpper(runes[0])
-		parts[i] = string(runes)
-	}
-	return strings.Join(parts, " ")
-}
-
 func simpleBar(cur, maxV, width int) string {
 	if maxV <= 0 {
 		return "[" + strings.Repeat(" ", width) + "]"
@@ -675,41 +1478,6 @@ func ratioBar(cur, maxV, width int) string {
 	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
 }
 
-var (
-	titleStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("14"))
-	dimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
-	errorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
-	warnStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
-	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
-	infoStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("12"))
-	promptStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("14")).Bold(true)
-
-	sidePanelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("8")).
-			Padding(0, 1)
-
-	logPanelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("8")).
-			Padding(0, 1)
-
-	inventoryPanelStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("8")).
-				Padding(0)
-
-	inputPanelStyle = lipgloss.NewStyle().
-			Border(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("8")).
-			Padding(0, 1)
-
-	footerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
-
-	outerFrameStyle = lipgloss.NewStyle().
-			Margin(outerMarginTop, outerMarginRight, outerMarginBottom, outerMarginLeft)
-)
-
 const (
 	minTerminalWidth = 60
 	minContentWidth  = minTerminalWidth - outerMarginLeft - outerMarginRight
@@ -725,9 +1493,14 @@ const (
 	introLine           = "Enter 'help' for commands."
 	eventLogTitle       = "Event Log"
 	commandsTitle       = "Commands"
-	footerHint          = "Enter: run  •  ↑/↓: history  •  PgUp/PgDn/Home/End | Wheel/Ctrl+J/K: log | line scroll  •  Ctrl+C: save & quit"
+	footerHint          = "Enter: run  •  Tab: complete  •  Ctrl+T: stats  •  ↑/↓: history  •  PgUp/PgDn/Home/End | Wheel/Ctrl+J/K: log | line scroll  •  Ctrl+C: save & quit"
 	promptExampleLine1  = "Example: help | explore | hunt 2 | rest 1"
 	promptExampleLine2  = "Use: use healing_potion | save | exit"
 	promptContentHeight = 3
 	wheelScrollLines    = 1
+	undoLimit           = 10
+	flashDuration       = 400 * time.Millisecond
 )
+
+// flashEndMsg clears the alert flash.
+type flashEndMsg struct{}
@@ -1,13 +1,40 @@
 package tui
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/divijg19/Grimoire/internal/adapters"
 	"github.com/divijg19/Grimoire/internal/engine"
 )
 
+type memStore struct {
+	saved *engine.State
+	saves int
+}
+
+func (s *memStore) Load() (*engine.State, error) {
+	state := engine.DefaultState()
+	return &state, nil
+}
+
+func (s *memStore) Save(state *engine.State) error {
+	copied := *state
+	s.saved = &copied
+	s.saves++
+	return nil
+}
+
+func (s *memStore) Archive() (string, error) {
+	s.saved = nil
+	return "", nil
+}
+
 func TestSplitColumnOuterWidths_PreservesWidth(t *testing.T) {
 	for total := 60; total <= 120; total++ {
 		left, right := splitColumnOuterWidths(total)
@@ -148,3 +175,274 @@ func TestRenderResizeWarning_StaysWithinTerminalWidth(t *testing.T) {
 		t.Fatalf("resize warning overflowed terminal width: got=%d max=%d", w, termWidth)
 	}
 }
+
+func TestExecute_UndoRestoresPreHuntState(t *testing.T) {
+	state := engine.DefaultState()
+	store := &memStore{}
+	m := newModel(&state, store, adapters.NewSeededMathRNG(7))
+
+	before := state.Player
+	m.execute("hunt 1")
+	if state.Player.SP == before.SP {
+		t.Fatalf("expected hunt to spend SP")
+	}
+
+	m.execute("undo")
+	if state.Player.SP != before.SP || state.Player.XP != before.XP || state.Player.Gold != before.Gold {
+		t.Fatalf("undo did not restore stats: sp=%d xp=%d gold=%d", state.Player.SP, state.Player.XP, state.Player.Gold)
+	}
+	if store.saved == nil || store.saved.Player.SP != before.SP {
+		t.Fatalf("expected restored state to be saved")
+	}
+	if len(m.undoStack) != 0 {
+		t.Fatalf("expected undo stack drained, got %d", len(m.undoStack))
+	}
+}
+
+func TestExecute_NonMutatingCommandsSkipUndo(t *testing.T) {
+	state := engine.DefaultState()
+	m := newModel(&state, &memStore{}, adapters.NewSeededMathRNG(7))
+
+	m.execute("status")
+	m.execute("help")
+	m.execute("rest 99")
+	if len(m.undoStack) != 0 {
+		t.Fatalf("expected no undo history, got %d", len(m.undoStack))
+	}
+}
+
+func TestHandle_FlashesOnHighPriorityUnlessBellOff(t *testing.T) {
+	state := engine.DefaultState()
+	m := newModel(&state, &memStore{}, nil)
+
+	m.handle(engine.Events{engine.GoldGained{Amount: 5}}, nil)
+	if m.flashing {
+		t.Fatal("expected no flash for a normal event")
+	}
+	m.handle(engine.Events{engine.LevelUp{NewLevel: 2}}, nil)
+	if !m.flashing {
+		t.Fatal("expected flash on level up")
+	}
+
+	m.flashing = false
+	m.execute("bell off")
+	m.handle(engine.Events{engine.LevelUp{NewLevel: 3}}, nil)
+	if m.flashing {
+		t.Fatal("expected no flash with the bell off")
+	}
+}
+
+func TestExecute_SellAllAsksFirst(t *testing.T) {
+	state := engine.DefaultState()
+	m := newModel(&state, &memStore{}, adapters.NewSeededMathRNG(7))
+
+	m.execute("sell all")
+	m.execute("n")
+	if len(state.Player.Inventory) == 0 {
+		t.Fatal("expected nothing sold after declining")
+	}
+
+	m.execute("sell all")
+	m.execute("y")
+	if len(state.Player.Inventory) != 0 {
+		t.Fatalf("expected everything sold, got %v", state.Player.Inventory)
+	}
+}
+
+func TestAddLines_HonorsLogLimit(t *testing.T) {
+	state := engine.DefaultState()
+	m := newModel(&state, &memStore{}, nil)
+
+	for i := 0; i < 400; i++ {
+		m.addLines(fmt.Sprintf("line %d", i))
+	}
+	if len(m.logs) != defaultLogLimit || m.logs[0] != "line 100" {
+		t.Fatalf("expected the last %d lines kept, got %d starting %q", defaultLogLimit, len(m.logs), m.logs[0])
+	}
+
+	m.execute("scrollback 1000")
+	for i := 400; i < 1200; i++ {
+		m.addLines(fmt.Sprintf("line %d", i))
+	}
+	if len(m.logs) != 1000 {
+		t.Fatalf("expected 1000 lines kept, got %d", len(m.logs))
+	}
+	if got := len(m.windowLines()); got != logWindow {
+		t.Fatalf("expected a %d line render window, got %d", logWindow, got)
+	}
+}
+
+func TestScrollBack_SlidesWindowThroughOlderLines(t *testing.T) {
+	state := engine.DefaultState()
+	m := newModel(&state, &memStore{}, nil)
+	m.width, m.height = 120, 40
+	m.layout()
+	m.setLogLimit(1000)
+	for i := 0; i < 800; i++ {
+		m.addLines(fmt.Sprintf("line %d", i))
+	}
+
+	m.viewport.GotoTop()
+	if !m.scrollBack() || m.logOffset == 0 {
+		t.Fatal("expected the window to slide back from the top")
+	}
+	m.execute("status")
+	if m.logOffset != 0 {
+		t.Fatal("expected new output to jump back to the newest lines")
+	}
+}
+
+func TestExportLog_WritesAllKeptLines(t *testing.T) {
+	state := engine.DefaultState()
+	m := newModel(&state, &memStore{}, nil)
+	m.setLogLimit(500)
+	for i := 0; i < 450; i++ {
+		m.addLines(successStyle.Render(fmt.Sprintf("line %d", i)))
+	}
+
+	path := filepath.Join(t.TempDir(), "log.txt")
+	kept := len(m.logs)
+	m.execute("export log " + path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != kept {
+		t.Fatalf("expected %d exported lines, got %d", kept, len(lines))
+	}
+	if lines[len(lines)-1] != "line 449" || strings.Contains(string(data), "\x1b[") {
+		t.Fatalf("expected plain text ending with the newest line, got %q", lines[len(lines)-1])
+	}
+}
+
+func TestExecute_NoAutosaveOnlySavesExplicitly(t *testing.T) {
+	state := engine.DefaultState()
+	store := &memStore{}
+	m := newModel(&state, store, adapters.NewSeededMathRNG(7))
+	m.execute("autosave off")
+
+	m.execute("explore")
+	m.execute("undo")
+	if store.saves != 0 {
+		t.Fatalf("expected no saves with autosave off, got %d", store.saves)
+	}
+	if !strings.Contains(m.footer(200), "Autosave OFF") {
+		t.Fatalf("expected footer to show autosave off, got %q", m.footer(200))
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	m = updated.(model)
+	if m.quitting || !m.confirmQuit || store.saves != 0 {
+		t.Fatalf("expected first Ctrl+C to warn without saving (quitting=%v saves=%d)", m.quitting, store.saves)
+	}
+
+	m.execute("save")
+	if store.saves != 1 || m.dirty {
+		t.Fatalf("expected one explicit save, got %d (dirty=%v)", store.saves, m.dirty)
+	}
+}
+
+func TestUsable_RequiresTerminal(t *testing.T) {
+	if !Usable(func(uintptr) bool { return true }) {
+		t.Fatalf("expected the TUI on a terminal")
+	}
+	if Usable(func(uintptr) bool { return false }) {
+		t.Fatalf("expected the CLI fallback without a terminal")
+	}
+	stdout := os.Stdout.Fd()
+	if Usable(func(fd uintptr) bool { return fd != stdout }) {
+		t.Fatalf("expected the CLI fallback when stdout is piped")
+	}
+}
+
+func TestSizeTimeout_FallsBackToFixedSize(t *testing.T) {
+	state := engine.DefaultState()
+	m := newModel(&state, nil, nil)
+	if got := m.View(); got != "Loading..." {
+		t.Fatalf("expected Loading... before any size, got %q", got)
+	}
+	updated, _ := m.Update(sizeTimeoutMsg{})
+	m = updated.(model)
+	if m.width != fallbackWidth || m.height != fallbackHeight {
+		t.Fatalf("expected %dx%d fallback, got %dx%d", fallbackWidth, fallbackHeight, m.width, m.height)
+	}
+	if m.View() == "Loading..." {
+		t.Fatalf("expected a real render after the fallback")
+	}
+
+	// A size that already arrived is kept.
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	updated, _ = updated.(model).Update(sizeTimeoutMsg{})
+	if got := updated.(model); got.width != 120 {
+		t.Fatalf("expected real size kept, got width %d", got.width)
+	}
+}
+
+func TestSetupWizard_TransitionsToPlayWithChosenNameAndClass(t *testing.T) {
+	state := engine.DefaultState()
+	store := &memStore{}
+	m := newModel(&state, store, nil)
+	m.setup = newSetupWizard(state.Player)
+	m.width, m.height = 100, 40
+
+	var updated tea.Model = m
+	send := func(msg tea.Msg) {
+		updated, _ = updated.Update(msg)
+	}
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Morgana")})
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(updated.View(), "Choose a class") {
+		t.Fatalf("expected class step, got:\n%s", updated.View())
+	}
+
+	// Classes are listed by ID; step to mage from the current class.
+	w := updated.(model).setup
+	for w.classes[w.cursor].ID != "mage" {
+		send(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	got := updated.(model)
+	if got.setup != nil {
+		t.Fatal("wizard should close once setup completes")
+	}
+	if state.Player.Name != "Morgana" || state.Player.Class != "Mage" {
+		t.Fatalf("player = %q the %q, want Morgana the Mage", state.Player.Name, state.Player.Class)
+	}
+	if store.saved == nil || store.saved.Player.Name != "Morgana" {
+		t.Fatal("expected the new character to be saved")
+	}
+	if strings.Contains(got.View(), "Choose a class") {
+		t.Fatal("play screen should replace the wizard")
+	}
+}
+
+func TestSetupWizard_BlankNameKeepsDefault(t *testing.T) {
+	state := engine.DefaultState()
+	m := newModel(&state, &memStore{}, nil)
+	m.setup = newSetupWizard(state.Player)
+
+	var updated tea.Model = m
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if state.Player.Name != "Traveller" || state.Player.Class != "Adventurer" {
+		t.Fatalf("player = %q the %q, want defaults", state.Player.Name, state.Player.Class)
+	}
+}
+
+func TestExecute_EmptyReplyDeclines(t *testing.T) {
+	state := engine.DefaultState()
+	m := newModel(&state, &memStore{}, adapters.NewSeededMathRNG(7))
+
+	m.execute("sell all")
+	if m.confirm == nil || !strings.Contains(m.confirmPrompt, "(y/N)") {
+		t.Fatalf("expected a pending confirmation, got %q", m.confirmPrompt)
+	}
+	m.execute("")
+	if m.confirm != nil || len(state.Player.Inventory) == 0 {
+		t.Fatalf("expected an empty reply to decline, inventory %v", state.Player.Inventory)
+	}
+}
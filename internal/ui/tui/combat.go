@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+	"github.com/divijg19/Grimoire/internal/ui/format"
+)
+
+// formatEvents renders events as log lines, folding each encounter's
+// blow-by-blow combat events into one compact block.
+func formatEvents(events engine.Events) []string {
+	var lines []string
+	for i := 0; i < len(events); {
+		if _, ok := events[i].(engine.EncounterStarted); ok {
+			n := combatRun(events[i:])
+			lines = append(lines, combatBlock(events[i:i+n])...)
+			i += n
+			continue
+		}
+		lines = append(lines, formatEvent(events[i]))
+		i++
+	}
+	return lines
+}
+
+// combatRun returns how many leading events belong to one fight.
+func combatRun(events engine.Events) int {
+	for i, ev := range events {
+		switch ev.(type) {
+		case engine.EncounterStarted, engine.DamageDealt, engine.EnemyDefeated:
+		case engine.CombatStalemate, engine.PlayerDefeated:
+			return i + 1
+		default:
+			return i
+		}
+	}
+	return len(events)
+}
+
+// combatBlock summarizes one fight: a header naming the enemies, then one
+// line per round with both sides' damage and running HP, then the outcome.
+func combatBlock(events engine.Events) []string {
+	var (
+		names []string
+		lines []string
+		round strings.Builder
+		n     int
+	)
+	flush := func() {
+		if round.Len() > 0 {
+			lines = append(lines, round.String())
+			round.Reset()
+		}
+	}
+
+	for _, e := range events {
+		switch ev := e.(type) {
+		case engine.EncounterStarted:
+			names = append(names, format.PrettyID(ev.EnemyID))
+		case engine.DamageDealt:
+			if ev.Source == "player" {
+				flush()
+				n++
+				fmt.Fprintf(&round, "  R%-2d you %d → %s %d", n, ev.Amount, format.PrettyID(ev.Target), ev.HPLeft)
+			} else {
+				fmt.Fprintf(&round, " │ %s %d → you %d", format.PrettyID(ev.Source), ev.Amount, ev.HPLeft)
+			}
+		default:
+			flush()
+			lines = append(lines, formatEvent(e))
+		}
+	}
+	flush()
+
+	header := warnStyle.Render("Encounter: " + strings.Join(names, ", "))
+	return append([]string{header}, lines...)
+}
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+func TestFormatEvents_FoldsCombatIntoRounds(t *testing.T) {
+	events := engine.Events{
+		engine.EncounterStarted{EnemyID: "goblin"},
+		engine.DamageDealt{Source: "player", Target: "goblin", Amount: 3, HPLeft: 5},
+		engine.DamageDealt{Source: "goblin", Target: "player", Amount: 2, HPLeft: 98},
+		engine.DamageDealt{Source: "player", Target: "goblin", Amount: 5, HPLeft: 0},
+		engine.EnemyDefeated{EnemyID: "goblin", XP: 5, Gold: 3},
+		engine.XPGained{Amount: 5},
+	}
+
+	var got []string
+	for _, line := range formatEvents(events) {
+		got = append(got, ansi.Strip(line))
+	}
+	want := []string{
+		"Encounter: Goblin",
+		"  R1  you 3 → Goblin 5 │ Goblin 2 → you 98",
+		"  R2  you 5 → Goblin 0",
+		"Defeated Goblin • +5 XP • +3 gold",
+		ansi.Strip(formatEvent(engine.XPGained{Amount: 5})),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected lines:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestCombatRun_StopsAtTerminalEvent(t *testing.T) {
+	events := engine.Events{
+		engine.EncounterStarted{EnemyID: "wolf"},
+		engine.EncounterStarted{EnemyID: "wolf"},
+		engine.DamageDealt{Source: "wolf", Target: "player", Amount: 9, HPLeft: 0},
+		engine.PlayerDefeated{},
+		engine.GoldGained{Amount: 1},
+	}
+	if n := combatRun(events); n != 4 {
+		t.Fatalf("expected the fight to span 4 events, got %d", n)
+	}
+}
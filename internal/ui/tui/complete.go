@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/divijg19/Grimoire/internal/ui/commands"
+)
+
+// completionCommands are the command words Tab completes.
+var completionCommands = commands.Words(true)
+
+// itemArgCommands take an inventory item ID as their first argument.
+var itemArgCommands = map[string]bool{
+	"use":     true,
+	"equip":   true,
+	"examine": true,
+}
+
+// completeInput expands the last token of value. Commands complete against
+// completionCommands, item arguments against the inventory. A unique match
+// is filled in; an ambiguous prefix is extended to the longest shared prefix
+// and the candidates are returned so the UI can list them.
+func completeInput(value string, inventory map[string]int) (string, []string) {
+	fields := strings.Fields(value)
+	trailingSpace := strings.HasSuffix(value, " ")
+
+	var (
+		head       string
+		token      string
+		candidates []string
+		suffix     string
+	)
+	switch {
+	case len(fields) == 1 && !trailingSpace:
+		token = fields[0]
+		candidates = completionCommands
+		suffix = " "
+	case len(fields) >= 1 && itemArgCommands[fields[0]] &&
+		((len(fields) == 1 && trailingSpace) || (len(fields) == 2 && !trailingSpace)):
+		head = fields[0] + " "
+		if len(fields) == 2 {
+			token = fields[1]
+		}
+		candidates = sortedKeys(inventory)
+	default:
+		return value, nil
+	}
+
+	var matches []string
+	for _, cand := range candidates {
+		if strings.HasPrefix(cand, token) {
+			matches = append(matches, cand)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return value, nil
+	case 1:
+		return head + matches[0] + suffix, nil
+	default:
+		return head + longestCommonPrefix(matches), matches
+	}
+}
+
+// completionHint returns the untyped remainder of a unique completion, shown
+// as ghost text after the cursor.
+func completionHint(value string, inventory map[string]int) string {
+	if value == "" {
+		return ""
+	}
+	completed, options := completeInput(value, inventory)
+	if len(options) > 0 || !strings.HasPrefix(completed, value) {
+		return ""
+	}
+	return strings.TrimRight(completed[len(value):], " ")
+}
+
+func longestCommonPrefix(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	prefix := words[0]
+	for _, w := range words[1:] {
+		for !strings.HasPrefix(w, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
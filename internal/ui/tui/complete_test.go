@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestCompleteInput(t *testing.T) {
+	inv := map[string]int{"healing_potion": 2, "hunting_horn": 1, "torch": 1}
+
+	tests := []struct {
+		in      string
+		want    string
+		options []string
+	}{
+		{in: "exp", want: "explore "},
+		{in: "hu", want: "hunt "},
+		{in: "ex", want: "ex", options: []string{"explore", "exit"}},
+		{in: "use h", want: "use h", options: []string{"healing_potion", "hunting_horn"}},
+		{in: "use he", want: "use healing_potion"},
+		{in: "use t", want: "use torch"},
+		{in: "use ", want: "use ", options: []string{"healing_potion", "hunting_horn", "torch"}},
+		{in: "zzz", want: "zzz"},
+		{in: "hunt 2", want: "hunt 2"},
+		{in: "", want: ""},
+	}
+
+	for _, tc := range tests {
+		got, options := completeInput(tc.in, inv)
+		if got != tc.want {
+			t.Fatalf("completeInput(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+		if !reflect.DeepEqual(options, tc.options) {
+			t.Fatalf("completeInput(%q) options = %v, want %v", tc.in, options, tc.options)
+		}
+	}
+}
+
+func TestCompletionHint_ShowsUniqueRemainder(t *testing.T) {
+	inv := map[string]int{"healing_potion": 1}
+	if got := completionHint("exp", inv); got != "lore" {
+		t.Fatalf("expected ghost hint %q, got %q", "lore", got)
+	}
+	if got := completionHint("ex", inv); got != "" {
+		t.Fatalf("expected no hint for ambiguous prefix, got %q", got)
+	}
+	if got := completionHint("use heal", inv); got != "ing_potion" {
+		t.Fatalf("expected item hint, got %q", got)
+	}
+}
+
+func TestRenderInputPanelWithHint_KeepsWidth(t *testing.T) {
+	plain := renderInputPanel(40, "exp")
+	hinted := renderInputPanelWithHint(40, "exp", strings.Repeat("lore", 20))
+	if lipgloss.Width(hinted) != lipgloss.Width(plain) || lipgloss.Height(hinted) != lipgloss.Height(plain) {
+		t.Fatalf("hint changed panel size: plain=%dx%d hinted=%dx%d",
+			lipgloss.Width(plain), lipgloss.Height(plain), lipgloss.Width(hinted), lipgloss.Height(hinted))
+	}
+}
@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"os"
+	"time"
+)
+
+// ================================
+// Terminal Fallback
+// ================================
+
+// sizeTimeout is how long the model waits for a WindowSizeMsg before
+// rendering at fallbackWidth×fallbackHeight instead.
+const (
+	sizeTimeout    = 500 * time.Millisecond
+	fallbackWidth  = 80
+	fallbackHeight = 24
+)
+
+// sizeTimeoutMsg fires sizeTimeout after start.
+type sizeTimeoutMsg struct{}
+
+// Usable reports whether the TUI can run: both stdin and stdout must be
+// terminals by isTerminal (typically term.IsTerminal). Piped or CI runs
+// should use the CLI instead.
+func Usable(isTerminal func(fd uintptr) bool) bool {
+	return isTerminal(os.Stdin.Fd()) && isTerminal(os.Stdout.Fd())
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// defaultLogLimit is how many log lines the model keeps by default.
+	defaultLogLimit = 300
+
+	// logWindow is how many of the kept lines the viewport renders at once;
+	// scrolling past either edge slides the window through the rest.
+	logWindow = 300
+)
+
+// windowLines returns the slice of logs the viewport currently renders.
+func (m *model) windowLines() []string {
+	end := len(m.logs) - m.logOffset
+	start := max(end-logWindow, 0)
+	return m.logs[start:end]
+}
+
+// scrollBack slides the render window toward older lines once the viewport
+// is at its top. It reports whether the window moved.
+func (m *model) scrollBack() bool {
+	if !m.viewport.AtTop() || len(m.logs)-m.logOffset <= logWindow {
+		return false
+	}
+	shift := min(logWindow/2, len(m.logs)-m.logOffset-logWindow)
+	m.logOffset += shift
+	m.refreshViewportContent()
+	m.viewport.SetYOffset(m.wrappedHeight(m.windowLines()[:shift]))
+	return true
+}
+
+// scrollForward slides the render window back toward the newest lines once
+// the viewport is at its bottom. It reports whether the window moved.
+func (m *model) scrollForward() bool {
+	if !m.viewport.AtBottom() || m.logOffset == 0 {
+		return false
+	}
+	shift := min(logWindow/2, m.logOffset)
+	keep := m.windowLines()[shift:]
+	m.logOffset -= shift
+	m.refreshViewportContent()
+	m.viewport.SetYOffset(m.wrappedHeight(keep) - m.viewport.Height)
+	return true
+}
+
+// wrappedHeight is how many viewport rows lines take once wrapped.
+func (m *model) wrappedHeight(lines []string) int {
+	if len(lines) == 0 {
+		return 0
+	}
+	if m.viewport.Width <= 0 {
+		return len(lines)
+	}
+	return strings.Count(wrapLogLines(lines, m.viewport.Width), "\n") + 1
+}
+
+// setLogLimit changes how many lines are kept, dropping the oldest.
+func (m *model) setLogLimit(n int) {
+	m.logLimit = n
+	if len(m.logs) > n {
+		m.logs = m.logs[len(m.logs)-n:]
+	}
+	m.logOffset = 0
+	m.refreshViewportContent()
+	m.viewport.GotoBottom()
+}
+
+// exportLog writes every kept log line, without styling, to path.
+func (m *model) exportLog(path string) error {
+	var b strings.Builder
+	for _, line := range m.logs {
+		b.WriteString(ansi.Strip(line))
+		b.WriteByte('\n')
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
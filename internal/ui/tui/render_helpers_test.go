@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/divijg19/Grimoire/internal/engine"
 )
 
@@ -24,13 +25,6 @@ func TestTruncateText_AppendsEllipsis(t *testing.T) {
 	}
 }
 
-func TestItemDisplayName_NormalizesLookup(t *testing.T) {
-	got := itemDisplayName("Healing Potion")
-	if got != engine.Items["healing_potion"].Name {
-		t.Fatalf("unexpected display name %q", got)
-	}
-}
-
 func TestResizeMessage_ContainsBounds(t *testing.T) {
 	msg := resizeMessage(58, 18, 60, 20)
 	if !strings.Contains(msg, "58x18") || !strings.Contains(msg, "60x20") {
@@ -50,3 +44,74 @@ func TestFormatEvent_ExplorationTreasureText(t *testing.T) {
 		t.Fatalf("unexpected formatted event text: %q", msg)
 	}
 }
+
+func TestFormatEvent_LootSummaryUsesDisplayNames(t *testing.T) {
+	msg := formatEvent(engine.LootFound{Items: []string{"rusty_dagger", "healing_potion"}})
+	if !strings.Contains(msg, "Loot: Rusty Dagger, Healing Potion") {
+		t.Fatalf("unexpected loot summary text: %q", msg)
+	}
+}
+
+func TestRenderBestiaryPanel_HidesUnseenEnemies(t *testing.T) {
+	state := engine.DefaultState()
+	state.Bestiary = map[string]engine.BestiaryEntry{"goblin": {Seen: 2, Killed: 1}}
+
+	out := renderBestiaryPanel(&state, 30, 20)
+	if !strings.Contains(out, "Goblin") {
+		t.Fatalf("expected goblin listed, got:\n%s", out)
+	}
+	if strings.Contains(out, "Orc") {
+		t.Fatalf("expected unseen orc hidden, got:\n%s", out)
+	}
+	if got := strings.Count(out, "???"); got != len(engine.Enemies)-1 {
+		t.Fatalf("expected %d hidden entries, got %d", len(engine.Enemies)-1, got)
+	}
+}
+
+func TestRenderBestiaryPanel_StaysWithinHeight(t *testing.T) {
+	state := engine.DefaultState()
+	state.Bestiary = map[string]engine.BestiaryEntry{}
+	for id := range engine.Enemies {
+		state.Bestiary[id] = engine.BestiaryEntry{Seen: 1}
+	}
+
+	out := renderBestiaryPanel(&state, 24, 6)
+	if h := lipgloss.Height(out); h != 6+inventoryPanelStyle.GetVerticalFrameSize() {
+		t.Fatalf("expected panel height %d, got %d", 6+inventoryPanelStyle.GetVerticalFrameSize(), h)
+	}
+}
+
+func TestTruncateText_WideRunesStayWithinWidth(t *testing.T) {
+	inputs := []string{
+		"宝剣宝剣宝剣宝剣",
+		"a宝b剣c🗡️d🛡️e",
+		"👨‍👩‍👧 family 👨‍👩‍👧",
+		successStyle.Render("古代のコイン x3"),
+	}
+	for _, in := range inputs {
+		for width := 1; width <= 12; width++ {
+			got := truncateText(in, width)
+			if w := lipgloss.Width(got); w > width {
+				t.Fatalf("truncateText(%q, %d) = %q has width %d", in, width, got, w)
+			}
+		}
+	}
+	if got := truncateText("宝剣宝剣", 5); got != "宝剣…" {
+		t.Fatalf("expected whole wide runes before the ellipsis, got %q", got)
+	}
+}
+
+func TestFormatEvents_CollapsesLevelUpRunIntoOneLine(t *testing.T) {
+	events := engine.CollapseLevelUps(engine.Events{
+		engine.LevelUp{NewLevel: 2, NewMaxHP: 110},
+		engine.LevelUp{NewLevel: 3, NewMaxHP: 120},
+		engine.LevelUp{NewLevel: 4, NewMaxHP: 130},
+	})
+	lines := formatEvents(events)
+	if len(lines) != 1 {
+		t.Fatalf("expected one summary line, got %q", lines)
+	}
+	if !strings.Contains(lines[0], "Leveled up x3! Now level 4, Max HP 130") {
+		t.Fatalf("unexpected summary %q", lines[0])
+	}
+}
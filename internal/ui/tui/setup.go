@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+// ================================
+// New Game Setup
+// ================================
+
+type setupStep int
+
+const (
+	setupName setupStep = iota
+	setupClass
+)
+
+// setupWizard collects a name and class before a brand-new game starts.
+// While it is set on the model, Update and View route here instead.
+type setupWizard struct {
+	step    setupStep
+	name    textinput.Model
+	classes []engine.Class
+	cursor  int
+}
+
+func newSetupWizard(p engine.Player) *setupWizard {
+	name := textinput.New()
+	name.Placeholder = p.Name
+	name.Prompt = "❯ "
+	name.CharLimit = 24
+	name.Focus()
+
+	w := &setupWizard{name: name}
+	for _, id := range engine.ClassIDs() {
+		c := engine.Classes[id]
+		if strings.EqualFold(c.Name, p.Class) {
+			w.cursor = len(w.classes)
+		}
+		w.classes = append(w.classes, c)
+	}
+	return w
+}
+
+// chosenName is the typed name, or the placeholder when left blank.
+func (w *setupWizard) chosenName() string {
+	if name := strings.TrimSpace(w.name.Value()); name != "" {
+		return name
+	}
+	return w.name.Placeholder
+}
+
+func (m model) updateSetup(msg tea.Msg) (tea.Model, tea.Cmd) {
+	w := m.setup
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.layout()
+		return m, nil
+
+	case sizeTimeoutMsg:
+		if m.width == 0 || m.height == 0 {
+			m.width, m.height = fallbackWidth, fallbackHeight
+			m.layout()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			// Nothing has been played yet, so there is nothing to save.
+			m.quitting = true
+			return m, tea.Quit
+
+		case "enter":
+			if w.step == setupName {
+				w.step = setupClass
+				w.name.Blur()
+				return m, nil
+			}
+			m.finishSetup()
+			return m, nil
+
+		case "esc":
+			if w.step == setupClass {
+				w.step = setupName
+				w.name.Focus()
+			}
+			return m, nil
+		}
+
+		if w.step == setupClass {
+			switch msg.String() {
+			case "up", "k":
+				w.cursor = (w.cursor + len(w.classes) - 1) % len(w.classes)
+			case "down", "j", "tab":
+				w.cursor = (w.cursor + 1) % len(w.classes)
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	w.name, cmd = w.name.Update(msg)
+	return m, cmd
+}
+
+// finishSetup seeds the player from the wizard and hands over to play.
+func (m *model) finishSetup() {
+	w := m.setup
+	m.setup = nil
+	m.state.Player.Name = w.chosenName()
+	m.state.Player.Class = w.classes[w.cursor].Name
+	m.start = m.state.Clone()
+	if m.store != nil {
+		if err := m.store.Save(m.state); err != nil {
+			m.addError("save failed: " + err.Error())
+		}
+	}
+	m.addLines(successStyle.Render(fmt.Sprintf("%s the %s sets out.", m.state.Player.Name, m.state.Player.Class)))
+}
+
+func (m model) setupView() string {
+	if m.width == 0 || m.height == 0 {
+		return "Loading..."
+	}
+	w := m.setup
+	lines := []string{titleStyle.Render("A new adventure"), ""}
+	if w.step == setupName {
+		lines = append(lines,
+			"What is your name?",
+			w.name.View(),
+			"",
+			dimStyle.Render("Enter to continue · Ctrl+C to quit"),
+		)
+	} else {
+		lines = append(lines, fmt.Sprintf("Choose a class, %s:", w.chosenName()), "")
+		for i, c := range w.classes {
+			row := fmt.Sprintf("  %-12s %s", c.Name, dimStyle.Render(c.Description))
+			if i == w.cursor {
+				row = promptStyle.Render("❯ ") + fmt.Sprintf("%-12s %s", c.Name, c.Description)
+			}
+			lines = append(lines, row)
+		}
+		lines = append(lines, "", dimStyle.Render("↑/↓ to choose · Enter to begin · Esc to go back"))
+	}
+	panel := sidePanelStyle.Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, panel)
+}
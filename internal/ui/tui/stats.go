@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+// ================================
+// Right Panel Modes
+// ================================
+
+type panelMode int
+
+const (
+	panelInventory panelMode = iota
+	panelStats
+	panelBestiary
+)
+
+// ================================
+// Stats History
+// ================================
+
+// historyLimit bounds how many samples the stats panel keeps per series.
+const historyLimit = 64
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// statSeries holds recent HP and gold values, sampled after each command.
+type statSeries struct {
+	hp   []int
+	gold []int
+}
+
+func (s *statSeries) sample(state *engine.State) {
+	s.hp = appendBounded(s.hp, state.Player.HP)
+	s.gold = appendBounded(s.gold, state.Player.Gold)
+}
+
+func appendBounded(series []int, v int) []int {
+	series = append(series, v)
+	if len(series) > historyLimit {
+		series = series[len(series)-historyLimit:]
+	}
+	return series
+}
+
+// sparkline renders the newest width values of series as block characters
+// scaled between the series minimum and maximum. The result is always
+// exactly width cells; short series are padded on the left.
+func sparkline(series []int, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if len(series) > width {
+		series = series[len(series)-width:]
+	}
+	if len(series) == 0 {
+		return strings.Repeat(" ", width)
+	}
+
+	lo, hi := series[0], series[0]
+	for _, v := range series {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", width-len(series)))
+	top := len(sparkBlocks) - 1
+	for _, v := range series {
+		idx := top
+		if hi > lo {
+			idx = (v - lo) * top / (hi - lo)
+		}
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
+
+// renderStatsPanel draws HP and gold sparklines in the right column.
+func renderStatsPanel(state *engine.State, series statSeries, outerWidth, contentHeight int) string {
+	contentWidth := max(1, outerWidth-inventoryPanelStyle.GetHorizontalFrameSize())
+	p := state.Player
+	lines := []string{
+		titleStyle.Render("Stats"),
+		"",
+		truncateText(fmt.Sprintf("HP %d/%d", p.HP, p.MaxHP), contentWidth),
+		successStyle.Render(sparkline(series.hp, contentWidth)),
+		"",
+		truncateText(fmt.Sprintf("Gold %d", p.Gold), contentWidth),
+		warnStyle.Render(sparkline(series.gold, contentWidth)),
+	}
+	if limit := max(1, contentHeight); len(lines) > limit {
+		lines = lines[:limit]
+	}
+	return inventoryPanelStyle.Width(contentWidth).Height(max(1, contentHeight)).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/divijg19/Grimoire/internal/engine"
+)
+
+func TestSparkline_HasRequestedWidth(t *testing.T) {
+	series := []int{100, 80, 60, 90, 30, 100, 10}
+	for _, width := range []int{1, 3, 7, 12} {
+		if got := lipgloss.Width(sparkline(series, width)); got != width {
+			t.Fatalf("width %d: sparkline rendered %d cells", width, got)
+		}
+	}
+
+	if got := sparkline([]int{0, 5, 10}, 3); got != "▁▄█" {
+		t.Fatalf("unexpected scaling: %q", got)
+	}
+	if got := sparkline([]int{4}, 3); got != "  █" {
+		t.Fatalf("expected left padding for short series, got %q", got)
+	}
+}
+
+func TestView_StatsPanelDoesNotOverflow(t *testing.T) {
+	state := engine.DefaultState()
+	m := newModel(&state, nil, nil)
+	m.panel = panelStats
+	for i := 0; i < historyLimit+10; i++ {
+		state.Player.Gold += i * 37
+		m.series.sample(&state)
+	}
+	m.height = 20
+
+	for width := 60; width <= 120; width++ {
+		m.width = width
+		m.layout()
+		view := m.View()
+		if strings.Contains(view, "Terminal too small") {
+			continue
+		}
+		if h := lipgloss.Height(view); h > m.height {
+			t.Fatalf("stats overflow at width %d: viewHeight=%d termHeight=%d", width, h, m.height)
+		}
+		if w := lipgloss.Width(view); w > m.width {
+			t.Fatalf("stats overflow at width %d: viewWidth=%d", width, w)
+		}
+	}
+}
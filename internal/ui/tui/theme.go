@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme carries every color the TUI renders with. Styles are rebuilt from
+// the active theme, so render code never hardcodes a color.
+type Theme struct {
+	Name    string
+	Title   lipgloss.TerminalColor
+	Dim     lipgloss.TerminalColor
+	Error   lipgloss.TerminalColor
+	Warn    lipgloss.TerminalColor
+	Success lipgloss.TerminalColor
+	Info    lipgloss.TerminalColor
+	Prompt  lipgloss.TerminalColor
+	Border  lipgloss.TerminalColor
+	Footer  lipgloss.TerminalColor
+}
+
+var themes = map[string]Theme{
+	"default": {
+		Name:    "default",
+		Title:   lipgloss.Color("14"),
+		Dim:     lipgloss.Color("8"),
+		Error:   lipgloss.Color("9"),
+		Warn:    lipgloss.Color("11"),
+		Success: lipgloss.Color("10"),
+		Info:    lipgloss.Color("12"),
+		Prompt:  lipgloss.Color("14"),
+		Border:  lipgloss.Color("8"),
+		Footer:  lipgloss.Color("8"),
+	},
+	"solarized": {
+		Name:    "solarized",
+		Title:   lipgloss.Color("#2aa198"),
+		Dim:     lipgloss.Color("#586e75"),
+		Error:   lipgloss.Color("#dc322f"),
+		Warn:    lipgloss.Color("#b58900"),
+		Success: lipgloss.Color("#859900"),
+		Info:    lipgloss.Color("#268bd2"),
+		Prompt:  lipgloss.Color("#6c71c4"),
+		Border:  lipgloss.Color("#586e75"),
+		Footer:  lipgloss.Color("#586e75"),
+	},
+	"monochrome": {
+		Name:    "monochrome",
+		Title:   lipgloss.NoColor{},
+		Dim:     lipgloss.NoColor{},
+		Error:   lipgloss.NoColor{},
+		Warn:    lipgloss.NoColor{},
+		Success: lipgloss.NoColor{},
+		Info:    lipgloss.NoColor{},
+		Prompt:  lipgloss.NoColor{},
+		Border:  lipgloss.NoColor{},
+		Footer:  lipgloss.NoColor{},
+	},
+}
+
+var (
+	activeTheme Theme
+
+	titleStyle   lipgloss.Style
+	dimStyle     lipgloss.Style
+	errorStyle   lipgloss.Style
+	warnStyle    lipgloss.Style
+	successStyle lipgloss.Style
+	infoStyle    lipgloss.Style
+	promptStyle  lipgloss.Style
+
+	sidePanelStyle      lipgloss.Style
+	logPanelStyle       lipgloss.Style
+	inventoryPanelStyle lipgloss.Style
+	inputPanelStyle     lipgloss.Style
+	footerStyle         lipgloss.Style
+
+	outerFrameStyle = lipgloss.NewStyle().
+			Margin(outerMarginTop, outerMarginRight, outerMarginBottom, outerMarginLeft)
+)
+
+func init() {
+	applyTheme(themes[defaultThemeName()])
+}
+
+// defaultThemeName honors NO_COLOR by starting in monochrome.
+func defaultThemeName() string {
+	if os.Getenv("NO_COLOR") != "" {
+		return "monochrome"
+	}
+	return "default"
+}
+
+// SetTheme switches the active theme by name.
+func SetTheme(name string) error {
+	t, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q", name)
+	}
+	applyTheme(t)
+	return nil
+}
+
+func themeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func applyTheme(t Theme) {
+	activeTheme = t
+
+	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(t.Title)
+	dimStyle = lipgloss.NewStyle().Foreground(t.Dim)
+	errorStyle = lipgloss.NewStyle().Foreground(t.Error)
+	warnStyle = lipgloss.NewStyle().Foreground(t.Warn)
+	successStyle = lipgloss.NewStyle().Foreground(t.Success)
+	infoStyle = lipgloss.NewStyle().Foreground(t.Info)
+	promptStyle = lipgloss.NewStyle().Foreground(t.Prompt).Bold(true)
+
+	sidePanelStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(0, 1)
+
+	logPanelStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(0, 1)
+
+	inventoryPanelStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(0)
+
+	inputPanelStyle = lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(t.Border).
+		Padding(0, 1)
+
+	footerStyle = lipgloss.NewStyle().Foreground(t.Footer)
+}
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSetTheme_ChangesTitleAndErrorColors(t *testing.T) {
+	defer applyTheme(themes["default"])
+
+	if err := SetTheme("default"); err != nil {
+		t.Fatalf("SetTheme(default): %v", err)
+	}
+	defTitle, defError := titleStyle.GetForeground(), errorStyle.GetForeground()
+
+	if err := SetTheme("solarized"); err != nil {
+		t.Fatalf("SetTheme(solarized): %v", err)
+	}
+	if titleStyle.GetForeground() == defTitle || errorStyle.GetForeground() == defError {
+		t.Fatalf("expected solarized to change title/error colors")
+	}
+
+	if err := SetTheme("monochrome"); err != nil {
+		t.Fatalf("SetTheme(monochrome): %v", err)
+	}
+	if _, ok := titleStyle.GetForeground().(lipgloss.NoColor); !ok {
+		t.Fatalf("expected monochrome title to have no color")
+	}
+	if _, ok := errorStyle.GetForeground().(lipgloss.NoColor); !ok {
+		t.Fatalf("expected monochrome error to have no color")
+	}
+}
+
+func TestSetTheme_UnknownNameErrors(t *testing.T) {
+	if err := SetTheme("neon"); err == nil {
+		t.Fatalf("expected error for unknown theme")
+	}
+}
+
+func TestSetTheme_KeepsFrameSizes(t *testing.T) {
+	defer applyTheme(themes["default"])
+
+	want := inputPanelStyle.GetHorizontalFrameSize()
+	for _, name := range themeNames() {
+		_ = SetTheme(name)
+		if got := inputPanelStyle.GetHorizontalFrameSize(); got != want {
+			t.Fatalf("theme %s changed input frame size: %d != %d", name, got, want)
+		}
+	}
+}